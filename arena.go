@@ -0,0 +1,120 @@
+package futures
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrArenaInUse is returned by Arena.Release while some of the futures of
+// the arena are still pending.
+var ErrArenaInUse = errors.New("futures: arena has pending futures")
+
+// arenaSlabSize is the number of futures allocated at once.
+const arenaSlabSize = 64
+
+// Arena allocates futures in bulk for code that creates many short-lived
+// futures at a time, such as a request handler that fans out to a few
+// dozen backends. Pass WithArena to New and its relatives to allocate the
+// Future from the arena instead of the heap, and call Release once the
+// request is done to make the memory available for the next request.
+//
+// An Arena only saves the allocation of the Future itself; the context
+// and goroutine of each computation are allocated as usual. Measure
+// before you use it.
+type Arena struct {
+	mu    sync.Mutex
+	slabs map[reflect.Type]arenaSlab
+}
+
+// arenaSlab holds the futures of one type.
+type arenaSlab interface {
+	pending() int
+	reset()
+}
+
+// NewArena returns an empty Arena.
+func NewArena() *Arena {
+	return &Arena{slabs: map[reflect.Type]arenaSlab{}}
+}
+
+// WithArena allocates the Future from a instead of the heap.
+func WithArena(a *Arena) Option {
+	return func(o *options) {
+		o.arena = a
+	}
+}
+
+// Release makes the memory of all futures allocated from a available for
+// reuse. It fails with ErrArenaInUse if any of them is still pending, in
+// which case nothing is released.
+//
+// Release is legal only when no one uses the futures of a anymore:
+// calling it invalidates all of them, even the settled ones.
+func (a *Arena) Release() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	pending := 0
+	for _, s := range a.slabs {
+		pending += s.pending()
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: %d pending", ErrArenaInUse, pending)
+	}
+	for _, s := range a.slabs {
+		s.reset()
+	}
+	return nil
+}
+
+// slab is the arenaSlab for futures of type T.
+type slab[T any] struct {
+	chunks [][]Future[T]
+	used   int
+}
+
+// allocFuture returns a new pending Future, allocated from a if a is not
+// nil.
+func allocFuture[T any](a *Arena) *Future[T] {
+	if a == nil {
+		return rawFuture[T]()
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	key := reflect.TypeOf((*T)(nil))
+	s, ok := a.slabs[key].(*slab[T])
+	if !ok {
+		s = &slab[T]{}
+		a.slabs[key] = s
+	}
+	i := s.used
+	if i/arenaSlabSize == len(s.chunks) {
+		s.chunks = append(s.chunks, make([]Future[T], arenaSlabSize))
+	}
+	s.used++
+	f := &s.chunks[i/arenaSlabSize][i%arenaSlabSize]
+	f.core.done = make(chan struct{})
+	f.fromArena = true
+	return f
+}
+
+func (s *slab[T]) pending() int {
+	n := 0
+	for i := 0; i < s.used; i++ {
+		f := &s.chunks[i/arenaSlabSize][i%arenaSlabSize]
+		f.core.mu.Lock()
+		if !f.core.settled {
+			n++
+		}
+		f.core.mu.Unlock()
+	}
+	return n
+}
+
+func (s *slab[T]) reset() {
+	for _, c := range s.chunks {
+		clear(c)
+	}
+	s.used = 0
+}
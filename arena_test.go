@@ -0,0 +1,79 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestArena(t *testing.T) {
+	a := futures.NewArena()
+	for round := 0; round < 3; round++ {
+		var futs []*futures.Future[int]
+		for i := 0; i < 100; i++ {
+			i := i
+			futs = append(futs, futures.New(func(ctx context.Context) (int, error) {
+				return i, nil
+			}, futures.WithArena(a)))
+		}
+		for i, f := range futs {
+			if v, err := f.Get(); v != i || err != nil {
+				t.Fatalf("round %d: future %d = %d, %v", round, i, v, err)
+			}
+		}
+		if err := a.Release(); err != nil {
+			t.Fatalf("round %d: Release: %v", round, err)
+		}
+	}
+}
+
+func TestArenaReleasePending(t *testing.T) {
+	a := futures.NewArena()
+	release := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (string, error) {
+		<-release
+		return "done", nil
+	}, futures.WithArena(a))
+	futures.New(func(ctx context.Context) (int, error) { return 1, nil },
+		futures.WithArena(a), futures.WithExecutor(futures.SyncExecutor))
+
+	if err := a.Release(); !errors.Is(err, futures.ErrArenaInUse) {
+		t.Fatalf("Release with a pending future: err = %v, want ErrArenaInUse", err)
+	}
+	close(release)
+	if v, _ := f.Get(); v != "done" {
+		t.Errorf("Get after a failed Release = %q, want done", v)
+	}
+	if err := a.Release(); err != nil {
+		t.Errorf("Release: %v", err)
+	}
+}
+
+// The benchmarks simulate a request handler that creates 50 futures.
+const futuresPerRequest = 50
+
+func BenchmarkRequestPlain(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < futuresPerRequest; j++ {
+			futures.New(func(ctx context.Context) (int, error) { return j, nil },
+				futures.WithExecutor(futures.SyncExecutor))
+		}
+	}
+}
+
+func BenchmarkRequestArena(b *testing.B) {
+	b.ReportAllocs()
+	a := futures.NewArena()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < futuresPerRequest; j++ {
+			futures.New(func(ctx context.Context) (int, error) { return j, nil },
+				futures.WithExecutor(futures.SyncExecutor), futures.WithArena(a))
+		}
+		if err := a.Release(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
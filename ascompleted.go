@@ -0,0 +1,21 @@
+package futures
+
+// AsCompleted returns a Stream that delivers each of futs as soon as it
+// has settled, in completion order. The stream ends after the last Future.
+//
+// By default, the stream buffers all futures, so the producer never waits
+// for a slow consumer. Use WithResultBuffer and WithOverflow to bound the
+// buffer.
+func AsCompleted[T any](futs []*Future[T], opts ...StreamOption) *Stream[*Future[T]] {
+	s := newStream[*Future[T]](newStreamOptions(len(futs), opts))
+	go func() {
+		defer s.close(nil)
+		ch := completionOrder(futs)
+		for range futs {
+			if !s.send(<-ch) {
+				return
+			}
+		}
+	}()
+	return s
+}
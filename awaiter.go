@@ -0,0 +1,40 @@
+package futures
+
+import "context"
+
+// Awaiter reports whether anyone is interested in the result of a Future.
+// A producer can use it to skip optional work, such as enriching a result
+// that nobody is going to read.
+//
+// Both methods are cheap atomic reads. They are heuristics by nature: the
+// answer can change right after it has been returned.
+type Awaiter interface {
+	// HasWaiters reports whether a goroutine is currently blocked in Get,
+	// GetWithContext, or Wait.
+	HasWaiters() bool
+	// WasAwaited reports whether Get, GetWithContext, Wait, or Done has
+	// ever been called.
+	WasAwaited() bool
+}
+
+type awaiterKey struct{}
+
+// AwaiterFromContext returns the Awaiter of the Future whose computation
+// received ctx. It returns false if ctx does not belong to a computation
+// started by New or NewWithContext.
+func AwaiterFromContext(ctx context.Context) (Awaiter, bool) {
+	a, ok := ctx.Value(awaiterKey{}).(Awaiter)
+	return a, ok
+}
+
+// HasWaiters reports whether a goroutine is currently blocked in Get,
+// GetWithContext, or Wait. See Awaiter.
+func (f *Future[T]) HasWaiters() bool {
+	return f.core.Waiters() > 0
+}
+
+// WasAwaited reports whether Get, GetWithContext, Wait, or Done has ever
+// been called on f, or TryGet has returned its result. See Awaiter.
+func (f *Future[T]) WasAwaited() bool {
+	return f.awaited.Load()
+}
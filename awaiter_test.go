@@ -0,0 +1,99 @@
+package futures_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+// eventually polls cond until it holds or a second has passed.
+func eventually(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAwaiterTransitions(t *testing.T) {
+	paths := map[string]func(f *futures.Future[int]){
+		"Get": func(f *futures.Future[int]) { f.Get() },
+		"GetWithContext": func(f *futures.Future[int]) {
+			f.GetWithContext(context.Background())
+		},
+		"Wait": func(f *futures.Future[int]) { f.Wait(context.Background()) },
+	}
+	for name, await := range paths {
+		t.Run(name, func(t *testing.T) {
+			release := make(chan struct{})
+			f := futures.New(func(ctx context.Context) (int, error) {
+				<-release
+				return 1, nil
+			})
+			if f.WasAwaited() || f.HasWaiters() {
+				t.Fatal("fresh Future reports being awaited")
+			}
+			returned := make(chan struct{})
+			go func() {
+				await(f)
+				close(returned)
+			}()
+			eventually(t, f.HasWaiters, "HasWaiters stays false while a reader blocks")
+			if !f.WasAwaited() {
+				t.Error("WasAwaited = false while a reader blocks")
+			}
+			close(release)
+			<-returned
+			if f.HasWaiters() {
+				t.Error("HasWaiters = true after the reader returned")
+			}
+			if !f.WasAwaited() {
+				t.Error("WasAwaited = false after the reader returned")
+			}
+		})
+	}
+}
+
+func TestAwaiterDone(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	f.Done()
+	if !f.WasAwaited() {
+		t.Error("WasAwaited = false after Done")
+	}
+	if f.HasWaiters() {
+		t.Error("HasWaiters = true without a blocked reader")
+	}
+}
+
+func TestAwaiterFromContext(t *testing.T) {
+	release := make(chan struct{})
+	sawWaiter := make(chan bool, 1)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		a, ok := futures.AwaiterFromContext(ctx)
+		if !ok {
+			t.Error("AwaiterFromContext: no Awaiter in the computation's context")
+		}
+		<-release
+		sawWaiter <- a.HasWaiters()
+		return 1, nil
+	})
+	go f.Get()
+	eventually(t, f.HasWaiters, "HasWaiters stays false while a reader blocks")
+	close(release)
+	if !<-sawWaiter {
+		t.Error("HasWaiters inside the computation = false while Get blocks")
+	}
+	if _, ok := futures.AwaiterFromContext(context.Background()); ok {
+		t.Error("AwaiterFromContext found an Awaiter in a plain context")
+	}
+}
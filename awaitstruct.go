@@ -0,0 +1,178 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// anyFuture is implemented by a Future of any type. It gives reflection
+// code access to a Future without knowing its type parameter.
+type anyFuture interface {
+	waitable
+	valueType() reflect.Type
+	reflectValue() reflect.Value
+}
+
+var anyFutureType = reflect.TypeOf((*anyFuture)(nil)).Elem()
+
+func (f *Future[T]) valueType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// reflectValue returns the value of f. f must have settled.
+func (f *Future[T]) reflectValue() reflect.Value {
+	return reflect.ValueOf(&f.core.value).Elem()
+}
+
+// AwaitStructOption configures AwaitStruct.
+type AwaitStructOption func(*awaitStructOptions)
+
+type awaitStructOptions struct {
+	joinErrors bool
+}
+
+// WithJoinedErrors makes AwaitStruct wait for all futures and return the
+// errors of all failed futures joined by errors.Join, instead of returning
+// the first error.
+func WithJoinedErrors() AwaitStructOption {
+	return func(o *awaitStructOptions) {
+		o.joinErrors = true
+	}
+}
+
+// structFuture is a Future found in a struct by AwaitStruct.
+type structFuture struct {
+	path   string
+	fut    anyFuture
+	target reflect.Value // the field to assign the value to, if any
+}
+
+// AwaitStruct awaits all futures stored in the struct that ptr points to.
+//
+// AwaitStruct looks at every exported field of type *Future[T], for any T,
+// including the fields of nested structs and of non-nil pointers to
+// structs. Nil futures are skipped. All futures are awaited concurrently.
+//
+// If a future field is named with an "F" suffix and the struct has an
+// exported field with the same name minus the suffix, that field receives
+// the value of the future. For example, the value of UserF is assigned to
+// User. Such a field must be assignable from T.
+//
+// AwaitStruct returns the first error of any future, or ErrCanceled or
+// ErrTimeout if ctx is done first. In both cases, no field is assigned. Use WithJoinedErrors
+// to collect the errors of all failed futures.
+//
+// AwaitStruct is meant as a convenience for small programs and scripts;
+// it gives up compile-time type safety.
+func AwaitStruct(ctx context.Context, ptr any, opts ...AwaitStructOption) error {
+	var o awaitStructOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("futures: AwaitStruct needs a non-nil pointer to a struct, got %T", ptr)
+	}
+	var futs []structFuture
+	visited := map[uintptr]bool{rv.Pointer(): true}
+	if err := collectFutures(rv.Elem(), "", visited, &futs); err != nil {
+		return err
+	}
+
+	// Canceling ctx on return releases the goroutines below when
+	// AwaitStruct returns early with the first error.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errc := make(chan error, len(futs))
+	for _, sf := range futs {
+		go func(sf structFuture) {
+			select {
+			case <-sf.fut.Done():
+				if err := sf.fut.Err(); err != nil {
+					errc <- fmt.Errorf("%s: %w", sf.path, err)
+					return
+				}
+				errc <- nil
+			case <-ctx.Done():
+				errc <- contextError(ctx)
+			}
+		}(sf)
+	}
+	var errs []error
+	for range futs {
+		if err := <-errc; err != nil {
+			if !o.joinErrors {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, sf := range futs {
+		if sf.target.IsValid() {
+			sf.target.Set(sf.fut.reflectValue())
+		}
+	}
+	return nil
+}
+
+// collectFutures appends the futures found in the struct v to futs.
+func collectFutures(v reflect.Value, prefix string, visited map[uintptr]bool, futs *[]structFuture) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		path := prefix + sf.Name
+		switch {
+		case sf.Type.Kind() == reflect.Pointer && sf.Type.Implements(anyFutureType):
+			if fv.IsNil() {
+				continue
+			}
+			fut := fv.Interface().(anyFuture)
+			target, err := futureTarget(v, sf.Name, fut, path)
+			if err != nil {
+				return err
+			}
+			*futs = append(*futs, structFuture{path: path, fut: fut, target: target})
+		case sf.Type.Kind() == reflect.Struct:
+			if err := collectFutures(fv, path+".", visited, futs); err != nil {
+				return err
+			}
+		case sf.Type.Kind() == reflect.Pointer && sf.Type.Elem().Kind() == reflect.Struct:
+			if fv.IsNil() || visited[fv.Pointer()] {
+				continue
+			}
+			visited[fv.Pointer()] = true
+			if err := collectFutures(fv.Elem(), path+".", visited, futs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// futureTarget returns the field of v that receives the value of the
+// future stored in the field name, or an invalid Value if there is none.
+func futureTarget(v reflect.Value, name string, fut anyFuture, path string) (reflect.Value, error) {
+	base, ok := strings.CutSuffix(name, "F")
+	if !ok || base == "" {
+		return reflect.Value{}, nil
+	}
+	sf, ok := v.Type().FieldByName(base)
+	if !ok || !sf.IsExported() {
+		return reflect.Value{}, nil
+	}
+	if !fut.valueType().AssignableTo(sf.Type) {
+		return reflect.Value{}, fmt.Errorf("futures: cannot assign %s (%s) to field %s (%s)", path, fut.valueType(), base, sf.Type)
+	}
+	return v.FieldByIndex(sf.Index), nil
+}
@@ -0,0 +1,120 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+type profile struct {
+	AgeF *futures.Future[int]
+	Age  int
+}
+
+type page struct {
+	UserF   *futures.Future[string]
+	User    string
+	Profile profile
+	Extra   *profile
+	ErrF    *futures.Future[error]
+	Err     error
+	NilF    *futures.Future[int]
+	Nil     int
+	OtherF  *futures.Future[int] // no matching field
+}
+
+func TestAwaitStruct(t *testing.T) {
+	p := page{
+		UserF:   futures.Completed("gopher"),
+		Profile: profile{AgeF: futures.New(func(ctx context.Context) (int, error) { return 13, nil })},
+		Extra:   &profile{AgeF: futures.Completed(7)},
+		ErrF:    futures.Completed[error](nil),
+		OtherF:  futures.Completed(1),
+		Nil:     -1,
+	}
+	if err := futures.AwaitStruct(context.Background(), &p); err != nil {
+		t.Fatalf("AwaitStruct: %v", err)
+	}
+	if p.User != "gopher" {
+		t.Errorf("User = %q, want gopher", p.User)
+	}
+	if p.Profile.Age != 13 {
+		t.Errorf("Profile.Age = %d, want 13", p.Profile.Age)
+	}
+	if p.Extra.Age != 7 {
+		t.Errorf("Extra.Age = %d, want 7", p.Extra.Age)
+	}
+	if p.Nil != -1 {
+		t.Errorf("Nil = %d; a nil future must leave its field alone", p.Nil)
+	}
+}
+
+func TestAwaitStructTypeMismatch(t *testing.T) {
+	s := struct {
+		CountF *futures.Future[int]
+		Count  string
+	}{CountF: futures.Completed(1)}
+	err := futures.AwaitStruct(context.Background(), &s)
+	if err == nil || !strings.Contains(err.Error(), "cannot assign") {
+		t.Fatalf("AwaitStruct: err = %v, want a type mismatch error", err)
+	}
+}
+
+func TestAwaitStructErrors(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	s := struct {
+		AF, BF *futures.Future[int]
+		A, B   int
+	}{AF: futures.Failed[int](errA), BF: futures.Failed[int](errB), A: -1}
+
+	err := futures.AwaitStruct(context.Background(), &s)
+	if !errors.Is(err, errA) && !errors.Is(err, errB) {
+		t.Fatalf("AwaitStruct: err = %v, want a or b", err)
+	}
+	if s.A != -1 {
+		t.Error("a field was assigned although AwaitStruct failed")
+	}
+
+	err = futures.AwaitStruct(context.Background(), &s, futures.WithJoinedErrors())
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("AwaitStruct with joined errors: err = %v, want both a and b", err)
+	}
+}
+
+func TestAwaitStructContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	s := struct{ XF *futures.Future[int] }{
+		XF: futures.New(func(ctx context.Context) (int, error) {
+			<-release
+			return 1, nil
+		}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := futures.AwaitStruct(ctx, &s); !errors.Is(err, context.Canceled) {
+		t.Fatalf("AwaitStruct: err = %v, want context.Canceled", err)
+	}
+}
+
+func TestAwaitStructEmbeddingTypes(t *testing.T) {
+	// A DiagnosticFuture value embeds *Future and must not be mistaken for
+	// a pointer to a Future.
+	s := struct {
+		DF futures.DiagnosticFuture[int]
+	}{DF: futures.DiagnosticFuture[int]{Future: futures.Completed(1)}}
+	if err := futures.AwaitStruct(context.Background(), &s); err != nil {
+		t.Fatalf("AwaitStruct: %v", err)
+	}
+}
+
+func TestAwaitStructBadArgument(t *testing.T) {
+	for _, arg := range []any{nil, 1, page{}, (*page)(nil)} {
+		if err := futures.AwaitStruct(context.Background(), arg); err == nil {
+			t.Errorf("AwaitStruct(%T) = nil, want an error", arg)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package futures
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffPolicy decides how long to wait before retrying.
+type BackoffPolicy interface {
+	// Delay returns the time to wait after the given failed attempt, or
+	// a negative duration to stop retrying. Attempts are counted from 1.
+	Delay(attempt int) time.Duration
+}
+
+// Backoff is a BackoffPolicy whose delay grows exponentially from a base
+// delay up to a maximum, optionally with random jitter and a limited
+// number of attempts.
+type Backoff struct {
+	base        time.Duration
+	multiplier  float64
+	maxDelay    time.Duration
+	jitter      float64
+	maxAttempts int
+}
+
+// ExponentialBackoff returns a policy that waits base after the first
+// attempt and multiplier times longer after each further attempt, but never
+// longer than maxDelay. A maxDelay of 0 means no maximum.
+func ExponentialBackoff(base time.Duration, multiplier float64, maxDelay time.Duration) *Backoff {
+	return &Backoff{base: base, multiplier: multiplier, maxDelay: maxDelay}
+}
+
+// ConstantBackoff returns a policy that always waits delay.
+func ConstantBackoff(delay time.Duration) *Backoff {
+	return &Backoff{base: delay, multiplier: 1}
+}
+
+// WithJitter returns a copy of b that randomly varies each delay by up to
+// ±fraction of its length. Jitter keeps many clients that failed at the
+// same time from retrying at the same time.
+func (b *Backoff) WithJitter(fraction float64) *Backoff {
+	c := *b
+	c.jitter = math.Max(0, math.Min(fraction, 1))
+	return &c
+}
+
+// WithMaxAttempts returns a copy of b that stops retrying after n
+// attempts in total. An n below 1 means no limit.
+func (b *Backoff) WithMaxAttempts(n int) *Backoff {
+	c := *b
+	c.maxAttempts = max(n, 0)
+	return &c
+}
+
+// Delay implements BackoffPolicy. The maximum delay applies after jitter,
+// so no delay exceeds it.
+func (b *Backoff) Delay(attempt int) time.Duration {
+	if b.maxAttempts > 0 && attempt >= b.maxAttempts {
+		return -1
+	}
+	d := float64(b.base) * math.Pow(b.multiplier, float64(max(attempt, 1)-1))
+	if b.jitter > 0 {
+		d *= 1 + b.jitter*(2*rand.Float64()-1)
+	}
+	if b.maxDelay > 0 {
+		d = math.Min(d, float64(b.maxDelay))
+	}
+	if d >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(d)
+}
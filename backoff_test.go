@@ -0,0 +1,49 @@
+package futures_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	b := futures.ExponentialBackoff(100*time.Millisecond, 2, time.Second)
+	want := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, w := range want {
+		if d := b.Delay(i + 1); d != w*time.Millisecond {
+			t.Errorf("Delay(%d) = %v, want %v", i+1, d, w*time.Millisecond)
+		}
+	}
+}
+
+func TestConstantBackoff(t *testing.T) {
+	b := futures.ConstantBackoff(time.Second)
+	for attempt := 1; attempt < 5; attempt++ {
+		if d := b.Delay(attempt); d != time.Second {
+			t.Errorf("Delay(%d) = %v, want 1s", attempt, d)
+		}
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	b := futures.ExponentialBackoff(time.Second, 2, 3*time.Second).WithJitter(0.5)
+	for i := 0; i < 100; i++ {
+		if d := b.Delay(1); d < 500*time.Millisecond || d > 1500*time.Millisecond {
+			t.Fatalf("Delay(1) = %v, want 1s ± 50%%", d)
+		}
+		if d := b.Delay(3); d > 3*time.Second {
+			t.Fatalf("Delay(3) = %v exceeds the maximum of 3s", d)
+		}
+	}
+}
+
+func TestBackoffWithMaxAttempts(t *testing.T) {
+	b := futures.ConstantBackoff(time.Second).WithMaxAttempts(3)
+	if d := b.Delay(2); d != time.Second {
+		t.Errorf("Delay(2) = %v, want 1s", d)
+	}
+	if d := b.Delay(3); d >= 0 {
+		t.Errorf("Delay(3) = %v, want a negative delay", d)
+	}
+}
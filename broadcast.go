@@ -0,0 +1,44 @@
+package futures
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Broadcast returns n independent futures that each settle with the
+// outcome of f: all resolve to the same value, or all fail with the same
+// error. Canceling one of them does not affect the others; once all of
+// them have been canceled, f is canceled too, as nobody is left to read
+// it.
+//
+// No goroutine waits for f; its outcome is handed to the n futures when
+// it settles. Broadcast returns an error if n is less than 1.
+func Broadcast[T any](f *Future[T], n int) ([]*Future[T], error) {
+	if n < 1 {
+		return nil, fmt.Errorf("futures: Broadcast needs at least one consumer, got %d", n)
+	}
+	var remaining atomic.Int64
+	remaining.Store(int64(n))
+	outs := make([]*Future[T], n)
+	for i := range outs {
+		out := newFuture[T]()
+		var once sync.Once
+		out.cancel = func(cause error) {
+			once.Do(func() {
+				if remaining.Add(-1) == 0 {
+					f.CancelWithCause(cause)
+				}
+			})
+		}
+		outs[i] = out
+	}
+	f.markAwaited()
+	f.ensureStarted()
+	f.core.AddCallback(func(v T, err error) {
+		for _, out := range outs {
+			out.settle(v, err)
+		}
+	})
+	return outs, nil
+}
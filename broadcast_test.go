@@ -0,0 +1,90 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestBroadcast(t *testing.T) {
+	release := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 42, nil
+	})
+	outs, err := futures.Broadcast(f, 3)
+	if err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+	if len(outs) != 3 {
+		t.Fatalf("Broadcast returned %d futures, want 3", len(outs))
+	}
+	outs[0].Cancel()
+	close(release)
+	if _, err := outs[0].Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("canceled consumer: err = %v, want ErrCanceled", err)
+	}
+	for i, out := range outs[1:] {
+		if v, err := out.Get(); v != 42 || err != nil {
+			t.Errorf("consumer %d: Get = %v, %v; want 42, nil", i+1, v, err)
+		}
+	}
+	if v, err := f.Get(); v != 42 || err != nil {
+		t.Errorf("original: Get = %v, %v; want 42, nil", v, err)
+	}
+}
+
+func TestBroadcastFailure(t *testing.T) {
+	errBoom := errors.New("boom")
+	outs, err := futures.Broadcast(futures.Failed[string](errBoom), 2)
+	if err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+	for i, out := range outs {
+		if _, err := out.Get(); err != errBoom {
+			t.Errorf("consumer %d: err = %v, want %v", i, err, errBoom)
+		}
+	}
+}
+
+func TestBroadcastInvalidCount(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		if outs, err := futures.Broadcast(futures.Completed(1), n); err == nil || outs != nil {
+			t.Errorf("Broadcast(f, %d) = %v, %v; want an error", n, outs, err)
+		}
+	}
+}
+
+func TestBroadcastAllCanceled(t *testing.T) {
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+		return 0, ctx.Err()
+	})
+	outs, err := futures.Broadcast(f, 3)
+	if err != nil {
+		t.Fatalf("Broadcast: %v", err)
+	}
+	<-started
+	outs[0].Cancel()
+	outs[0].Cancel() // a second Cancel of the same consumer does not count
+	outs[1].Cancel()
+	if f.State() != futures.Unsettled {
+		t.Fatal("the source was canceled while a consumer was left")
+	}
+	outs[2].Cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("canceling all consumers did not cancel the source")
+	}
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("source: err = %v, want ErrCanceled", err)
+	}
+}
@@ -0,0 +1,84 @@
+package futures
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// SplitBudget divides the time remaining until the deadline of ctx among
+// sequential phases, such as awaiting one batch of futures and then
+// another one that depends on the first. It returns one context per
+// fraction, each derived from ctx.
+//
+// The deadline of phase i is the current time plus the fractions up to
+// and including i of the remaining time. Deadlines are absolute, so a
+// phase that finishes early leaves its unused time to the phases that
+// follow. Fractions that sum to more than 1 are scaled down to sum to 1;
+// if they sum to exactly 1, the last phase ends at the deadline of ctx.
+// Negative, infinite, and NaN fractions count as 0.
+//
+// If ctx has no deadline, every phase gets ctx itself. If the deadline of
+// ctx has passed, all returned contexts are done.
+//
+// The returned contexts need no cancel functions; the resources they hold
+// are released at their deadline or when ctx is done.
+func SplitBudget(ctx context.Context, fractions ...float64) []context.Context {
+	return SplitBudgetWithFloor(ctx, 0, fractions...)
+}
+
+// SplitBudgetWithFloor is like SplitBudget but grants each phase at least
+// floor, even if its fraction of the remaining time is smaller. The
+// floors never extend a phase beyond the deadline of ctx, so if the
+// remaining time is too short for all floors, the last phases get less.
+func SplitBudgetWithFloor(ctx context.Context, floor time.Duration, fractions ...float64) []context.Context {
+	if len(fractions) == 0 {
+		return nil
+	}
+	phases := make([]context.Context, len(fractions))
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		for i := range phases {
+			phases[i] = ctx
+		}
+		return phases
+	}
+
+	sum := 0.0
+	for _, f := range fractions {
+		sum += sanitizeFraction(f)
+	}
+	scale := 1.0
+	if sum > 1 {
+		scale = 1 / sum
+	}
+	now := time.Now()
+	remaining := max(deadline.Sub(now), 0)
+	cum := 0.0
+	prev := now
+	for i, f := range fractions {
+		cum += sanitizeFraction(f) * scale
+		d := now.Add(time.Duration(math.Round(float64(remaining) * cum)))
+		if i == len(fractions)-1 && cum >= 1-1e-9 {
+			d = deadline // avoid a rounding gap at the end
+		}
+		if floor > 0 && d.Sub(prev) < floor {
+			d = prev.Add(floor)
+		}
+		if d.After(deadline) {
+			d = deadline
+		}
+		phase, cancel := context.WithDeadline(ctx, d)
+		context.AfterFunc(phase, cancel)
+		phases[i] = phase
+		prev = d
+	}
+	return phases
+}
+
+func sanitizeFraction(f float64) float64 {
+	if f < 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0
+	}
+	return f
+}
@@ -0,0 +1,113 @@
+package futures_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+// remaining returns the time left until the deadline of ctx.
+func remaining(t *testing.T, ctx context.Context) time.Duration {
+	t.Helper()
+	d, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("context has no deadline")
+	}
+	return time.Until(d)
+}
+
+func near(got, want time.Duration) bool {
+	const tolerance = 50 * time.Millisecond
+	return got > want-tolerance && got < want+tolerance
+}
+
+func TestSplitBudget(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	phases := futures.SplitBudget(ctx, 0.6, 0.4)
+	if len(phases) != 2 {
+		t.Fatalf("got %d phases, want 2", len(phases))
+	}
+	if got := remaining(t, phases[0]); !near(got, 6*time.Second) {
+		t.Errorf("phase 1 ends in %v, want about 6s", got)
+	}
+	d0, _ := ctx.Deadline()
+	if d1, _ := phases[1].Deadline(); !d1.Equal(d0) {
+		t.Errorf("last phase ends at %v, want the deadline of ctx %v", d1, d0)
+	}
+	cancel()
+	for i, p := range phases {
+		if p.Err() == nil {
+			t.Errorf("phase %d is not canceled with its parent", i)
+		}
+	}
+}
+
+func TestSplitBudgetScalesFractions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	phases := futures.SplitBudget(ctx, 1, 1, 2, -1, math.NaN())
+	want := []time.Duration{2500 * time.Millisecond, 5 * time.Second, 10 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, p := range phases {
+		if got := remaining(t, p); !near(got, want[i]) {
+			t.Errorf("phase %d ends in %v, want about %v", i, got, want[i])
+		}
+	}
+}
+
+func TestSplitBudgetPartial(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	phases := futures.SplitBudget(ctx, 0.2, 0.3)
+	if got := remaining(t, phases[1]); !near(got, 5*time.Second) {
+		t.Errorf("phase 2 ends in %v, want about 5s", got)
+	}
+}
+
+func TestSplitBudgetWithFloor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	phases := futures.SplitBudgetWithFloor(ctx, 2*time.Second, 0.01, 0.01, 0.98)
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 10 * time.Second}
+	for i, p := range phases {
+		if got := remaining(t, p); !near(got, want[i]) {
+			t.Errorf("phase %d ends in %v, want about %v", i, got, want[i])
+		}
+	}
+
+	short, cancelShort := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancelShort()
+	phases = futures.SplitBudgetWithFloor(short, 2*time.Second, 0.5, 0.5)
+	d, _ := short.Deadline()
+	if d1, _ := phases[1].Deadline(); d1.After(d) {
+		t.Errorf("floor extended the last phase beyond the deadline")
+	}
+	if got := remaining(t, phases[0]); !near(got, 2*time.Second) {
+		t.Errorf("phase 1 ends in %v, want about 2s", got)
+	}
+}
+
+func TestSplitBudgetNoDeadline(t *testing.T) {
+	ctx := context.Background()
+	for i, p := range futures.SplitBudget(ctx, 0.5, 0.5) {
+		if p != ctx {
+			t.Errorf("phase %d is not ctx itself", i)
+		}
+	}
+	if phases := futures.SplitBudget(ctx); phases != nil {
+		t.Errorf("SplitBudget without fractions = %v, want nil", phases)
+	}
+}
+
+func TestSplitBudgetExpired(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	for i, p := range futures.SplitBudget(ctx, 0.5, 0.5) {
+		if p.Err() == nil {
+			t.Errorf("phase %d of an expired budget is not done", i)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package futures
+
+import "fmt"
+
+// OnSuccess arranges for fn to be called with the value of f once f has
+// resolved successfully. fn always runs in a new goroutine, even if f has
+// resolved already, so OnSuccess never blocks. If f fails, fn is not
+// called. OnSuccess returns f, so that calls can be chained:
+//
+//	f.OnSuccess(record).OnFailure(alert)
+//
+// If fn panics, the panic is recovered and passed to the handler set by
+// WithPanicHandler.
+func (f *Future[T]) OnSuccess(fn func(T)) *Future[T] {
+	f.markAwaited()
+	f.ensureStarted()
+	f.core.AddCallback(func(v T, err error) {
+		if err == nil {
+			go f.runCallback(func() { fn(v) })
+		}
+	})
+	return f
+}
+
+// OnFailure is like OnSuccess but calls fn with the error of f once f has
+// failed, including when it was canceled.
+func (f *Future[T]) OnFailure(fn func(error)) *Future[T] {
+	f.markAwaited()
+	f.ensureStarted()
+	f.core.AddCallback(func(_ T, err error) {
+		if err != nil {
+			go f.runCallback(func() { fn(err) })
+		}
+	})
+	return f
+}
+
+// OnComplete is like OnSuccess but calls fn once f has settled, no matter
+// how. If f has failed, fn receives the zero value and the error. Use it
+// for work that has to happen either way, such as releasing a resource.
+func (f *Future[T]) OnComplete(fn func(T, error)) *Future[T] {
+	f.markAwaited()
+	f.ensureStarted()
+	f.core.AddCallback(func(v T, err error) {
+		go f.runCallback(func() { fn(v, err) })
+	})
+	return f
+}
+
+// Finally arranges for fn to be called once f has settled, no matter how,
+// like a deferred call for asynchronous code. fn runs in a new goroutine
+// and does not change the outcome of f; Finally returns f itself. Use it
+// for cleanup such as closing a file or releasing a lock. A panic in fn
+// goes to the handler set by WithPanicHandler, as with OnSuccess.
+func (f *Future[T]) Finally(fn func()) *Future[T] {
+	return f.OnComplete(func(T, error) { fn() })
+}
+
+// runCallback calls fn and passes a panic to the panic handler of f.
+func (f *Future[T]) runCallback(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			pe := newPanicError(r)
+			if f.panicHandler != nil {
+				f.panicHandler(pe)
+				return
+			}
+			ReportUnobservedError(fmt.Errorf("futures: callback of future %q panicked: %w", f.name, pe))
+		}
+	}()
+	runStrict(StrictCallbacks, "a callback of "+describeFuture(f.name), fn)
+}
@@ -0,0 +1,130 @@
+package futures_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestOnSuccessOnFailure(t *testing.T) {
+	successes := make(chan int, 1)
+	failures := make(chan error, 1)
+	p := futures.NewPromise[int]()
+	f := p.Future().
+		OnSuccess(func(v int) { successes <- v }).
+		OnFailure(func(err error) { failures <- err })
+	if f != p.Future() {
+		t.Error("OnSuccess and OnFailure do not return the receiver")
+	}
+	p.Resolve(7)
+	if v := <-successes; v != 7 {
+		t.Errorf("OnSuccess got %d, want 7", v)
+	}
+
+	errBoom := errors.New("boom")
+	futures.Failed[int](errBoom).
+		OnSuccess(func(v int) { successes <- v }).
+		OnFailure(func(err error) { failures <- err })
+	if err := <-failures; err != errBoom {
+		t.Errorf("OnFailure got %v, want %v", err, errBoom)
+	}
+	select {
+	case v := <-successes:
+		t.Errorf("OnSuccess ran with %d for a failed future", v)
+	case err := <-failures:
+		t.Errorf("OnFailure ran with %v for a resolved future", err)
+	default:
+	}
+}
+
+func TestOnSuccessDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	done := make(chan struct{})
+	futures.Completed(1).OnSuccess(func(int) {
+		<-release
+		close(done)
+	})
+	close(release)
+	<-done
+}
+
+func TestOnSuccessPanicHandler(t *testing.T) {
+	handled := make(chan *futures.PanicError, 1)
+	p := futures.NewPromise[int](futures.WithPanicHandler(func(pe *futures.PanicError) { handled <- pe }))
+	p.Future().OnSuccess(func(int) { panic("callback failed") })
+	p.Resolve(1)
+	if pe := <-handled; pe.Value() != "callback failed" {
+		t.Errorf("panic handler got %v, want the panic value", pe.Value())
+	}
+}
+
+func TestOnFailurePanicDefault(t *testing.T) {
+	reported := make(chan error, 1)
+	futures.SetUnobservedErrorHandler(func(err error) { reported <- err })
+	defer futures.SetUnobservedErrorHandler(nil)
+	futures.Failed[int](errors.New("boom")).OnFailure(func(error) { panic("alert failed") })
+	err := <-reported
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) || !strings.Contains(err.Error(), "alert failed") {
+		t.Errorf("reported %v, want the PanicError", err)
+	}
+}
+
+func TestOnComplete(t *testing.T) {
+	type result struct {
+		v   int
+		err error
+	}
+	results := make(chan result, 2)
+	record := func(v int, err error) { results <- result{v, err} }
+
+	p := futures.NewPromise[int]()
+	if f := p.Future().OnComplete(record); f != p.Future() {
+		t.Error("OnComplete does not return the receiver")
+	}
+	p.Resolve(3)
+	if r := <-results; r.v != 3 || r.err != nil {
+		t.Errorf("OnComplete got %v, %v; want 3, nil", r.v, r.err)
+	}
+
+	errBoom := errors.New("boom")
+	futures.Failed[int](errBoom).OnComplete(record)
+	if r := <-results; r.v != 0 || r.err != errBoom {
+		t.Errorf("OnComplete got %v, %v; want 0, %v", r.v, r.err, errBoom)
+	}
+}
+
+func TestFinally(t *testing.T) {
+	cleaned := make(chan struct{}, 2)
+	cleanup := func() { cleaned <- struct{}{} }
+
+	p := futures.NewPromise[int]()
+	f := p.Future().Finally(cleanup)
+	if f != p.Future() {
+		t.Error("Finally does not return the receiver")
+	}
+	p.Resolve(1)
+	<-cleaned
+	if v, err := f.Get(); v != 1 || err != nil {
+		t.Errorf("Get = %v, %v; want 1, nil", v, err)
+	}
+
+	errBoom := errors.New("boom")
+	g := futures.Failed[int](errBoom).Finally(cleanup)
+	<-cleaned
+	if _, err := g.Get(); err != errBoom {
+		t.Errorf("err = %v, want %v", err, errBoom)
+	}
+}
+
+func TestFinallyPanic(t *testing.T) {
+	handled := make(chan *futures.PanicError, 1)
+	p := futures.NewPromise[int](futures.WithPanicHandler(func(pe *futures.PanicError) { handled <- pe }))
+	p.Future().Finally(func() { panic("cleanup failed") })
+	p.Resolve(1)
+	if pe := <-handled; pe.Value() != "cleanup failed" {
+		t.Errorf("panic handler got %v, want the panic value", pe.Value())
+	}
+}
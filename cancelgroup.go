@@ -0,0 +1,70 @@
+package futures
+
+import (
+	"context"
+	"sync"
+)
+
+// Cancelable is a Future of any type, as seen by a CancelGroup.
+type Cancelable interface {
+	Done() <-chan struct{}
+	Cancel()
+}
+
+// CancelGroup is a set of futures that are canceled together, for
+// example all futures that serve one request. The zero CancelGroup is
+// empty and ready to use. A CancelGroup is safe for concurrent use.
+type CancelGroup struct {
+	mu       sync.Mutex
+	fs       []Cancelable
+	canceled bool
+}
+
+// NewCancelGroup returns an empty CancelGroup that is canceled when ctx
+// is done.
+func NewCancelGroup(ctx context.Context) *CancelGroup {
+	g := &CancelGroup{}
+	context.AfterFunc(ctx, g.Cancel)
+	return g
+}
+
+// Add adds f to g. If g has been canceled already, f is canceled right
+// away.
+func (g *CancelGroup) Add(f Cancelable) {
+	g.mu.Lock()
+	g.fs = append(g.fs, f)
+	canceled := g.canceled
+	g.mu.Unlock()
+	if canceled {
+		f.Cancel()
+	}
+}
+
+// Cancel cancels all futures in g and all futures that are added to g
+// later. Futures that have settled already are not affected.
+func (g *CancelGroup) Cancel() {
+	g.mu.Lock()
+	g.canceled = true
+	fs := g.fs
+	g.mu.Unlock()
+	for _, f := range fs {
+		f.Cancel()
+	}
+}
+
+// Wait blocks until all futures in g have settled, including those that
+// are added while Wait runs.
+func (g *CancelGroup) Wait() {
+	for n := 0; ; {
+		g.mu.Lock()
+		fs := g.fs
+		g.mu.Unlock()
+		if n == len(fs) {
+			return
+		}
+		for _, f := range fs[n:] {
+			<-f.Done()
+		}
+		n = len(fs)
+	}
+}
@@ -0,0 +1,89 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+// blocked returns a Future that stays pending until it is canceled.
+func blocked() *futures.Future[int] {
+	return futures.New(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+}
+
+func TestCancelGroup(t *testing.T) {
+	var g futures.CancelGroup
+	a, b := blocked(), futures.New(func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	done := futures.Completed(1)
+	g.Add(a)
+	g.Add(b)
+	g.Add(done)
+	g.Cancel()
+	g.Wait()
+	if _, err := a.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("a: err = %v, want ErrCanceled", err)
+	}
+	if _, err := b.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("b: err = %v, want ErrCanceled", err)
+	}
+	if v, err := done.Get(); v != 1 || err != nil {
+		t.Errorf("settled future changed to %v, %v", v, err)
+	}
+
+	late := blocked()
+	g.Add(late)
+	if _, err := late.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("future added after Cancel: err = %v, want ErrCanceled", err)
+	}
+}
+
+func TestCancelGroupContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := futures.NewCancelGroup(ctx)
+	f := blocked()
+	g.Add(f)
+	cancel()
+	g.Wait()
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want ErrCanceled", err)
+	}
+}
+
+func TestCancelGroupConcurrent(t *testing.T) {
+	var g futures.CancelGroup
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.Add(blocked())
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		g.Cancel()
+	}()
+	wg.Wait()
+	g.Wait()
+}
+
+func TestCancelGroupWaitResolved(t *testing.T) {
+	var g futures.CancelGroup
+	p := futures.NewPromise[int]()
+	g.Add(p.Future())
+	go p.Resolve(1)
+	g.Wait()
+	if v, err := p.Future().Get(); v != 1 || err != nil {
+		t.Errorf("Get = %v, %v; want 1, nil", v, err)
+	}
+}
@@ -0,0 +1,26 @@
+package futures
+
+// Catch returns a Future that resolves to the value of f if f resolves,
+// and to the result of handler if f fails. Unlike Recover, handler can
+// fail as well, in which case the returned Future fails with the error
+// handler returns.
+//
+// handler sees every failure of f, including cancellation and panics.
+// To let a cancellation through, return the error unchanged:
+//
+//	futures.Catch(f, func(err error) (int, error) {
+//		if errors.Is(err, futures.ErrCanceled) {
+//			return 0, err
+//		}
+//		return fallback, nil
+//	})
+//
+// If handler panics, the returned Future fails with a *PanicError.
+func Catch[T any](f *Future[T], handler func(error) (T, error)) *Future[T] {
+	return chain(f, func(v T, err error) (T, error) {
+		if err == nil {
+			return v, nil
+		}
+		return catchPanic(func() (T, error) { return handler(err) })
+	})
+}
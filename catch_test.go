@@ -0,0 +1,89 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestCatchPassesValuesThrough(t *testing.T) {
+	called := false
+	v, err := futures.Catch(futures.Completed(1), func(error) (int, error) {
+		called = true
+		return -1, nil
+	}).Get()
+	if v != 1 || err != nil || called {
+		t.Errorf("Get = %d, %v (handler called: %v); want 1, nil without calling handler", v, err, called)
+	}
+}
+
+func TestCatchRecovers(t *testing.T) {
+	errBoom := errors.New("boom")
+	var got error
+	v, err := futures.Catch(futures.Failed[int](errBoom), func(err error) (int, error) {
+		got = err
+		return -1, nil
+	}).Get()
+	if v != -1 || err != nil {
+		t.Errorf("Get = %d, %v; want -1, nil", v, err)
+	}
+	if got != errBoom {
+		t.Errorf("handler received %v, want %v", got, errBoom)
+	}
+}
+
+func TestCatchHandlerFails(t *testing.T) {
+	errFallback := errors.New("no fallback")
+	_, err := futures.Catch(futures.Failed[int](errors.New("boom")), func(error) (int, error) {
+		return 0, errFallback
+	}).Get()
+	if err != errFallback {
+		t.Errorf("err = %v, want %v", err, errFallback)
+	}
+
+	_, err = futures.Catch(futures.Failed[int](errors.New("boom")), func(error) (int, error) {
+		panic("handler broke")
+	}).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) || pe.Value() != "handler broke" {
+		t.Errorf("err = %v, want a PanicError from the handler", err)
+	}
+}
+
+func TestCatchRecoversFromPanic(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) {
+		panic("upstream broke")
+	})
+	v, err := futures.Catch(f, func(err error) (int, error) {
+		var pe *futures.PanicError
+		if !errors.As(err, &pe) {
+			return 0, err
+		}
+		return -1, nil
+	}).Get()
+	if v != -1 || err != nil {
+		t.Errorf("Get = %d, %v; want -1, nil", v, err)
+	}
+}
+
+func TestCatchSeesCancellation(t *testing.T) {
+	started := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	out := futures.Catch(f, func(err error) (int, error) {
+		if errors.Is(err, futures.ErrCanceled) {
+			return 0, err
+		}
+		return -1, nil
+	})
+	<-started
+	f.Cancel()
+	if _, err := out.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want the cancellation to pass through", err)
+	}
+}
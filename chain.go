@@ -0,0 +1,159 @@
+package futures
+
+import (
+	"context"
+	"sync"
+)
+
+// chain returns a Future that settles with the result of next, which is
+// called in a new goroutine with the outcome of f once f has settled. If
+// the returned Future settles first, for example because it was canceled,
+// next is not called. No goroutine waits while f is pending, so long
+// chains cost no more than their stages.
+//
+// The returned Future becomes a stage of the Chain of f. If the Chain has
+// been canceled, it is born canceled.
+func chain[T, U any](f *Future[T], next func(v T, err error) (U, error)) *Future[U] {
+	return chainTo(f, newFuture[U](), false, next)
+}
+
+// chainTo is chain with the returned Future provided by the caller. If
+// inline is true, next is called on the goroutine that settles f, or on
+// the calling goroutine if f has settled already.
+func chainTo[T, U any](f *Future[T], out *Future[U], inline bool, next func(v T, err error) (U, error)) *Future[U] {
+	ChainOf(f).add(out)
+	f.markAwaited()
+	f.ensureStarted()
+	f.core.AddCallback(func(v T, err error) {
+		if isSettled(out) {
+			return
+		}
+		if inline {
+			var u U
+			runStrict(StrictCallbacks, "an inline continuation of "+describeFuture(f.name), func() {
+				u, err = next(v, err)
+			})
+			out.settle(u, err)
+			return
+		}
+		go func() {
+			u, err := next(v, err)
+			out.settle(u, err)
+		}()
+	})
+	return out
+}
+
+// Settled is the part of a Future that does not depend on the type of its
+// value.
+type Settled interface {
+	Name() string
+	Done() <-chan struct{}
+	Err() error
+	State() Settlement
+}
+
+// Chain is a handle on all stages derived from the same root Future by
+// chaining combinators such as Recover, MapError, and Checkpoint3. Use it
+// to cancel or await a pipeline as a whole. Stages join the Chain as they
+// are created.
+type Chain struct {
+	mu     sync.Mutex
+	stages []chainStage
+	// canceled is set by Cancel; cause is the cause it was called with.
+	canceled bool
+	cause    error
+}
+
+type chainStage struct {
+	f      Settled
+	cancel func(cause error)
+}
+
+// ChainOf returns the Chain that f belongs to. If f is not a stage of a
+// chain yet, ChainOf starts a new Chain with f as its root.
+func ChainOf[T any](f *Future[T]) *Chain {
+	if c := f.chain.Load(); c != nil {
+		return c
+	}
+	c := &Chain{stages: []chainStage{{f: f, cancel: f.CancelWithCause}}}
+	if !f.chain.CompareAndSwap(nil, c) {
+		return f.chain.Load()
+	}
+	return c
+}
+
+// add appends f to c as a new stage.
+func (c *Chain) add(f stage) {
+	c.mu.Lock()
+	c.stages = append(c.stages, chainStage{f: f, cancel: f.CancelWithCause})
+	canceled, cause := c.canceled, c.cause
+	c.mu.Unlock()
+	f.setChain(c)
+	if canceled {
+		f.CancelWithCause(cause)
+	}
+}
+
+// stage is a Future that is added to a Chain.
+type stage interface {
+	Settled
+	CancelWithCause(cause error)
+	setChain(c *Chain)
+}
+
+func (f *Future[T]) setChain(c *Chain) {
+	f.chain.Store(c)
+}
+
+// Cancel cancels all stages of c with the given cause, as
+// CancelWithCause does. The stages are canceled from the last to the
+// root, so that a stage that is still running cannot start the next one.
+// Stages that are added to c later are canceled right away.
+func (c *Chain) Cancel(cause error) {
+	c.mu.Lock()
+	c.canceled, c.cause = true, cause
+	stages := c.stages
+	c.mu.Unlock()
+	for i := len(stages) - 1; i >= 0; i-- {
+		stages[i].cancel(cause)
+	}
+}
+
+// Futures returns the stages of c in the order they were added, starting
+// with the root.
+func (c *Chain) Futures() []Settled {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fs := make([]Settled, len(c.stages))
+	for i, s := range c.stages {
+		fs[i] = s.f
+	}
+	return fs
+}
+
+// Wait blocks until all stages of c have settled or ctx is done. It
+// returns the error of the first stage that failed, in the order of
+// Futures, or ErrCanceled or ErrTimeout if ctx is done first. Stages that
+// are added while Wait runs are waited for as well.
+func (c *Chain) Wait(ctx context.Context) error {
+	for n := 0; ; {
+		fs := c.Futures()
+		if n == len(fs) {
+			for _, f := range fs {
+				if err := f.Err(); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		for _, f := range fs[n:] {
+			select {
+			case <-f.Done():
+			case <-ctx.Done():
+				return contextError(ctx)
+			}
+		}
+		n = len(fs)
+	}
+}
@@ -0,0 +1,90 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestChainCancel(t *testing.T) {
+	errAbort := errors.New("abort")
+	var ran [6]atomic.Bool
+	stage := func(i int) []futures.CheckpointStage[int] {
+		return []futures.CheckpointStage[int]{{Name: "stage", Check: func(int) error {
+			ran[i].Store(true)
+			return nil
+		}}}
+	}
+	entered := make(chan struct{})
+	release := make(chan struct{})
+
+	root := futures.New(func(ctx context.Context) (int, error) { return 1, nil })
+	s2 := futures.Checkpoint3(root, stage(2))
+	s3 := futures.Checkpoint3(s2, []futures.CheckpointStage[int]{{Name: "slow", Check: func(int) error {
+		close(entered)
+		<-release
+		return nil
+	}}})
+	s4 := futures.Checkpoint3(s3, stage(4))
+	s5 := futures.Checkpoint3(s4, stage(5))
+
+	c := futures.ChainOf(s5)
+	if c != futures.ChainOf(root) {
+		t.Fatal("ChainOf returns different chains for the root and the last stage")
+	}
+	if n := len(c.Futures()); n != 5 {
+		t.Fatalf("chain has %d stages, want 5", n)
+	}
+
+	<-entered
+	c.Cancel(errAbort)
+	close(release)
+	if err := c.Wait(context.Background()); !errors.Is(err, errAbort) {
+		t.Errorf("Wait: err = %v, want %v", err, errAbort)
+	}
+	if !ran[2].Load() {
+		t.Error("stage 2 did not run")
+	}
+	if ran[4].Load() || ran[5].Load() {
+		t.Error("stages after the canceled stage ran")
+	}
+	for i, f := range c.Futures()[2:] {
+		if err := f.Err(); !errors.Is(err, futures.ErrCanceled) || !errors.Is(err, errAbort) {
+			t.Errorf("stage %d: err = %v, want ErrCanceled with cause %v", i+3, err, errAbort)
+		}
+	}
+
+	s6 := futures.Checkpoint3(s5, stage(0))
+	if _, err := s6.Get(); !errors.Is(err, errAbort) {
+		t.Errorf("stage added after Cancel: err = %v, want %v", err, errAbort)
+	}
+	if ran[0].Load() {
+		t.Error("stage added after Cancel ran")
+	}
+}
+
+func TestChainWait(t *testing.T) {
+	errBoom := errors.New("boom")
+	p := futures.NewPromise[int]()
+	last := futures.Checkpoint3(futures.Checkpoint3(p.Future(), nil), []futures.CheckpointStage[int]{
+		{Name: "fail", Check: func(int) error { return errBoom }},
+	})
+	c := futures.ChainOf(last)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Wait(ctx); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("Wait with a canceled context: err = %v, want ErrCanceled", err)
+	}
+
+	p.Resolve(1)
+	if err := c.Wait(context.Background()); !errors.Is(err, errBoom) {
+		t.Errorf("Wait: err = %v, want %v", err, errBoom)
+	}
+	if states := c.Futures(); states[0].State() != futures.Resolved || states[2].State() != futures.Rejected {
+		t.Errorf("stage states = %v, %v; want resolved, rejected", states[0].State(), states[2].State())
+	}
+}
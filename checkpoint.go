@@ -0,0 +1,51 @@
+package futures
+
+import "fmt"
+
+// CheckpointStage is a verification step that a resolved value must pass,
+// such as a schema validation or a signature check.
+type CheckpointStage[T any] struct {
+	// Name identifies the stage in a CheckpointError.
+	Name string
+	// Check returns an error if the value does not pass the stage.
+	Check func(T) error
+}
+
+// CheckpointError is the error of a Future returned by Checkpoint3 whose
+// value failed a stage.
+type CheckpointError struct {
+	// Stage is the name of the stage that failed.
+	Stage string
+	// Err is the error returned by the stage's Check function, or a
+	// *PanicError if it panicked.
+	Err error
+}
+
+func (e *CheckpointError) Error() string {
+	return fmt.Sprintf("futures: checkpoint %q: %v", e.Stage, e.Err)
+}
+
+func (e *CheckpointError) Unwrap() error {
+	return e.Err
+}
+
+// Checkpoint3 returns a Future that resolves to the value of f once the
+// value has passed all stages. The stages run one after another, in
+// order, after f has resolved. If a stage fails, the remaining stages do
+// not run, and the returned Future fails with a *CheckpointError. If f
+// fails, the returned Future fails with the same error, and no stage runs.
+func Checkpoint3[T any](f *Future[T], stages []CheckpointStage[T]) *Future[T] {
+	return chain(f, func(v T, err error) (T, error) {
+		if err != nil {
+			return v, err
+		}
+		for _, st := range stages {
+			_, err := catchPanic(func() (struct{}, error) { return struct{}{}, st.Check(v) })
+			if err != nil {
+				var zero T
+				return zero, &CheckpointError{Stage: st.Name, Err: err}
+			}
+		}
+		return v, nil
+	})
+}
@@ -0,0 +1,63 @@
+package futures_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestCheckpoint3(t *testing.T) {
+	errSignature := errors.New("bad signature")
+	var ran []string
+	stage := func(name string, err error) futures.CheckpointStage[string] {
+		return futures.CheckpointStage[string]{Name: name, Check: func(string) error {
+			ran = append(ran, name)
+			return err
+		}}
+	}
+	stages := []futures.CheckpointStage[string]{
+		stage("schema", nil),
+		stage("signature", errSignature),
+		stage("rate-limit", nil),
+	}
+	_, err := futures.Checkpoint3(futures.Completed("doc"), stages).Get()
+	var ce *futures.CheckpointError
+	if !errors.As(err, &ce) || ce.Stage != "signature" || !errors.Is(err, errSignature) {
+		t.Fatalf("err = %v, want a CheckpointError of stage signature", err)
+	}
+	if got := strings.Join(ran, ","); got != "schema,signature" {
+		t.Errorf("stages ran: %s, want schema,signature", got)
+	}
+
+	ran = nil
+	stages[1] = stage("signature", nil)
+	if v, err := futures.Checkpoint3(futures.Completed("doc"), stages).Get(); v != "doc" || err != nil {
+		t.Errorf("Get = %q, %v; want doc, nil", v, err)
+	}
+	if len(ran) != 3 {
+		t.Errorf("%d stages ran, want 3", len(ran))
+	}
+}
+
+func TestCheckpoint3SourceFails(t *testing.T) {
+	errBoom := errors.New("boom")
+	stages := []futures.CheckpointStage[int]{{Name: "never", Check: func(int) error {
+		t.Error("stage ran for a failed future")
+		return nil
+	}}}
+	if _, err := futures.Checkpoint3(futures.Failed[int](errBoom), stages).Get(); err != errBoom {
+		t.Errorf("err = %v, want %v", err, errBoom)
+	}
+}
+
+func TestCheckpoint3StagePanics(t *testing.T) {
+	stages := []futures.CheckpointStage[int]{{Name: "panicky", Check: func(int) error { panic("oops") }}}
+	_, err := futures.Checkpoint3(futures.Completed(1), stages).Get()
+	var pe *futures.PanicError
+	var ce *futures.CheckpointError
+	if !errors.As(err, &ce) || ce.Stage != "panicky" || !errors.As(err, &pe) {
+		t.Errorf("err = %v, want a CheckpointError wrapping a PanicError", err)
+	}
+}
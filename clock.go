@@ -0,0 +1,53 @@
+package futures
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the source of time for everything time-related in this package.
+// Replace it with a fake clock, such as the one in package futuretest, to
+// test timeouts and delays without waiting for them.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// AfterFunc arranges for f to be called once duration d has elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer created by Clock.AfterFunc.
+type Timer interface {
+	// Stop prevents the timer from firing. It returns false if the timer
+	// has already fired or been stopped.
+	Stop() bool
+}
+
+// SystemClock is the Clock based on the time package. This is the default.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// sleep waits for d on clock, or until ctx is done, in which case it
+// returns ErrCanceled or ErrTimeout.
+func sleep(ctx context.Context, clock Clock, d time.Duration) error {
+	if d <= 0 {
+		return contextError(ctx)
+	}
+	fired := make(chan struct{})
+	t := clock.AfterFunc(d, func() { close(fired) })
+	select {
+	case <-fired:
+		return nil
+	case <-ctx.Done():
+		t.Stop()
+		return contextError(ctx)
+	}
+}
@@ -0,0 +1,29 @@
+package futures
+
+import "io"
+
+// Close tells f that its reader will never read it, so that the
+// resources behind it can be released early.
+//
+// If f is pending, Close cancels it like Cancel does. This cancels the
+// context of the computation, which runs the cleanup the computation has
+// registered with context.AfterFunc or by watching ctx.Done. If f has
+// resolved to a value that implements io.Closer, Close closes the value
+// and returns its error. The value itself stays in f, so Close is for
+// futures with a single reader; other readers would get a closed value.
+//
+// Close is idempotent: only the first call has an effect, and later calls
+// return the same error.
+func (f *Future[T]) Close() error {
+	f.closeOnce.Do(func() {
+		f.Cancel()
+		v, err := f.core.Get()
+		if err != nil {
+			return
+		}
+		if c, ok := any(v).(io.Closer); ok {
+			f.closeErr = c.Close()
+		}
+	})
+	return f.closeErr
+}
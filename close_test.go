@@ -0,0 +1,88 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+// fakeResource stands in for a connection or file handle.
+type fakeResource struct {
+	closed atomic.Int32
+}
+
+func (r *fakeResource) Close() error {
+	if r.closed.Add(1) > 1 {
+		return errors.New("closed twice")
+	}
+	return nil
+}
+
+func TestClosePending(t *testing.T) {
+	res := &fakeResource{}
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (*fakeResource, error) {
+		context.AfterFunc(ctx, func() {
+			res.Close()
+			close(stopped)
+		})
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	<-started
+	if err := f.Close(); err != nil {
+		t.Errorf("Close() = %v, want nil", err)
+	}
+	<-stopped
+	if n := res.closed.Load(); n != 1 {
+		t.Errorf("resource closed %d times, want 1", n)
+	}
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("Get: err = %v, want ErrCanceled", err)
+	}
+}
+
+func TestCloseResolved(t *testing.T) {
+	res := &fakeResource{}
+	f := futures.New(func(ctx context.Context) (*fakeResource, error) { return res, nil })
+	f.Wait(context.Background())
+	if err := f.Close(); err != nil {
+		t.Errorf("Close() = %v, want nil", err)
+	}
+	if n := res.closed.Load(); n != 1 {
+		t.Errorf("resource closed %d times, want 1", n)
+	}
+	if v, err := f.Get(); v != res || err != nil {
+		t.Errorf("Get = %v, %v after Close; want the resolved value", v, err)
+	}
+}
+
+func TestCloseTwice(t *testing.T) {
+	res := &fakeResource{}
+	f := futures.Completed(res)
+	for i := 0; i < 2; i++ {
+		if err := f.Close(); err != nil {
+			t.Errorf("Close #%d = %v, want nil", i+1, err)
+		}
+	}
+	if n := res.closed.Load(); n != 1 {
+		t.Errorf("resource closed %d times, want 1", n)
+	}
+}
+
+func TestCloseError(t *testing.T) {
+	res := &fakeResource{}
+	res.closed.Store(1)
+	f := futures.Completed(res)
+	if err := f.Close(); err == nil {
+		t.Error("Close() = nil, want the error of the value's Close")
+	}
+	if err := f.Close(); err == nil {
+		t.Error("second Close() = nil, want the same error")
+	}
+}
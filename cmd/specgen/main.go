@@ -0,0 +1,108 @@
+// Command specgen generates SPEC.md from the spec tests of package
+// futures.
+//
+// Each test named TestSpec_Name in the input file becomes one guarantee in
+// the output. The heading is derived from Name, and the text is the doc
+// comment of the test.
+//
+// Usage:
+//
+//	specgen [-in spec_test.go] [-out SPEC.md]
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const prefix = "TestSpec_"
+
+func main() {
+	in := flag.String("in", "spec_test.go", "file with the spec tests")
+	out := flag.String("out", "SPEC.md", "file to write the specification to")
+	flag.Parse()
+
+	src, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	doc, err := generate(*in, src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, doc, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the specification for the spec tests in src.
+func generate(filename string, src []byte) ([]byte, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString("# Semantics of package futures\n\n")
+	fmt.Fprintf(&b, "<!-- Generated by cmd/specgen from %s. DO NOT EDIT. -->\n\n", filename)
+	b.WriteString("Each guarantee below is enforced by the test named after it.\n")
+	n := 0
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, prefix) {
+			continue
+		}
+		text := strings.TrimSpace(fn.Doc.Text())
+		if text == "" {
+			return nil, fmt.Errorf("%s: %s has no doc comment", filename, fn.Name.Name)
+		}
+		n++
+		fmt.Fprintf(&b, "\n## %d. %s\n\n", n, heading(strings.TrimPrefix(fn.Name.Name, prefix), text))
+		b.WriteString(strings.Join(strings.Fields(text), " "))
+		fmt.Fprintf(&b, "\n\nTest: `%s`\n", fn.Name.Name)
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("%s: no tests named %s*", filename, prefix)
+	}
+	return b.Bytes(), nil
+}
+
+// heading turns a name like DoneImpliesTryGetFinal into "Done implies
+// TryGet final". Runs of words that form an identifier mentioned in text,
+// such as TryGet, keep their spelling; other words are lowercased.
+func heading(name, text string) string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	words = append(words, string(runes[start:]))
+
+	var out []string
+	for i := 0; i < len(words); {
+		j := len(words)
+		for ; j > i+1; j-- {
+			if strings.Contains(text, strings.Join(words[i:j], "")) {
+				break
+			}
+		}
+		w := strings.Join(words[i:j], "")
+		if j == i+1 && len(out) > 0 {
+			w = strings.ToLower(w)
+		}
+		out = append(out, w)
+		i = j
+	}
+	return strings.Join(out, " ")
+}
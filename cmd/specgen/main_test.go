@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHeading(t *testing.T) {
+	tests := []struct{ name, text, want string }{
+		{"StickyFailure", "", "Sticky failure"},
+		{"DoneImpliesTryGetFinal", "Once Done is closed, TryGet reports", "Done implies TryGet final"},
+		{"PanicBecomesPanicError", "fails with a *PanicError", "Panic becomes PanicError"},
+		{"Once", "", "Once"},
+	}
+	for _, tt := range tests {
+		if got := heading(tt.name, tt.text); got != tt.want {
+			t.Errorf("heading(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src := `package x
+
+// Something always holds.
+func TestSpec_AlwaysHolds(t *testing.T) {}
+
+func TestOther(t *testing.T) {}
+`
+	doc, err := generate("x_test.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## 1. Always holds", "Something always holds.", "Test: `TestSpec_AlwaysHolds`"} {
+		if !strings.Contains(string(doc), want) {
+			t.Errorf("output lacks %q:\n%s", want, doc)
+		}
+	}
+	if strings.Contains(string(doc), "TestOther") {
+		t.Errorf("output includes a test that is not a spec test:\n%s", doc)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for name, src := range map[string]string{
+		"no doc comment": "package x\nfunc TestSpec_Undocumented(t *testing.T) {}\n",
+		"no spec tests":  "package x\nfunc TestOther(t *testing.T) {}\n",
+		"syntax error":   "package x\nfunc {",
+	} {
+		if _, err := generate("x_test.go", []byte(src)); err == nil {
+			t.Errorf("%s: generate returned no error", name)
+		}
+	}
+}
+
+// TestSpecUpToDate fails if SPEC.md does not match the spec tests. Run go
+// generate in the module root to update it.
+func TestSpecUpToDate(t *testing.T) {
+	src, err := os.ReadFile("../../spec_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := generate("spec_test.go", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../SPEC.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("SPEC.md is out of date; run go generate")
+	}
+}
@@ -0,0 +1,33 @@
+package futures
+
+// Compact returns a Stream of the values of s for which isEmpty returns
+// false. The returned Stream ends with the error of s. Closing it closes
+// s.
+func Compact[T any](s *Stream[T], isEmpty func(T) bool, opts ...StreamOption) *Stream[T] {
+	out := newStream[T](newStreamOptions(0, opts))
+	go func() {
+		defer s.Close()
+		for {
+			select {
+			case v, ok := <-s.C():
+				if !ok {
+					out.close(s.Err())
+					return
+				}
+				if !isEmpty(v) && !out.send(v) {
+					return
+				}
+			case <-out.quit:
+				out.close(nil)
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// CompactNil returns a Stream of the values of s that are not nil. See
+// Compact.
+func CompactNil[T any](s *Stream[*T], opts ...StreamOption) *Stream[*T] {
+	return Compact(s, func(p *T) bool { return p == nil }, opts...)
+}
@@ -0,0 +1,40 @@
+package futures_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestCompact(t *testing.T) {
+	s := futures.Compact(streamOf("a", "", "b", "", "", "c"), func(s string) bool { return s == "" })
+	if got := collect(s); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("got %q, want [a b c]", got)
+	}
+}
+
+func TestCompactNil(t *testing.T) {
+	a, b := "a", "b"
+	s := futures.CompactNil(streamOf(nil, &a, nil, &b))
+	var got []string
+	for p := range s.C() {
+		got = append(got, *p)
+	}
+	if !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("got %q, want [a b]", got)
+	}
+}
+
+func TestCompactClose(t *testing.T) {
+	ch := make(chan string)
+	src := futures.StreamOf(ch)
+	s := futures.Compact(src, func(s string) bool { return s == "" })
+	s.Close()
+	for range s.C() {
+	}
+	// Closing the compacted stream closes the source.
+	go func() { ch <- "x" }()
+	for range src.C() {
+	}
+}
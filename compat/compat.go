@@ -0,0 +1,96 @@
+// Package compat helps migrating code to package futures one call site at
+// a time. It is transitional: its functions give up type safety for
+// uniformity, so replace calls to them with the typed API of package
+// futures once the surrounding code has been converted.
+package compat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/appliedgo/futures"
+)
+
+// ErrUnsupportedType is returned by Await for values it cannot wait for.
+var ErrUnsupportedType = errors.New("compat: unsupported type")
+
+// ErrClosed is returned by Await for a channel that was closed without
+// delivering a value.
+var ErrClosed = errors.New("compat: channel closed")
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Await waits for x until ctx is done and returns what x delivered. x may
+// be:
+//
+//   - a *futures.Future[T], or any other type with a method
+//     GetWithContext(context.Context) (T, error), such as a type that
+//     embeds futures.Core: Await returns its value and error.
+//   - a channel of any element type that can be received from: Await
+//     returns the first value received, or ErrClosed if the channel is
+//     closed.
+//   - a context.Context: Await returns nil, nil once it is done.
+//
+// For any other x, Await returns ErrUnsupportedType. If ctx is done first,
+// Await returns futures.ErrCanceled or futures.ErrTimeout.
+//
+// The value is returned as an any, so the compiler no longer checks its
+// type. The caller must assert it to the type that x delivers.
+func Await(ctx context.Context, x any) (any, error) {
+	if c, ok := x.(context.Context); ok {
+		select {
+		case <-c.Done():
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctxError(ctx)
+		}
+	}
+	v := reflect.ValueOf(x)
+	if !v.IsValid() {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedType, x)
+	}
+	if v.Kind() == reflect.Chan {
+		return awaitChan(ctx, v)
+	}
+	if m := v.MethodByName("GetWithContext"); m.IsValid() {
+		t := m.Type()
+		if t.NumIn() == 1 && t.In(0) == contextType && t.NumOut() == 2 && t.Out(1) == errorType {
+			out := m.Call([]reflect.Value{reflect.ValueOf(ctx)})
+			err, _ := out[1].Interface().(error)
+			return out[0].Interface(), err
+		}
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedType, x)
+}
+
+// awaitChan receives the first value from the channel ch.
+func awaitChan(ctx context.Context, ch reflect.Value) (any, error) {
+	if ch.Type().ChanDir()&reflect.RecvDir == 0 {
+		return nil, fmt.Errorf("%w: %v is send-only", ErrUnsupportedType, ch.Type())
+	}
+	chosen, v, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: ch},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+	})
+	switch {
+	case chosen == 1:
+		return nil, ctxError(ctx)
+	case !ok:
+		return nil, ErrClosed
+	}
+	return v.Interface(), nil
+}
+
+// ctxError translates the error of the done context ctx like
+// futures.Future.GetWithContext does.
+func ctxError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return futures.ErrTimeout
+	}
+	return futures.ErrCanceled
+}
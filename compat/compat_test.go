@@ -0,0 +1,64 @@
+package compat_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/compat"
+)
+
+func TestAwaitFuture(t *testing.T) {
+	v, err := compat.Await(context.Background(), futures.Completed(42))
+	if v != 42 || err != nil {
+		t.Errorf("Await = %v, %v; want 42, nil", v, err)
+	}
+	errBoom := errors.New("boom")
+	if _, err := compat.Await(context.Background(), futures.Failed[int](errBoom)); err != errBoom {
+		t.Errorf("failed future: err = %v, want %v", err, errBoom)
+	}
+}
+
+func TestAwaitChannel(t *testing.T) {
+	ch := make(chan string, 2)
+	ch <- "first"
+	ch <- "second"
+	if v, err := compat.Await(context.Background(), (<-chan string)(ch)); v != "first" || err != nil {
+		t.Errorf("Await = %v, %v; want first, nil", v, err)
+	}
+	closed := make(chan int)
+	close(closed)
+	if _, err := compat.Await(context.Background(), closed); !errors.Is(err, compat.ErrClosed) {
+		t.Errorf("closed channel: err = %v, want ErrClosed", err)
+	}
+	if _, err := compat.Await(context.Background(), (chan<- int)(closed)); !errors.Is(err, compat.ErrUnsupportedType) {
+		t.Errorf("send-only channel: err = %v, want ErrUnsupportedType", err)
+	}
+}
+
+func TestAwaitContext(t *testing.T) {
+	done, cancel := context.WithCancel(context.Background())
+	cancel()
+	if v, err := compat.Await(context.Background(), done); v != nil || err != nil {
+		t.Errorf("Await = %v, %v; want nil, nil", v, err)
+	}
+}
+
+func TestAwaitGivesUp(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, x := range []any{make(chan int), futures.NewPromise[int]().Future(), context.Background()} {
+		if _, err := compat.Await(ctx, x); !errors.Is(err, futures.ErrCanceled) {
+			t.Errorf("Await(%T): err = %v, want ErrCanceled", x, err)
+		}
+	}
+}
+
+func TestAwaitUnsupported(t *testing.T) {
+	for _, x := range []any{nil, 42, "future"} {
+		if _, err := compat.Await(context.Background(), x); !errors.Is(err, compat.ErrUnsupportedType) {
+			t.Errorf("Await(%#v): err = %v, want ErrUnsupportedType", x, err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+// readers are the ways of reading a Future that must be safe for
+// concurrent use. Each blocks until the Future has settled and returns
+// what it observed.
+var concurrentReaders = map[string]func(f *futures.Future[int]) (int, error){
+	"Get": func(f *futures.Future[int]) (int, error) { return f.Get() },
+	"GetWithContext": func(f *futures.Future[int]) (int, error) {
+		return f.GetWithContext(context.Background())
+	},
+	"Wait": func(f *futures.Future[int]) (int, error) {
+		err := f.Wait(context.Background())
+		v, _, _ := f.TryGet()
+		return v, err
+	},
+	"Done": func(f *futures.Future[int]) (int, error) {
+		<-f.Done()
+		v, err, _ := f.TryGet()
+		return v, err
+	},
+	"TryGet": func(f *futures.Future[int]) (int, error) {
+		for {
+			if v, err, ok := f.TryGet(); ok {
+				return v, err
+			}
+			runtime.Gosched()
+		}
+	},
+	"State": func(f *futures.Future[int]) (int, error) {
+		for f.State() == futures.Unsettled {
+			runtime.Gosched()
+		}
+		v, err, _ := f.TryGet()
+		if (f.State() == futures.Rejected) != (err != nil) {
+			return v, fmt.Errorf("State() = %v does not match err %v", f.State(), err)
+		}
+		return v, err
+	},
+}
+
+const readersPerMethod = 50
+
+// readConcurrently starts readersPerMethod goroutines for each reader
+// and returns a function that waits for them and checks what they saw.
+func readConcurrently(t *testing.T, f *futures.Future[int]) (wait func(wantV int, wantErr error)) {
+	type result struct {
+		method string
+		v      int
+		err    error
+	}
+	results := make(chan result, readersPerMethod*len(concurrentReaders))
+	var wg sync.WaitGroup
+	for method, read := range concurrentReaders {
+		method, read := method, read
+		for i := 0; i < readersPerMethod; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				v, err := read(f)
+				results <- result{method, v, err}
+			}()
+		}
+	}
+	return func(wantV int, wantErr error) {
+		t.Helper()
+		wg.Wait()
+		close(results)
+		for r := range results {
+			if r.v != wantV || !errors.Is(r.err, wantErr) || (wantErr == nil && r.err != nil) {
+				t.Errorf("%s observed %v, %v; want %v, %v", r.method, r.v, r.err, wantV, wantErr)
+			}
+		}
+	}
+}
+
+func TestConcurrentReadersBeforeSettlement(t *testing.T) {
+	release := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 42, nil
+	})
+	wait := readConcurrently(t, f)
+	close(release)
+	wait(42, nil)
+}
+
+func TestConcurrentReadersAfterSettlement(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) { return 42, nil })
+	f.Get()
+	readConcurrently(t, f)(42, nil)
+}
+
+func TestConcurrentReadersMixed(t *testing.T) {
+	errBoom := errors.New("boom")
+	release := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 0, errBoom
+	})
+	early := readConcurrently(t, f)
+	settling := make(chan struct{})
+	go func() {
+		close(release)
+		close(settling)
+	}()
+	during := readConcurrently(t, f)
+	<-settling
+	f.Get()
+	late := readConcurrently(t, f)
+	early(0, errBoom)
+	during(0, errBoom)
+	late(0, errBoom)
+}
+
+func TestConcurrentReadersAndCancel(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	wait := readConcurrently(t, f)
+	go f.Cancel()
+	go f.Cancel()
+	wait(0, futures.ErrCanceled)
+}
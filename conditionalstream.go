@@ -0,0 +1,54 @@
+package futures
+
+// ConditionalStream returns a Stream that routes values from two streams
+// according to the signals of control. For every true that control
+// emits, the next value of onTrue is forwarded; for every false, the next
+// value of onFalse.
+//
+// The returned Stream ends when control ends, with the error of control.
+// If a signal selects a stream that has ended, the returned Stream ends
+// with the error of that stream. Closing the returned Stream closes all
+// three streams, and so does its end.
+func ConditionalStream[T any](control *Stream[bool], onTrue, onFalse *Stream[T], opts ...StreamOption) *Stream[T] {
+	out := newStream[T](newStreamOptions(0, opts))
+	go routeStream(out, control, onTrue, onFalse)
+	return out
+}
+
+// routeStream runs the Stream returned by ConditionalStream.
+func routeStream[T any](out *Stream[T], control *Stream[bool], onTrue, onFalse *Stream[T]) {
+	defer onFalse.Close()
+	defer onTrue.Close()
+	defer control.Close()
+	for {
+		var cond bool
+		select {
+		case c, ok := <-control.C():
+			if !ok {
+				out.close(control.Err())
+				return
+			}
+			cond = c
+		case <-out.quit:
+			out.close(nil)
+			return
+		}
+		src := onFalse
+		if cond {
+			src = onTrue
+		}
+		select {
+		case v, ok := <-src.C():
+			if !ok {
+				out.close(src.Err())
+				return
+			}
+			if !out.send(v) {
+				return
+			}
+		case <-out.quit:
+			out.close(nil)
+			return
+		}
+	}
+}
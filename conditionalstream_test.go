@@ -0,0 +1,58 @@
+package futures_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+// streamOf returns a Stream of vs that ends after the last value.
+func streamOf[T any](vs ...T) *futures.Stream[T] {
+	ch := make(chan T, len(vs))
+	for _, v := range vs {
+		ch <- v
+	}
+	close(ch)
+	return futures.StreamOf(ch)
+}
+
+func TestConditionalStream(t *testing.T) {
+	var signals []bool
+	for i := 0; i < 5; i++ {
+		signals = append(signals, true, false)
+	}
+	out := futures.ConditionalStream(
+		streamOf(signals...),
+		streamOf(1, 3, 5, 7, 9, 11),
+		streamOf(2, 4, 6, 8, 10, 12),
+	)
+	var got []int
+	for v := range out.C() {
+		got = append(got, v)
+	}
+	if want := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := out.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestConditionalStreamSourceEnded(t *testing.T) {
+	control := streamOf(true, true, false)
+	onFalse := streamOf(2)
+	out := futures.ConditionalStream(control, streamOf(1), onFalse)
+	var got []int
+	for v := range out.C() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1}) {
+		t.Errorf("got %v, want [1]", got)
+	}
+	// The end of the returned Stream closes the other streams.
+	for range onFalse.C() {
+	}
+	for range control.C() {
+	}
+}
@@ -0,0 +1,207 @@
+package futures
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PackageOptions are defaults that apply to all futures of the package.
+type PackageOptions struct {
+	// MaxConcurrent limits how many computations started by New and its
+	// relatives run at the same time. Computations beyond the limit wait
+	// for a free slot; a computation whose Future is canceled while it
+	// waits never runs. Zero means no limit.
+	//
+	// Beware of nesting: a computation that awaits another Future holds
+	// its slot while waiting. If all slots are held by computations that
+	// wait for futures that have not got a slot yet, nothing can proceed.
+	// Use the limit only for computations that do not await other futures,
+	// or set it high enough to cover the nesting depth.
+	MaxConcurrent int
+	// DefaultTimeout, if positive, is the timeout for computations whose
+	// context has no deadline.
+	DefaultTimeout time.Duration
+	// LogLevel is the minimum level of messages the package logs through
+	// slog.Default.
+	LogLevel slog.Level
+	// DisableMetrics keeps the package from reporting to the sink set by
+	// SetMetricsSink, without unsetting it. Types that take a sink of
+	// their own, such as PoolWithMetrics, report regardless.
+	DisableMetrics bool
+}
+
+// Environment variables read by LoadConfig.
+const (
+	EnvMaxConcurrent    = "FUTURES_MAX_CONCURRENT"
+	EnvDefaultTimeoutMS = "FUTURES_DEFAULT_TIMEOUT_MS"
+	EnvLogLevel         = "FUTURES_LOG_LEVEL"
+	EnvMetricsEnabled   = "FUTURES_METRICS_ENABLED"
+)
+
+var (
+	pkgOptions atomic.Pointer[PackageOptions]
+	// slots limits the number of running computations.
+	slots limiter
+)
+
+func init() {
+	pkgOptions.Store(&PackageOptions{LogLevel: slog.LevelInfo})
+}
+
+// LoadConfig reads the package options from the environment variables
+// FUTURES_MAX_CONCURRENT, FUTURES_DEFAULT_TIMEOUT_MS, FUTURES_LOG_LEVEL,
+// and FUTURES_METRICS_ENABLED, applies them with SetPackageOptions, and
+// returns them. FUTURES_METRICS_ENABLED takes the values of
+// strconv.ParseBool; false sets DisableMetrics.
+//
+// Unset variables leave the respective option at its default. Invalid
+// values are logged as a warning and ignored.
+//
+// There is no variable for a panic handler. Panic handlers are set per
+// Future with WithPanicHandler, and looking one up by name would need the
+// plugin package, which works on few platforms and only with cgo.
+func LoadConfig() PackageOptions {
+	o := PackageOptions{LogLevel: slog.LevelInfo}
+	if v, ok := os.LookupEnv(EnvMaxConcurrent); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			warnEnv(EnvMaxConcurrent, v)
+		} else {
+			o.MaxConcurrent = n
+		}
+	}
+	if v, ok := os.LookupEnv(EnvDefaultTimeoutMS); ok {
+		ms, err := strconv.Atoi(v)
+		if err != nil || ms < 0 {
+			warnEnv(EnvDefaultTimeoutMS, v)
+		} else {
+			o.DefaultTimeout = time.Duration(ms) * time.Millisecond
+		}
+	}
+	if v, ok := os.LookupEnv(EnvLogLevel); ok {
+		var l slog.Level
+		if err := l.UnmarshalText([]byte(v)); err != nil {
+			warnEnv(EnvLogLevel, v)
+		} else {
+			o.LogLevel = l
+		}
+	}
+	if v, ok := os.LookupEnv(EnvMetricsEnabled); ok {
+		if b, err := strconv.ParseBool(v); err != nil {
+			warnEnv(EnvMetricsEnabled, v)
+		} else {
+			o.DisableMetrics = !b
+		}
+	}
+	SetPackageOptions(o)
+	return o
+}
+
+func warnEnv(name, value string) {
+	logf(slog.LevelWarn, "futures: ignoring invalid environment variable", "name", name, "value", value)
+}
+
+// SetPackageOptions replaces the package options. Computations that are
+// already running keep their slots; a lower MaxConcurrent takes effect as
+// they finish.
+func SetPackageOptions(o PackageOptions) {
+	pkgOptions.Store(&o)
+	slots.setLimit(o.MaxConcurrent)
+}
+
+// CurrentPackageOptions returns the package options in effect.
+func CurrentPackageOptions() PackageOptions {
+	return *pkgOptions.Load()
+}
+
+// MaxConcurrentFutures returns the limit of concurrently running
+// computations, or 0 if there is no limit.
+func MaxConcurrentFutures() int {
+	return pkgOptions.Load().MaxConcurrent
+}
+
+// limiter is a counting semaphore whose limit can change while slots are
+// held. A limit of 0 means no limit.
+type limiter struct {
+	mu      sync.Mutex
+	limit   int
+	running int
+	queue   []chan struct{}
+}
+
+// acquire waits for a free slot. It returns false if ctx is done first.
+func (l *limiter) acquire(ctx context.Context) bool {
+	l.mu.Lock()
+	if l.limit == 0 || l.running < l.limit {
+		l.running++
+		l.mu.Unlock()
+		return true
+	}
+	granted := make(chan struct{})
+	l.queue = append(l.queue, granted)
+	l.mu.Unlock()
+
+	select {
+	case <-granted:
+		return true
+	case <-ctx.Done():
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, ch := range l.queue {
+		if ch == granted {
+			l.queue = append(l.queue[:i], l.queue[i+1:]...)
+			return false
+		}
+	}
+	// The slot was granted while ctx was done; pass it on.
+	l.running--
+	l.grant()
+	return false
+}
+
+func (l *limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.running--
+	l.grant()
+}
+
+func (l *limiter) setLimit(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = n
+	l.grant()
+}
+
+// grant hands free slots to waiters. l.mu must be held.
+func (l *limiter) grant() {
+	for len(l.queue) > 0 && (l.limit == 0 || l.running < l.limit) {
+		l.running++
+		close(l.queue[0])
+		l.queue = l.queue[1:]
+	}
+}
+
+// withDefaultTimeout applies PackageOptions.DefaultTimeout to ctx.
+func withDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	d := pkgOptions.Load().DefaultTimeout
+	if _, ok := ctx.Deadline(); ok || d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// logf logs a message through slog.Default if level is enabled by the
+// package options.
+func logf(level slog.Level, msg string, args ...any) {
+	if level < pkgOptions.Load().LogLevel {
+		return
+	}
+	slog.Default().Log(context.Background(), level, msg, args...)
+}
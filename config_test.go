@@ -0,0 +1,145 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv(EnvMaxConcurrent, "3")
+	t.Setenv(EnvDefaultTimeoutMS, "250")
+	t.Setenv(EnvLogLevel, "bogus")
+	defer SetPackageOptions(PackageOptions{})
+
+	o := LoadConfig()
+	if got := MaxConcurrentFutures(); got != 3 {
+		t.Errorf("MaxConcurrentFutures = %d, want 3", got)
+	}
+	if o.DefaultTimeout != 250*time.Millisecond {
+		t.Errorf("DefaultTimeout = %v, want 250ms", o.DefaultTimeout)
+	}
+	if o.LogLevel != 0 {
+		t.Errorf("LogLevel = %v, want the default for an invalid value", o.LogLevel)
+	}
+}
+
+// counterSink counts the calls of Count.
+type counterSink struct{ n atomic.Int64 }
+
+func (s *counterSink) Count(string, int64)     { s.n.Add(1) }
+func (s *counterSink) Gauge(string, float64)   {}
+func (s *counterSink) Observe(string, float64) {}
+
+func TestLoadConfigMetricsEnabled(t *testing.T) {
+	sink := &counterSink{}
+	SetMetricsSink(sink)
+	defer SetMetricsSink(nil)
+	defer SetPackageOptions(PackageOptions{})
+
+	for _, c := range []struct {
+		value       string
+		wantDisable bool
+	}{
+		{"false", true},
+		{"0", true},
+		{"true", false},
+		{"maybe", false}, // invalid, so the default
+	} {
+		t.Setenv(EnvMetricsEnabled, c.value)
+		if o := LoadConfig(); o.DisableMetrics != c.wantDisable {
+			t.Errorf("%s=%s: DisableMetrics = %v, want %v", EnvMetricsEnabled, c.value, o.DisableMetrics, c.wantDisable)
+		}
+		before := sink.n.Load()
+		count(MetricFuturesUnread, 1)
+		if reported := sink.n.Load() > before; reported == c.wantDisable {
+			t.Errorf("%s=%s: metric reported = %v, want %v", EnvMetricsEnabled, c.value, reported, !c.wantDisable)
+		}
+	}
+}
+
+func TestMaxConcurrent(t *testing.T) {
+	SetPackageOptions(PackageOptions{MaxConcurrent: 2})
+	defer SetPackageOptions(PackageOptions{})
+
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	var futs []*Future[int]
+	for i := 0; i < 6; i++ {
+		futs = append(futs, New(func(ctx context.Context) (int, error) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			<-release
+			running.Add(-1)
+			return 0, nil
+		}))
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	for _, f := range futs {
+		f.Get()
+	}
+	if p := peak.Load(); p != 2 {
+		t.Errorf("peak concurrency = %d, want 2", p)
+	}
+}
+
+func TestMaxConcurrentCanceledWhileQueued(t *testing.T) {
+	SetPackageOptions(PackageOptions{MaxConcurrent: 1})
+	defer SetPackageOptions(PackageOptions{})
+
+	release := make(chan struct{})
+	holder := New(func(ctx context.Context) (int, error) {
+		<-release
+		return 0, nil
+	})
+	var ran atomic.Bool
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := NewWithContext(ctx, func(ctx context.Context) (int, error) {
+		ran.Store(true)
+		return 0, nil
+	})
+	cancel()
+	if _, err := queued.Get(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("queued Future: err = %v, want context.Canceled", err)
+	}
+	close(release)
+	holder.Get()
+
+	// The canceled Future must not have kept a slot or a place in line.
+	next := New(func(ctx context.Context) (int, error) { return 1, nil })
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := next.GetWithContext(ctx); err != nil {
+		t.Fatalf("next Future: %v", err)
+	}
+	if ran.Load() {
+		t.Error("the canceled Future's computation ran")
+	}
+}
+
+func TestLimiterLoweredLimit(t *testing.T) {
+	var l limiter
+	l.setLimit(2)
+	ctx := context.Background()
+	l.acquire(ctx)
+	l.acquire(ctx)
+	l.setLimit(1)
+	l.release()
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if l.acquire(short) {
+		t.Fatal("acquired a slot although the lowered limit is still in use")
+	}
+	l.release()
+	if !l.acquire(ctx) {
+		t.Fatal("could not acquire the freed slot")
+	}
+}
@@ -0,0 +1,34 @@
+package futures
+
+import "context"
+
+// ContextKey is a typed key for passing a Future through a context, for
+// example along an HTTP middleware chain. Keys of different types never
+// collide, even if they have the same name, and Get returns a Future of
+// the right type without a type assertion at the call site.
+type ContextKey[T any] struct {
+	name string
+}
+
+// NewContextKey returns a ContextKey for futures of type T. The name only
+// serves debugging; two keys for the same T and name are equal.
+func NewContextKey[T any](name string) ContextKey[T] {
+	return ContextKey[T]{name: name}
+}
+
+// Set returns a copy of ctx that carries f under k.
+func (k ContextKey[T]) Set(ctx context.Context, f *Future[T]) context.Context {
+	return context.WithValue(ctx, k, f)
+}
+
+// Get returns the Future stored in ctx under k. It returns false if ctx
+// carries no Future under k.
+func (k ContextKey[T]) Get(ctx context.Context) (*Future[T], bool) {
+	f, ok := ctx.Value(k).(*Future[T])
+	return f, ok && f != nil
+}
+
+// String returns the name of k.
+func (k ContextKey[T]) String() string {
+	return "futures.ContextKey(" + k.name + ")"
+}
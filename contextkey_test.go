@@ -0,0 +1,41 @@
+package futures_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestContextKey(t *testing.T) {
+	key := futures.NewContextKey[int]("user")
+	f := futures.Completed(7)
+	ctx := key.Set(context.Background(), f)
+
+	got, ok := key.Get(ctx)
+	if !ok || got != f {
+		t.Fatalf("Get = %v, %v; want the stored Future", got, ok)
+	}
+	if got, ok := futures.NewContextKey[int]("user").Get(ctx); !ok || got != f {
+		t.Errorf("an equal key does not find the Future")
+	}
+	if _, ok := futures.NewContextKey[int]("other").Get(ctx); ok {
+		t.Error("a key with another name finds the Future")
+	}
+}
+
+func TestContextKeyTypeSafety(t *testing.T) {
+	ctx := futures.NewContextKey[int]("result").Set(context.Background(), futures.Completed(1))
+	if f, ok := futures.NewContextKey[string]("result").Get(ctx); ok || f != nil {
+		t.Errorf("a string key retrieved the int Future: %v, %v", f, ok)
+	}
+	if ctx.Value("result") != nil {
+		t.Error("a plain string key collides with the ContextKey")
+	}
+}
+
+func TestContextKeyMissing(t *testing.T) {
+	if _, ok := futures.NewContextKey[int]("x").Get(context.Background()); ok {
+		t.Error("Get on an empty context returned true")
+	}
+}
@@ -0,0 +1,174 @@
+package futures
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Core is the settlement machinery that Future is built on. Embed it in a
+// struct to build a future type of your own, for example one with
+// domain-specific methods, without dealing with channels and atomics:
+//
+//	type QueryFuture struct {
+//		futures.Core[[]Row]
+//		partial atomic.Int64
+//	}
+//
+//	func (q *QueryFuture) PartialRows() int64 { return q.partial.Load() }
+//
+// The producer of the result calls Settle; the readers use Done, Get,
+// GetWithContext, and Err. A type that embeds Core passes the conformance
+// tests of futuretest.RunConformance.
+//
+// Core guarantees the following invariants:
+//
+//   - It settles at most once. Only the first call to Settle has an effect
+//     and returns true.
+//   - The value and error never change after settlement, and reading them
+//     never blocks.
+//   - The Done channel is closed exactly when Core settles.
+//   - Each callback added by AddCallback runs exactly once, after
+//     settlement, in the order the callbacks were added.
+//
+// The zero Core is pending and ready to use. A Core must not be copied
+// after first use.
+type Core[T any] struct {
+	mu        sync.Mutex
+	done      chan struct{}
+	settled   bool
+	value     T
+	err       error
+	callbacks []func(T, error)
+	waiters   atomic.Int32
+
+	// resolved is set once value and err are final. It gives readers of a
+	// settled Core a path that takes no lock; see the GetSettled benchmarks.
+	resolved atomic.Bool
+}
+
+// Settle settles c with the value v and the error err, closes the Done
+// channel, and runs the callbacks in the calling goroutine. It reports
+// whether this call settled c; if c has settled already, Settle does
+// nothing and returns false.
+//
+// Settle is meant for the producer of the result. Types that embed Core
+// and hand out their values to readers should not let the readers settle
+// them; embed Core in an unexported field and forward the reading methods
+// if that matters.
+func (c *Core[T]) Settle(v T, err error) bool {
+	return c.settle(v, err, nil, nil)
+}
+
+// settle is Settle that also stores the time of the settlement, as told
+// by clock, in *at, if at is not nil. It does so while holding c.mu.
+func (c *Core[T]) settle(v T, err error, clock Clock, at *time.Time) bool {
+	c.mu.Lock()
+	if c.settled {
+		c.mu.Unlock()
+		return false
+	}
+	if at != nil {
+		*at = clock.Now()
+	}
+	c.settled = true
+	c.value, c.err = v, err
+	c.resolved.Store(true)
+	if c.done == nil {
+		c.done = closedChan
+	} else {
+		close(c.done)
+	}
+	callbacks := c.callbacks
+	c.callbacks = nil
+	c.mu.Unlock()
+	for _, fn := range callbacks {
+		fn(v, err)
+	}
+	return true
+}
+
+// AddCallback arranges for fn to be called with the result once c has
+// settled. If c has settled already, fn runs right away in the calling
+// goroutine; otherwise it runs in the goroutine that calls Settle.
+func (c *Core[T]) AddCallback(fn func(v T, err error)) {
+	c.mu.Lock()
+	if !c.settled {
+		c.callbacks = append(c.callbacks, fn)
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+	fn(c.value, c.err)
+}
+
+// AddWaiter registers a reader that is about to block until c settles,
+// for Waiters to report. Call the returned function once the reader has
+// stopped waiting. Get and GetWithContext do this on their own.
+func (c *Core[T]) AddWaiter() (done func()) {
+	c.waiters.Add(1)
+	return func() { c.waiters.Add(-1) }
+}
+
+// Waiters returns the number of readers that are currently waiting for c.
+func (c *Core[T]) Waiters() int {
+	return int(c.waiters.Load())
+}
+
+// Done returns a channel that is closed when c has settled.
+func (c *Core[T]) Done() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done == nil {
+		c.done = make(chan struct{})
+	}
+	return c.done
+}
+
+// Get blocks until c has settled and returns its value and error.
+func (c *Core[T]) Get() (T, error) {
+	if c.resolved.Load() {
+		return c.value, c.err
+	}
+	done := c.Done()
+	defer c.AddWaiter()()
+	<-done
+	return c.value, c.err
+}
+
+// GetWithContext is like Get but gives up waiting when ctx is done, in
+// which case it returns the zero value and ErrCanceled or ErrTimeout.
+func (c *Core[T]) GetWithContext(ctx context.Context) (T, error) {
+	if c.resolved.Load() {
+		return c.value, c.err
+	}
+	done := c.Done()
+	select {
+	case <-done:
+		return c.value, c.err
+	default:
+	}
+	defer c.AddWaiter()()
+	select {
+	case <-done:
+		return c.value, c.err
+	case <-ctx.Done():
+		var zero T
+		return zero, contextError(ctx)
+	}
+}
+
+// Err returns the error c settled with, or nil while c is pending or if it
+// settled successfully. Err does not block.
+func (c *Core[T]) Err() error {
+	if c.resolved.Load() {
+		return c.err
+	}
+	select {
+	case <-c.Done():
+		return c.err
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestCoreZeroValue(t *testing.T) {
+	var c futures.Core[int]
+	if c.Err() != nil {
+		t.Fatal("zero Core is not pending")
+	}
+	if !c.Settle(1, nil) {
+		t.Error("first Settle returned false")
+	}
+	if c.Settle(2, errors.New("too late")) {
+		t.Error("second Settle returned true")
+	}
+	if v, err := c.Get(); v != 1 || err != nil {
+		t.Errorf("Get = %v, %v; want 1, nil", v, err)
+	}
+}
+
+func TestCoreSettleWithoutDone(t *testing.T) {
+	var c futures.Core[int]
+	c.Settle(1, nil)
+	select {
+	case <-c.Done():
+	default:
+		t.Error("Done is not closed")
+	}
+}
+
+func TestCoreCallbacks(t *testing.T) {
+	var c futures.Core[int]
+	var got []int
+	c.AddCallback(func(v int, err error) { got = append(got, v) })
+	c.AddCallback(func(v int, err error) { got = append(got, v*10) })
+	if len(got) != 0 {
+		t.Fatal("callback ran before settlement")
+	}
+	c.Settle(2, nil)
+	c.Settle(3, nil)
+	c.AddCallback(func(v int, err error) { got = append(got, v*100) })
+	want := []int{2, 20, 200}
+	if len(got) != len(want) {
+		t.Fatalf("callbacks saw %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("callbacks saw %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCoreWaiters(t *testing.T) {
+	var c futures.Core[int]
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.GetWithContext(context.Background())
+	}()
+	eventually(t, func() bool { return c.Waiters() == 1 }, "the reader was not counted")
+	c.Settle(1, nil)
+	<-done
+	if n := c.Waiters(); n != 0 {
+		t.Errorf("Waiters() = %d after settlement, want 0", n)
+	}
+}
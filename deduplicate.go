@@ -0,0 +1,62 @@
+package futures
+
+import (
+	"sync"
+	"time"
+)
+
+// Deduplicate wraps fn so that calls that come in while a result is being
+// computed, or within window after a successful result, share that result
+// instead of triggering the computation again. Use it for idempotent
+// operations that must not run twice in quick succession.
+//
+// The returned function hands out the same Future to every caller it
+// deduplicates, so canceling that Future affects all of them. A failed
+// result is not reused; the next call calls fn again. Pass WithClock to
+// replace the clock that measures the window.
+func Deduplicate[T any](fn func() *Future[T], window time.Duration, opts ...Option) func() *Future[T] {
+	d := &dedup[T]{fn: fn, window: window, clock: newOptions(opts).clock}
+	return d.get
+}
+
+// dedup is the state behind a function returned by Deduplicate.
+type dedup[T any] struct {
+	fn     func() *Future[T]
+	window time.Duration
+	clock  Clock
+
+	mu         sync.Mutex
+	cur        *Future[T]
+	resolvedAt time.Time
+}
+
+func (d *dedup[T]) get() *Future[T] {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cur != nil {
+		select {
+		case <-d.cur.Done():
+			if d.cur.core.err == nil {
+				if d.resolvedAt.IsZero() {
+					d.resolvedAt = d.clock.Now()
+				}
+				if d.clock.Now().Sub(d.resolvedAt) < d.window {
+					return d.cur
+				}
+			}
+		default:
+			return d.cur
+		}
+	}
+	f := attemptFuture(d.fn)
+	d.cur, d.resolvedAt = f, time.Time{}
+	go func() {
+		<-f.Done()
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.cur == f && d.resolvedAt.IsZero() {
+			d.resolvedAt = d.clock.Now()
+		}
+	}()
+	return f
+}
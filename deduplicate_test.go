@@ -0,0 +1,64 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestDeduplicate(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	calls := 0
+	send := futures.Deduplicate(func() *futures.Future[int] {
+		calls++
+		return futures.Completed(calls)
+	}, 100*time.Millisecond, futures.WithClock(clock))
+
+	first := send()
+	clock.Advance(50 * time.Millisecond)
+	if second := send(); second != first {
+		t.Error("calls within the window returned different futures")
+	}
+	clock.Advance(200 * time.Millisecond)
+	third := send()
+	if third == first {
+		t.Error("calls 200ms apart returned the same future")
+	}
+	if v, _ := third.Get(); v != 2 || calls != 2 {
+		t.Errorf("third call: value %d after %d calls, want 2 after 2", v, calls)
+	}
+}
+
+func TestDeduplicateInFlight(t *testing.T) {
+	release := make(chan struct{})
+	calls := 0
+	send := futures.Deduplicate(func() *futures.Future[int] {
+		calls++
+		return futures.New(func(ctx context.Context) (int, error) {
+			<-release
+			return 1, nil
+		})
+	}, 0)
+	a, b := send(), send()
+	close(release)
+	if a != b || calls != 1 {
+		t.Errorf("pending calls were not deduplicated: %d calls", calls)
+	}
+}
+
+func TestDeduplicateFailure(t *testing.T) {
+	calls := 0
+	send := futures.Deduplicate(func() *futures.Future[int] {
+		calls++
+		return futures.Failed[int](errors.New("failed"))
+	}, time.Hour)
+	send().Wait(context.Background())
+	send().Wait(context.Background())
+	if calls != 2 {
+		t.Errorf("fn called %d times, want 2; failures must not be reused", calls)
+	}
+}
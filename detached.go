@@ -0,0 +1,33 @@
+package futures
+
+import (
+	"context"
+)
+
+// NewDetached runs fn in a new goroutine, like New, but lets fn deliver
+// the result before it returns. fn calls resolve once the result is known;
+// the Future settles right away, and fn can go on with cleanup or other
+// work without blocking anyone. This is the equivalent of the buffered
+// channel from the article's "do more after computing" section.
+//
+// Only the first call to resolve has an effect. The context passed to fn
+// stays valid until fn returns. If fn returns without calling resolve, the
+// Future fails with ErrBrokenPromise.
+func NewDetached[T any](fn func(ctx context.Context, resolve func(T, error)), opts ...Option) *Future[T] {
+	var f *Future[T]
+	resolve := func(v T, err error) {
+		if err == nil {
+			checkContexts(f.name, v)
+		}
+		f.settle(v, err)
+	}
+	f, start := prepare(context.Background(), func(ctx context.Context) (T, error) {
+		fn(ctx, resolve)
+		// If fn has called resolve, the Future has settled already, and
+		// this result is ignored.
+		var zero T
+		return zero, ErrBrokenPromise
+	}, newOptions(opts))
+	start()
+	return f
+}
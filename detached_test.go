@@ -0,0 +1,50 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestNewDetachedProducerKeepsWorking(t *testing.T) {
+	resolved := make(chan struct{})
+	release := make(chan struct{})
+	cleanedUp := make(chan struct{})
+	f := futures.NewDetached(func(ctx context.Context, resolve func(int, error)) {
+		resolve(42, nil)
+		close(resolved)
+		// Cleanup after delivering the result.
+		<-release
+		resolve(0, context.Canceled) // ignored
+		close(cleanedUp)
+	})
+
+	<-resolved
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := f.Get(); v != 42 || err != nil {
+				t.Errorf("Get = %d, %v; want 42, nil", v, err)
+			}
+		}()
+	}
+	// All readers get the value while the producer is still working.
+	wg.Wait()
+	close(release)
+	<-cleanedUp
+	if v, err := f.Get(); v != 42 || err != nil {
+		t.Errorf("Get after cleanup = %d, %v; want 42, nil", v, err)
+	}
+}
+
+func TestNewDetachedNotResolved(t *testing.T) {
+	f := futures.NewDetached(func(ctx context.Context, resolve func(int, error)) {})
+	if _, err := f.Get(); !errors.Is(err, futures.ErrBrokenPromise) {
+		t.Errorf("Get: err = %v, want ErrBrokenPromise", err)
+	}
+}
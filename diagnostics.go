@@ -0,0 +1,164 @@
+package futures
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FutureDiagnostics describes how the computation of a DiagnosticFuture
+// went.
+type FutureDiagnostics struct {
+	// GoroutineID is the ID of the goroutine that ran the computation.
+	GoroutineID uint64
+	// Created is the time the future was created.
+	Created time.Time
+	// Started is the time the computation started.
+	Started time.Time
+	// Finished is the time the computation returned.
+	Finished time.Time
+	// AllocBytes and Mallocs are the bytes and heap objects allocated
+	// while the computation ran. They are taken from runtime.MemStats and
+	// therefore include allocations of all other goroutines running at the
+	// same time.
+	AllocBytes uint64
+	Mallocs    uint64
+	// Logs contains the records logged through DiagnosticLogger.
+	Logs []slog.Record
+}
+
+// DiagnosticFuture is a Future that records diagnostics about its
+// computation. It is meant for debugging: collecting allocation statistics
+// calls runtime.ReadMemStats, which stops the world briefly.
+type DiagnosticFuture[T any] struct {
+	*Future[T]
+
+	mu       sync.Mutex
+	finished bool
+	diag     FutureDiagnostics
+}
+
+// NewDiagnostic is like New but returns a DiagnosticFuture. fn can log
+// messages to the diagnostics through the logger returned by
+// DiagnosticLogger(ctx).
+func NewDiagnostic[T any](fn func(ctx context.Context) (T, error), opts ...Option) *DiagnosticFuture[T] {
+	clock := newOptions(opts).clock
+	d := &DiagnosticFuture[T]{}
+	d.diag.Created = clock.Now()
+	d.Future = New(func(ctx context.Context) (T, error) {
+		diag := FutureDiagnostics{
+			Created:     d.diag.Created,
+			Started:     clock.Now(),
+			GoroutineID: goroutineID(),
+		}
+		h := &diagHandler{rec: &diagRecorder{}, next: slog.Default().Handler()}
+		ctx = context.WithValue(ctx, diagLoggerKey{}, slog.New(h))
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		v, err := fn(ctx)
+		runtime.ReadMemStats(&after)
+
+		diag.Finished = clock.Now()
+		diag.AllocBytes = after.TotalAlloc - before.TotalAlloc
+		diag.Mallocs = after.Mallocs - before.Mallocs
+		diag.Logs = h.rec.records()
+		d.mu.Lock()
+		d.diag, d.finished = diag, true
+		d.mu.Unlock()
+		return v, err
+	}, opts...)
+	return d
+}
+
+// Diagnostics returns the diagnostics of the computation, or nil if the
+// computation has not returned yet. Note that a canceled future settles
+// before its computation returns.
+func (d *DiagnosticFuture[T]) Diagnostics() *FutureDiagnostics {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.finished {
+		return nil
+	}
+	diag := d.diag
+	return &diag
+}
+
+type diagLoggerKey struct{}
+
+// DiagnosticLogger returns the logger that records messages into the
+// diagnostics of the DiagnosticFuture whose computation received ctx.
+// Messages are passed on to slog.Default as well. If ctx does not belong to
+// a DiagnosticFuture, DiagnosticLogger returns slog.Default().
+func DiagnosticLogger(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(diagLoggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
+
+type diagRecorder struct {
+	mu   sync.Mutex
+	logs []slog.Record
+}
+
+func (r *diagRecorder) add(rec slog.Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, rec)
+}
+
+func (r *diagRecorder) records() []slog.Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]slog.Record(nil), r.logs...)
+}
+
+// diagHandler records every message and passes it on to next.
+type diagHandler struct {
+	rec   *diagRecorder
+	next  slog.Handler
+	attrs []slog.Attr
+}
+
+func (h *diagHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *diagHandler) Handle(ctx context.Context, r slog.Record) error {
+	rec := r.Clone()
+	rec.AddAttrs(h.attrs...)
+	h.rec.add(rec)
+	if h.next.Enabled(ctx, r.Level) {
+		return h.next.Handle(ctx, r)
+	}
+	return nil
+}
+
+func (h *diagHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &diagHandler{
+		rec:   h.rec,
+		next:  h.next.WithAttrs(attrs),
+		attrs: append(append([]slog.Attr(nil), h.attrs...), attrs...),
+	}
+}
+
+func (h *diagHandler) WithGroup(name string) slog.Handler {
+	return &diagHandler{rec: h.rec, next: h.next.WithGroup(name), attrs: h.attrs}
+}
+
+// goroutineID parses the ID of the current goroutine from its stack trace.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,49 @@
+package futures_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+var diagSink []byte
+
+func TestDiagnosticFuture(t *testing.T) {
+	d := futures.NewDiagnostic(func(ctx context.Context) (int, error) {
+		diagSink = make([]byte, 1<<20)
+		futures.DiagnosticLogger(ctx).Info("allocated", "bytes", len(diagSink))
+		return 1, nil
+	})
+	if _, err := d.Get(); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	diag := d.Diagnostics()
+	if diag == nil {
+		t.Fatal("Diagnostics = nil after Get")
+	}
+	if diag.AllocBytes < 1<<20 {
+		t.Errorf("AllocBytes = %d, want at least 1 MiB", diag.AllocBytes)
+	}
+	if diag.GoroutineID == 0 {
+		t.Error("GoroutineID = 0")
+	}
+	if diag.Started.Before(diag.Created) || diag.Finished.Before(diag.Started) {
+		t.Errorf("times out of order: created %v, started %v, finished %v", diag.Created, diag.Started, diag.Finished)
+	}
+	if len(diag.Logs) != 1 || diag.Logs[0].Message != "allocated" {
+		t.Errorf("Logs = %v, want the one message logged", diag.Logs)
+	}
+}
+
+func TestDiagnosticsPending(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	d := futures.NewDiagnostic(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	if d.Diagnostics() != nil {
+		t.Error("Diagnostics != nil while pending")
+	}
+}
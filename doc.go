@@ -0,0 +1,23 @@
+// Package futures provides a small set of types and functions for working
+// with values that are computed asynchronously.
+//
+// The article in futures.go shows that a channel and a goroutine are all you
+// need for a basic future. This package collects the patterns that come up
+// once a future needs more than that: reading the result more than once,
+// waiting with a timeout, canceling the computation, and combining several
+// futures into one.
+//
+// A Future is created by New, which runs the given function in a new
+// goroutine:
+//
+//	f := futures.New(func(ctx context.Context) (int, error) {
+//		return compute(ctx, 256)
+//	})
+//	// ... do other things ...
+//	value, err := f.Get()
+//
+// A Future settles exactly once. After that, any number of goroutines can
+// read the same value or error.
+package futures
+
+//go:generate go run ./cmd/specgen -in spec_test.go -out SPEC.md
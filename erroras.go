@@ -0,0 +1,26 @@
+package futures
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrWrongErrorType is the error of a Future returned by ErrorAs whose
+// source failed with an error that is not of the requested type.
+var ErrWrongErrorType = errors.New("futures: wrong error type")
+
+// ErrorAs returns a Future for the error of f, typed as E. If f fails with
+// an error that errors.As finds an E in, the returned Future resolves to
+// that E. If f fails with another error, the returned Future fails with an
+// error that matches both ErrWrongErrorType and the error of f. If f
+// resolves, the returned Future resolves to the zero value of E, meaning
+// no error.
+func ErrorAs[E error, T any](f *Future[T]) *Future[E] {
+	return chain(f, func(_ T, err error) (E, error) {
+		var e E
+		if err == nil || errors.As(err, &e) {
+			return e, nil
+		}
+		return e, fmt.Errorf("%w: %w", ErrWrongErrorType, err)
+	})
+}
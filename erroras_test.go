@@ -0,0 +1,32 @@
+package futures_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+type quotaError struct{ limit int }
+
+func (e *quotaError) Error() string { return fmt.Sprintf("quota of %d exceeded", e.limit) }
+
+func TestErrorAs(t *testing.T) {
+	qe := &quotaError{limit: 10}
+	e, err := futures.ErrorAs[*quotaError](futures.Failed[int](fmt.Errorf("upload: %w", qe))).Get()
+	if err != nil || e != qe {
+		t.Errorf("matching type: Get = %v, %v; want %v, nil", e, err, qe)
+	}
+
+	errBoom := errors.New("boom")
+	_, err = futures.ErrorAs[*quotaError](futures.Failed[int](errBoom)).Get()
+	if !errors.Is(err, futures.ErrWrongErrorType) || !errors.Is(err, errBoom) {
+		t.Errorf("wrong type: err = %v, want ErrWrongErrorType wrapping %v", err, errBoom)
+	}
+
+	e, err = futures.ErrorAs[*quotaError](futures.Completed(1)).Get()
+	if err != nil || e != nil {
+		t.Errorf("success: Get = %v, %v; want nil, nil", e, err)
+	}
+}
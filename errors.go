@@ -0,0 +1,141 @@
+package futures
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoFutures is returned by combinators that were called without any
+// futures to combine.
+var ErrNoFutures = errors.New("futures: no futures given")
+
+var (
+	// ErrCanceled is the error of a Future that was canceled, either
+	// through Cancel or through the context it was created with, and of
+	// a read that gave up because its context was canceled. It matches
+	// context.Canceled in errors.Is.
+	ErrCanceled error = &contextErr{msg: "futures: canceled", ctxErr: context.Canceled}
+	// ErrTimeout is the error of a Future whose context passed its
+	// deadline, and of a read that gave up because its context did. It
+	// matches context.DeadlineExceeded in errors.Is.
+	ErrTimeout error = &contextErr{msg: "futures: timeout", ctxErr: context.DeadlineExceeded}
+)
+
+// contextErr is the type of ErrCanceled and ErrTimeout.
+type contextErr struct {
+	msg    string
+	ctxErr error
+}
+
+func (e *contextErr) Error() string { return e.msg }
+
+func (e *contextErr) Is(target error) bool { return target == e.ctxErr }
+
+// Timeout reports whether e is ErrTimeout, like the Timeout method of
+// net.Error and of context.DeadlineExceeded.
+func (e *contextErr) Timeout() bool { return e.ctxErr == context.DeadlineExceeded }
+
+// Temporary reports whether e is ErrTimeout: a later attempt might
+// succeed in time, while a canceled one should not be repeated.
+func (e *contextErr) Temporary() bool { return e.Timeout() }
+
+// causeError is ErrCanceled or ErrTimeout together with the cause that
+// was given when canceling.
+type causeError struct {
+	kind  error
+	cause error
+}
+
+func (e *causeError) Error() string { return e.kind.Error() + ": " + e.cause.Error() }
+
+func (e *causeError) Is(target error) bool { return errors.Is(e.kind, target) }
+
+func (e *causeError) Unwrap() error { return e.cause }
+
+// Timeout reports whether e is a timeout, whatever the cause.
+func (e *causeError) Timeout() bool { return e.kind == ErrTimeout }
+
+// Temporary reports whether e is a timeout, whatever the cause.
+func (e *causeError) Temporary() bool { return e.Timeout() }
+
+// contextError translates the error of the done context ctx into
+// ErrCanceled or ErrTimeout, including the cause of the cancellation if
+// there is one.
+func contextError(ctx context.Context) error {
+	var kind error
+	switch err := ctx.Err(); err {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		kind = ErrTimeout
+	default:
+		kind = ErrCanceled
+	}
+	if cause := context.Cause(ctx); cause != nil && cause != ctx.Err() {
+		return &causeError{kind: kind, cause: cause}
+	}
+	return kind
+}
+
+// ErrBrokenPromise is the error of a Future whose producer is gone without
+// having delivered a result: a computation that called runtime.Goexit,
+// or a Promise whose Producer was closed before it
+// resolved the Promise.
+var ErrBrokenPromise = errors.New("futures: broken promise: producer exited without a result")
+
+// ErrAlreadySettled is returned by attempts to settle a Promise that has
+// settled already.
+var ErrAlreadySettled = errors.New("futures: already settled")
+
+// ErrZeroValue is the error of a Future created with WithRequireNonZero
+// whose result was the zero value of its type.
+var ErrZeroValue = errors.New("futures: zero value")
+
+// ErrProducerTimeout is the error of a Promise that was not settled before
+// the deadline set by RejectAfter or RejectAt. Like ErrTimeout, it has
+// Timeout and Temporary methods that return true.
+var ErrProducerTimeout error = &timeoutSentinel{msg: "futures: producer timeout"}
+
+// timeoutSentinel is the type of ErrProducerTimeout.
+type timeoutSentinel struct {
+	msg string
+}
+
+func (e *timeoutSentinel) Error() string { return e.msg }
+
+func (e *timeoutSentinel) Timeout() bool { return true }
+
+func (e *timeoutSentinel) Temporary() bool { return true }
+
+// producerTimeoutError is the error set by RejectAfter with a custom error.
+type producerTimeoutError struct {
+	err error
+}
+
+func (e *producerTimeoutError) Error() string { return ErrProducerTimeout.Error() + ": " + e.err.Error() }
+
+func (e *producerTimeoutError) Is(target error) bool { return target == ErrProducerTimeout }
+
+func (e *producerTimeoutError) Unwrap() error { return e.err }
+
+func (e *producerTimeoutError) Timeout() bool { return true }
+
+func (e *producerTimeoutError) Temporary() bool { return true }
+
+// CancellationError is the error of a Promise that was canceled on
+// purpose through Promise.Cancel. It matches ErrCanceled in errors.Is but,
+// unlike ErrCanceled, not context.Canceled, which tells a deliberate
+// cancellation apart from a context that was canceled.
+type CancellationError struct {
+	// Reason says why the Promise was canceled. It may be empty.
+	Reason string
+}
+
+func (e *CancellationError) Error() string {
+	if e.Reason == "" {
+		return ErrCanceled.Error()
+	}
+	return ErrCanceled.Error() + ": " + e.Reason
+}
+
+func (e *CancellationError) Is(target error) bool { return target == ErrCanceled }
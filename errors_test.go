@@ -0,0 +1,208 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+// blockUntilDone is a computation that runs until its context is done.
+func blockUntilDone(ctx context.Context) (int, error) {
+	<-ctx.Done()
+	return 0, ctx.Err()
+}
+
+func expired() context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	cancel()
+	return ctx
+}
+
+func canceled() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestFailurePathErrors(t *testing.T) {
+	errCause := errors.New("user navigated away")
+	var started []*futures.Future[int]
+	defer func() {
+		for _, f := range started {
+			f.Cancel()
+		}
+	}()
+	pending := func() *futures.Future[int] {
+		f := futures.New(blockUntilDone)
+		started = append(started, f)
+		return f
+	}
+
+	tests := []struct {
+		name        string
+		err         func() error
+		wantTimeout bool
+		wantCause   error
+	}{
+		{"Cancel", func() error {
+			f := pending()
+			f.Cancel()
+			return f.Err()
+		}, false, nil},
+		{"CancelWithCause", func() error {
+			f := pending()
+			f.CancelWithCause(errCause)
+			return f.Err()
+		}, false, errCause},
+		{"parent canceled", func() error {
+			return futures.NewWithContext(canceled(), blockUntilDone).Wait(context.Background())
+		}, false, nil},
+		{"parent canceled with cause", func() error {
+			ctx, cancel := context.WithCancelCause(context.Background())
+			cancel(errCause)
+			return futures.NewWithContext(ctx, blockUntilDone).Wait(context.Background())
+		}, false, errCause},
+		{"parent deadline", func() error {
+			return futures.NewWithContext(expired(), blockUntilDone).Wait(context.Background())
+		}, true, nil},
+		{"computation returns ctx.Err()", func() error {
+			ctx, cancel := context.WithCancel(context.Background())
+			f := futures.NewWithContext(ctx, func(ctx context.Context) (int, error) {
+				cancel()
+				return 0, ctx.Err()
+			})
+			return f.Wait(context.Background())
+		}, false, nil},
+		{"reader canceled", func() error {
+			return pending().Wait(canceled())
+		}, false, nil},
+		{"reader timeout", func() error {
+			_, err := pending().GetWithContext(expired())
+			return err
+		}, true, nil},
+		{"retry canceled", func() error {
+			return futures.RetryWithBackoffContext(canceled(), func() *futures.Future[int] {
+				return futures.Failed[int](errors.New("failed"))
+			}, futures.ConstantBackoff(time.Hour)).Wait(context.Background())
+		}, false, nil},
+		{"AwaitStruct timeout", func() error {
+			s := struct{ XF *futures.Future[int] }{XF: pending()}
+			return futures.AwaitStruct(expired(), &s)
+		}, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			isCanceled, isTimeout := errors.Is(err, futures.ErrCanceled), errors.Is(err, futures.ErrTimeout)
+			if isTimeout != tt.wantTimeout || isCanceled == tt.wantTimeout {
+				t.Errorf("err = %v: Is(ErrCanceled) = %v, Is(ErrTimeout) = %v; want exactly one, timeout: %v",
+					err, isCanceled, isTimeout, tt.wantTimeout)
+			}
+			if errors.Is(err, context.Canceled) != isCanceled || errors.Is(err, context.DeadlineExceeded) != isTimeout {
+				t.Errorf("err = %v does not match the corresponding context error", err)
+			}
+			if tt.wantCause != nil && errors.Unwrap(err) != tt.wantCause {
+				t.Errorf("errors.Unwrap(%v) = %v, want %v", err, errors.Unwrap(err), tt.wantCause)
+			}
+		})
+	}
+}
+
+func TestDefaultTimeoutError(t *testing.T) {
+	old := futures.CurrentPackageOptions()
+	defer futures.SetPackageOptions(old)
+	o := old
+	o.DefaultTimeout = time.Millisecond
+	futures.SetPackageOptions(o)
+
+	err := futures.New(blockUntilDone).Wait(context.Background())
+	if !errors.Is(err, futures.ErrTimeout) || errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want ErrTimeout only", err)
+	}
+}
+
+func TestCancelWithCauseContext(t *testing.T) {
+	errCause := errors.New("shutting down")
+	started := make(chan struct{})
+	seen := make(chan error, 1)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		seen <- context.Cause(ctx)
+		return 0, nil
+	})
+	<-started
+	f.CancelWithCause(errCause)
+	if cause := <-seen; cause != errCause {
+		t.Errorf("context.Cause = %v, want %v", cause, errCause)
+	}
+}
+
+// legacyRetryable is a retry predicate as found in resilience libraries
+// that predate errors.Is: it looks for net.Error-style methods.
+func legacyRetryable(err error) bool {
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return true
+	}
+	var temporary interface{ Temporary() bool }
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
+
+func TestErrorsSatisfyNetErrorMethods(t *testing.T) {
+	type netStyle interface {
+		Timeout() bool
+		Temporary() bool
+	}
+	for _, err := range []error{futures.ErrTimeout, futures.ErrCanceled, futures.ErrProducerTimeout} {
+		if _, ok := err.(netStyle); !ok {
+			t.Errorf("%v has no Timeout and Temporary methods", err)
+		}
+	}
+	var te *futures.TimeoutError
+	if _, ok := any(te).(netStyle); !ok {
+		t.Error("*TimeoutError has no Timeout and Temporary methods")
+	}
+}
+
+func TestErrorsThroughLegacyRetryPredicate(t *testing.T) {
+	timedOut, cancel := context.WithTimeoutCause(context.Background(), -time.Second, errors.New("too slow"))
+	defer cancel()
+	blocked := futures.New(blockUntilDone)
+	defer blocked.Cancel()
+	withTimeout := futures.New(blockUntilDone).WithTimeout(0)
+	canceledWithCause := futures.New(blockUntilDone)
+	canceledWithCause.CancelWithCause(errors.New("shutting down"))
+	producer := futures.NewPromise[int]()
+	producer.RejectAfter(0, errors.New("webhook never came"))
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"ErrTimeout", futures.ErrTimeout, true},
+		{"ErrCanceled", futures.ErrCanceled, false},
+		{"TimeoutError", withTimeout.Wait(context.Background()), true},
+		{"timeout with cause", blocked.Wait(timedOut), true},
+		{"cancel with cause", canceledWithCause.Err(), false},
+		{"ErrProducerTimeout", futures.ErrProducerTimeout, true},
+		{"producer timeout with error", producer.Future().Wait(context.Background()), true},
+		{"CancellationError", &futures.CancellationError{Reason: "user"}, false},
+		{"PanicError", futures.New(func(context.Context) (int, error) { panic("boom") }).Wait(context.Background()), false},
+		{"plain error", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Fatal("no error")
+			}
+			if got := legacyRetryable(tt.err); got != tt.want {
+				t.Errorf("legacyRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
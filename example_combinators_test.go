@@ -0,0 +1,559 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func ExampleZip4() {
+	f := futures.Zip4(
+		futures.Completed("gopher"),
+		futures.Completed(13),
+		futures.Completed(true),
+		futures.Completed(1.5),
+	)
+	q, err := f.Get()
+	fmt.Println(q.First, q.Second, q.Third, q.Fourth, err)
+	// Output:
+	// gopher 13 true 1.5 <nil>
+}
+
+func ExampleMap() {
+	n := futures.Completed(42)
+	s := futures.Map(n, strconv.Itoa)
+	fmt.Println(futures.Map(s, func(s string) string { return "#" + s }).Get())
+	// Output:
+	// #42 <nil>
+}
+
+func ExampleFlatMap() {
+	userID := futures.Completed(7)
+	fetchName := func(id int) *futures.Future[string] {
+		return futures.New(func(ctx context.Context) (string, error) {
+			return fmt.Sprintf("user-%d", id), nil
+		})
+	}
+	// fetchName returns a Future itself; FlatMap flattens the result.
+	fmt.Println(futures.FlatMap(userID, fetchName).Get())
+	// Output:
+	// user-7 <nil>
+}
+
+func ExampleThenInline() {
+	raw := futures.Completed(" 42 ")
+	n := futures.ThenInline(raw, func(s string) (int, error) {
+		return strconv.Atoi(strings.TrimSpace(s))
+	})
+	fmt.Println(n.Get())
+	// Output:
+	// 42 <nil>
+}
+
+func ExampleMapError() {
+	f := futures.Failed[[]byte](fs.ErrNotExist)
+	f = futures.MapError(f, func(err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("profile missing: %w", err)
+		}
+		return err
+	})
+	_, err := f.Get()
+	fmt.Println(err)
+	// Output:
+	// profile missing: file does not exist
+}
+
+func ExampleFuture_Recover() {
+	f := futures.Failed[int](errors.New("sensor offline"))
+	fmt.Println(f.Recover(func(error) int { return -1 }).Get())
+	// Output:
+	// -1 <nil>
+}
+
+func ExampleFinally() {
+	release := make(chan struct{})
+	f := futures.Finally(futures.Completed("rows"), func() {
+		fmt.Println("connection released")
+		close(release)
+	})
+	// The returned Future settles only after the cleanup has run.
+	fmt.Println(f.Get())
+	<-release
+	// Output:
+	// connection released
+	// rows <nil>
+}
+
+func ExampleFuture_OnComplete() {
+	done := make(chan struct{})
+	futures.Failed[int](errors.New("boom")).
+		OnSuccess(func(v int) { fmt.Println("never called") }).
+		OnComplete(func(v int, err error) {
+			fmt.Println("completed:", v, err)
+			close(done)
+		})
+	<-done
+	// Output:
+	// completed: 0 boom
+}
+
+func ExampleFuture_Finally() {
+	done := make(chan struct{})
+	f := futures.Completed("report.pdf").Finally(func() {
+		fmt.Println("temp dir removed")
+		close(done)
+	})
+	<-done
+	fmt.Println(f.Get())
+	// Output:
+	// temp dir removed
+	// report.pdf <nil>
+}
+
+func ExampleFuture_Done() {
+	p := futures.NewPromise[int]()
+	go p.Resolve(7)
+	select {
+	case <-p.Future().Done():
+		fmt.Println(p.Future().Get())
+	case <-time.After(time.Minute):
+		fmt.Println("gave up")
+	}
+	// Output:
+	// 7 <nil>
+}
+
+func ExampleWithJoinedErrors() {
+	var page struct {
+		UserF  *futures.Future[string]
+		User   string
+		CountF *futures.Future[int]
+		Count  int
+	}
+	errUser := errors.New("user service down")
+	errCount := errors.New("count query timed out")
+	page.UserF = futures.Failed[string](errUser)
+	page.CountF = futures.Failed[int](errCount)
+	// The error reports both failures, in the order they were noticed.
+	err := futures.AwaitStruct(context.Background(), &page, futures.WithJoinedErrors())
+	fmt.Println(errors.Is(err, errUser), errors.Is(err, errCount))
+	// Output:
+	// true true
+}
+
+func ExampleSliceMap() {
+	lines := futures.Completed([]string{"1", "2", "3"})
+	nums := futures.SliceMap(lines, strconv.Atoi)
+	fmt.Println(nums.Get())
+
+	_, err := futures.SliceMap(futures.Completed([]string{"1", "x"}), strconv.Atoi).Get()
+	fmt.Println(err)
+	// Output:
+	// [1 2 3] <nil>
+	// strconv.Atoi: parsing "x": invalid syntax
+}
+
+func ExampleSliceMapConcurrent() {
+	ids := futures.Completed([]int{1, 2, 3})
+	squares := futures.SliceMapConcurrent(ids, func(id int) (int, error) {
+		return id * id, nil
+	})
+	// The results are in the order of the elements, not of completion.
+	fmt.Println(squares.Get())
+	// Output:
+	// [1 4 9] <nil>
+}
+
+func ExampleFanOut() {
+	hosts := []string{"a.example", "b.example", "c.example"}
+	f := futures.FanOut(hosts, func(host string) *futures.Future[int] {
+		return futures.New(func(ctx context.Context) (int, error) {
+			return len(host), nil
+		})
+	})
+	fmt.Println(f.Get())
+	// Output:
+	// [9 9 9] <nil>
+}
+
+func ExampleFanOutWithLimit() {
+	f := futures.FanOutWithLimit([]int{1, 2, 3, 4}, 2, func(n int) *futures.Future[int] {
+		return futures.New(func(ctx context.Context) (int, error) {
+			return n * 10, nil
+		})
+	})
+	fmt.Println(f.Get())
+	// Output:
+	// [10 20 30 40] <nil>
+}
+
+func ExampleSequence() {
+	step := func(name string) func() *futures.Future[string] {
+		return func() *futures.Future[string] {
+			fmt.Println("running", name)
+			return futures.Completed(name)
+		}
+	}
+	f := futures.Sequence([]func() *futures.Future[string]{
+		step("create table"),
+		step("add index"),
+	})
+	fmt.Println(f.Get())
+	// Output:
+	// running create table
+	// running add index
+	// [create table add index] <nil>
+}
+
+func ExamplePipeline() {
+	f := futures.Pipeline("  Hello, Gopher  ", []func(string) *futures.Future[string]{
+		func(s string) *futures.Future[string] { return futures.Completed(strings.TrimSpace(s)) },
+		func(s string) *futures.Future[string] { return futures.Completed(strings.ToUpper(s)) },
+	})
+	fmt.Println(f.Get())
+	// Output:
+	// HELLO, GOPHER <nil>
+}
+
+func ExamplePipelineWithContext() {
+	double := func(ctx context.Context, n int) *futures.Future[int] {
+		return futures.NewWithContext(ctx, func(ctx context.Context) (int, error) {
+			return 2 * n, nil
+		})
+	}
+	f := futures.PipelineWithContext(context.Background(), 1,
+		[]func(context.Context, int) *futures.Future[int]{double, double, double})
+	fmt.Println(f.Get())
+	// Output:
+	// 8 <nil>
+}
+
+func ExampleCheckpoint3() {
+	stages := []futures.CheckpointStage[string]{
+		{Name: "not empty", Check: func(s string) error {
+			if s == "" {
+				return errors.New("empty document")
+			}
+			return nil
+		}},
+		{Name: "signed", Check: func(s string) error {
+			if !strings.HasSuffix(s, "-- signed") {
+				return errors.New("signature missing")
+			}
+			return nil
+		}},
+	}
+	fmt.Println(futures.Checkpoint3(futures.Completed("report -- signed"), stages).Get())
+
+	_, err := futures.Checkpoint3(futures.Completed("report"), stages).Get()
+	var cerr *futures.CheckpointError
+	if errors.As(err, &cerr) {
+		fmt.Println(cerr.Stage, "failed:", cerr.Err)
+	}
+	// Output:
+	// report -- signed <nil>
+	// signed failed: signature missing
+}
+
+func ExampleErrorAs() {
+	f := futures.Failed[[]byte](&fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist})
+	perr, err := futures.ErrorAs[*fs.PathError](f).Get()
+	fmt.Println(perr.Path, err)
+
+	_, err = futures.ErrorAs[*fs.PathError](futures.Failed[int](errors.New("other"))).Get()
+	fmt.Println(errors.Is(err, futures.ErrWrongErrorType))
+	// Output:
+	// config.yaml <nil>
+	// true
+}
+
+func ExampleDeduplicate() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	calls := 0
+	refresh := futures.Deduplicate(func() *futures.Future[int] {
+		calls++
+		return futures.Completed(calls)
+	}, time.Minute, futures.WithClock(clock))
+
+	fmt.Println(refresh().Get())
+	// Within the window, callers share the last result.
+	fmt.Println(refresh().Get())
+	clock.Advance(2 * time.Minute)
+	fmt.Println(refresh().Get())
+	// Output:
+	// 1 <nil>
+	// 1 <nil>
+	// 2 <nil>
+}
+
+func ExampleMemoizeWithKey() {
+	load := futures.MemoizeWithKey(func(lang string) *futures.Future[string] {
+		fmt.Println("loading", lang)
+		return futures.Completed("messages." + lang)
+	})
+	fmt.Println(load("de").Get())
+	fmt.Println(load("de").Get())
+	fmt.Println(load("fr").Get())
+	// Output:
+	// loading de
+	// messages.de <nil>
+	// messages.de <nil>
+	// loading fr
+	// messages.fr <nil>
+}
+
+func ExampleNewLazy() {
+	fallback := futures.NewLazy(func() string {
+		fmt.Println("computing fallback")
+		return "default"
+	})
+	fmt.Println(fallback.State())
+	fmt.Println(fallback.Get())
+	fmt.Println(fallback.Get())
+	// Output:
+	// unsettled
+	// computing fallback
+	// default <nil>
+	// default <nil>
+}
+
+func ExampleRetryWithBackoffContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	f := futures.RetryWithBackoffContext(ctx, func() *futures.Future[int] {
+		attempts++
+		if attempts == 2 {
+			// Give up from the outside, for example because the
+			// request that needs the result was canceled.
+			cancel()
+		}
+		return futures.Failed[int](errors.New("unavailable"))
+	}, futures.ConstantBackoff(time.Millisecond))
+	_, err := f.Get()
+	fmt.Println(attempts, errors.Is(err, futures.ErrCanceled))
+	// Output:
+	// 2 true
+}
+
+func ExampleConstantBackoff() {
+	b := futures.ConstantBackoff(500 * time.Millisecond).WithMaxAttempts(3)
+	for attempt := 1; attempt <= 3; attempt++ {
+		fmt.Println(b.Delay(attempt))
+	}
+	// Output:
+	// 500ms
+	// 500ms
+	// -1ns
+}
+
+func ExampleBackoff_WithJitter() {
+	b := futures.ConstantBackoff(time.Second).WithJitter(0.1)
+	d := b.Delay(1)
+	fmt.Println(d >= 900*time.Millisecond && d <= 1100*time.Millisecond)
+	// Output:
+	// true
+}
+
+func ExampleWhenIdle() {
+	// With a generous limit, fn is called right away.
+	f := futures.WhenIdle(func() *futures.Future[string] {
+		return futures.Completed("housekeeping done")
+	}, 1_000_000)
+	fmt.Println(f.Get())
+	// Output:
+	// housekeeping done <nil>
+}
+
+func ExampleFuture_WithTimeout() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	p := futures.NewPromise[string](futures.WithClock(clock))
+	f := p.Future().WithTimeout(5 * time.Second)
+
+	clock.Advance(5 * time.Second)
+	_, err := f.Get()
+	var terr *futures.TimeoutError
+	fmt.Println(errors.Is(err, futures.ErrTimeout), errors.As(err, &terr))
+	fmt.Println(terr.Deadline().Format(time.TimeOnly))
+	// The source was canceled with the timeout as the cause.
+	fmt.Println(p.Settlement())
+	// Output:
+	// true true
+	// 00:00:05
+	// rejected
+}
+
+func ExampleFuture_WithDeadline() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	deadline := clock.Now().Add(time.Minute)
+	p := futures.NewPromise[int](futures.WithClock(clock))
+	f := p.Future().WithDeadline(deadline)
+
+	p.Resolve(200)
+	fmt.Println(f.Get())
+	// Output:
+	// 200 <nil>
+}
+
+func ExampleFuture_TryGet() {
+	p := futures.NewPromise[string]()
+	f := p.Future()
+
+	_, _, ok := f.TryGet()
+	fmt.Println(f.State(), ok)
+	p.Resolve("ready")
+	v, err, ok := f.TryGet()
+	fmt.Println(f.State(), v, err, ok)
+	// Output:
+	// unsettled false
+	// resolved ready <nil> true
+}
+
+func ExampleFuture_State() {
+	p := futures.NewPromise[int]()
+	fmt.Println(p.Future().State())
+	p.Reject(errors.New("no quorum"))
+	fmt.Println(p.Future().State())
+	fmt.Println(futures.Completed(1).State())
+	// Output:
+	// unsettled
+	// rejected
+	// resolved
+}
+
+func ExampleFuture_WasAwaited() {
+	p := futures.NewPromise[int]()
+	f := p.Future()
+	fmt.Println(f.WasAwaited(), f.HasWaiters())
+
+	got := make(chan int)
+	go func() {
+		v, _ := f.Get()
+		got <- v
+	}()
+	for !f.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+	fmt.Println(f.WasAwaited(), f.HasWaiters())
+
+	p.Resolve(1)
+	<-got
+	fmt.Println(f.WasAwaited(), f.HasWaiters())
+	// Output:
+	// false false
+	// true true
+	// true false
+}
+
+func ExampleFuture_CancelWithCause() {
+	errShutdown := errors.New("server shutting down")
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, context.Cause(ctx)
+	})
+	f.CancelWithCause(errShutdown)
+	_, err := f.Get()
+	fmt.Println(errors.Is(err, futures.ErrCanceled), errors.Is(err, errShutdown))
+	// Output:
+	// true true
+}
+
+func ExampleFuture_Close() {
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	// Nobody is going to read f; release what it holds.
+	fmt.Println(f.Close())
+	fmt.Println(f.Err())
+	// Output:
+	// <nil>
+	// futures: canceled
+}
+
+func ExampleFuture_Duration() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	p := futures.NewPromise[int](futures.WithClock(clock))
+	f := p.Future()
+
+	_, ok := f.Duration()
+	fmt.Println(ok)
+	clock.Advance(1500 * time.Millisecond)
+	p.Resolve(1)
+	fmt.Println(f.Duration())
+	// Output:
+	// false
+	// 1.5s true
+}
+
+func ExampleFuture_String() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	f := futures.New(func(ctx context.Context) (int, error) {
+		clock.Advance(3 * time.Second)
+		return 0, errors.New("disk full")
+	}, futures.WithName("backup"), futures.WithClock(clock), futures.WithExecutor(futures.SyncExecutor))
+	fmt.Println(f.Name())
+	fmt.Println(f)
+	// Output:
+	// backup
+	// future "backup" rejected in 3s: disk full
+}
+
+func ExampleIsZeroResolved() {
+	var none *strings.Builder
+	fmt.Println(futures.IsZeroResolved(futures.Completed(none)))
+	fmt.Println(futures.IsZeroResolved(futures.Completed(&strings.Builder{})))
+	// Output:
+	// true
+	// false
+}
+
+func ExampleWithRequireNonZero() {
+	f := futures.New(func(ctx context.Context) (*strings.Builder, error) {
+		return nil, nil
+	}, futures.WithRequireNonZero())
+	_, err := f.Get()
+	fmt.Println(errors.Is(err, futures.ErrZeroValue))
+	// Output:
+	// true
+}
+
+func ExampleChainOf() {
+	p := futures.NewPromise[int]()
+	root := p.Future()
+	last := root.
+		MapError(func(err error) error { return fmt.Errorf("lookup: %w", err) }).
+		Recover(func(error) int { return 0 })
+
+	chain := futures.ChainOf(last)
+	fmt.Println(len(chain.Futures()))
+	chain.Cancel(errors.New("request aborted"))
+	fmt.Println(chain.Wait(context.Background()))
+	// Output:
+	// 3
+	// futures: canceled: request aborted
+}
+
+func ExampleNewCancelGroup() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := futures.NewCancelGroup(ctx)
+	for i := 0; i < 3; i++ {
+		g.Add(futures.New(func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}))
+	}
+	// Canceling the request cancels every Future of the group.
+	cancel()
+	g.Wait()
+	fmt.Println("all settled")
+	// Output:
+	// all settled
+}
@@ -0,0 +1,380 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func ExampleLoadConfig() {
+	defer futures.SetPackageOptions(futures.CurrentPackageOptions())
+	os.Setenv(futures.EnvMaxConcurrent, "64")
+	os.Setenv(futures.EnvDefaultTimeoutMS, "2500")
+	os.Setenv(futures.EnvMetricsEnabled, "false")
+	defer os.Unsetenv(futures.EnvMaxConcurrent)
+	defer os.Unsetenv(futures.EnvDefaultTimeoutMS)
+	defer os.Unsetenv(futures.EnvMetricsEnabled)
+
+	o := futures.LoadConfig()
+	fmt.Println(o.MaxConcurrent, o.DefaultTimeout, o.LogLevel, o.DisableMetrics)
+	fmt.Println(futures.MaxConcurrentFutures())
+	// Output:
+	// 64 2.5s INFO true
+	// 64
+}
+
+func ExampleSetPackageOptions() {
+	defer futures.SetPackageOptions(futures.CurrentPackageOptions())
+	futures.SetPackageOptions(futures.PackageOptions{DefaultTimeout: 10 * time.Millisecond})
+
+	// A computation whose context has no deadline gets the default timeout.
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	_, err := f.Get()
+	fmt.Println(errors.Is(err, futures.ErrTimeout))
+	fmt.Println(futures.CurrentPackageOptions().DefaultTimeout)
+	// Output:
+	// true
+	// 10ms
+}
+
+// collectGarbage runs the garbage collector until done reports true, so that
+// the finalizers of EnsureNoDrop get a chance to run.
+func collectGarbage(done func() bool) {
+	for i := 0; i < 1000 && !done(); i++ {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func ExampleSetMetricsSink() {
+	sink := &countSink{}
+	futures.SetMetricsSink(sink)
+	defer futures.SetMetricsSink(nil)
+	futures.SetMisuseHandler(func(m futures.Misuse) {})
+	defer futures.SetMisuseHandler(nil)
+
+	func() {
+		// The result of this Future is never read.
+		futures.EnsureNoDrop(futures.Completed(1))
+	}()
+	collectGarbage(func() bool { return sink.count(futures.MetricFuturesUnread) > 0 })
+	fmt.Println(futures.MetricFuturesUnread, sink.count(futures.MetricFuturesUnread))
+	// Output:
+	// futures_unread_total 1
+}
+
+func ExampleEnsureNoDrop() {
+	reported := make(chan futures.Misuse, 1)
+	futures.SetMisuseHandler(func(m futures.Misuse) { reported <- m })
+	defer futures.SetMisuseHandler(nil)
+
+	func() {
+		// The result of this Future is never read.
+		futures.EnsureNoDrop(futures.New(func(ctx context.Context) (int, error) {
+			return 0, errors.New("lost")
+		}, futures.WithName("audit log"), futures.WithExecutor(futures.SyncExecutor)))
+	}()
+	collectGarbage(func() bool { return len(reported) > 0 })
+	fmt.Println(<-reported)
+	// Output:
+	// future "audit log": future was garbage-collected without being read
+}
+
+func ExampleSetMisuseHandler() {
+	futures.SetMisuseHandler(func(m futures.Misuse) {
+		fmt.Println("misuse:", m)
+	})
+	defer futures.SetMisuseHandler(nil)
+	futures.CheckResolvedContexts(4)
+	defer futures.CheckResolvedContexts(0)
+
+	type session struct {
+		User string
+		Ctx  context.Context
+	}
+	futures.New(func(ctx context.Context) (session, error) {
+		return session{User: "gopher", Ctx: ctx}, nil
+	}, futures.WithName("login"), futures.WithExecutor(futures.SyncExecutor))
+	// Output:
+	// misuse: future "login": resolved value contains a context.Context at value.Ctx
+}
+
+func ExampleCheckResolvedContexts() {
+	var found []string
+	futures.SetMisuseHandler(func(m futures.Misuse) { found = append(found, m.Message) })
+	defer futures.SetMisuseHandler(nil)
+
+	type request struct{ ctx context.Context }
+	resolve := func() {
+		futures.New(func(ctx context.Context) ([]*request, error) {
+			return []*request{{ctx: ctx}}, nil
+		}, futures.WithExecutor(futures.SyncExecutor))
+	}
+	resolve()
+	fmt.Println(len(found), "with the check off")
+	futures.CheckResolvedContexts(3)
+	defer futures.CheckResolvedContexts(0)
+	resolve()
+	fmt.Println(found)
+	// Output:
+	// 0 with the check off
+	// [resolved value contains a context.Context at value[0].ctx]
+}
+
+func ExampleNewDiagnostic() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	d := futures.NewDiagnostic(func(ctx context.Context) (int, error) {
+		futures.DiagnosticLogger(ctx).Info("fetched rows", "count", 3)
+		clock.Advance(250 * time.Millisecond)
+		return 3, nil
+	}, futures.WithClock(clock))
+	fmt.Println(d.Get())
+
+	diag := d.Diagnostics()
+	fmt.Println(diag.Finished.Sub(diag.Started))
+	for _, rec := range diag.Logs {
+		fmt.Println(rec.Message)
+	}
+	// Output:
+	// 3 <nil>
+	// 250ms
+	// fetched rows
+}
+
+func ExampleWithGoroutineLabel() {
+	f := futures.New(func(ctx context.Context) (string, error) {
+		// The label shows up in profiles and goroutine dumps.
+		label, _ := pprof.Label(ctx, "future")
+		return label, nil
+	}, futures.WithGoroutineLabel("thumbnailer"))
+	fmt.Println(f.Get())
+	// Output:
+	// thumbnailer <nil>
+}
+
+func ExampleWithContextValue() {
+	type traceIDKey struct{}
+	f := futures.New(func(ctx context.Context) (string, error) {
+		return ctx.Value(traceIDKey{}).(string), nil
+	}, futures.WithContextValue(traceIDKey{}, "trace-4711"))
+	fmt.Println(f.Get())
+	// Output:
+	// trace-4711 <nil>
+}
+
+func ExampleWithSettleHook() {
+	f := futures.New(func(ctx context.Context) (int, error) {
+		return 0, errors.New("quota exceeded")
+	}, futures.WithName("upload"), futures.WithSettleHook(func(info futures.SettleInfo) {
+		fmt.Printf("%s settled: %v\n", info.Name, info.Err)
+	}))
+	f.Wait(context.Background())
+	// Output:
+	// upload settled: quota exceeded
+}
+
+func ExampleWithPanicHandler() {
+	handled := make(chan *futures.PanicError, 1)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		return 1, nil
+	}, futures.WithPanicHandler(func(pe *futures.PanicError) { handled <- pe }))
+	f.OnSuccess(func(int) { panic("callback bug") })
+	fmt.Println((<-handled).Value())
+	// Output:
+	// callback bug
+}
+
+func ExampleWithRepanic() {
+	f := futures.New(func(ctx context.Context) (int, error) {
+		panic("invariant violated")
+	}, futures.WithRepanic())
+
+	func() {
+		defer func() {
+			fmt.Println("recovered:", recover() != nil)
+		}()
+		f.Get()
+	}()
+	// Later reads return the *PanicError.
+	_, err := f.Get()
+	var pe *futures.PanicError
+	fmt.Println(errors.As(err, &pe), pe.Value())
+	// Output:
+	// recovered: true
+	// true invariant violated
+}
+
+func ExampleSetUnobservedErrorHandler() {
+	futures.SetUnobservedErrorHandler(func(err error) {
+		fmt.Println("unobserved:", err)
+	})
+	defer futures.SetUnobservedErrorHandler(nil)
+
+	futures.ReportUnobservedError(errors.New("cleanup failed"))
+	// Output:
+	// unobserved: cleanup failed
+}
+
+func ExampleSetShedding() {
+	futures.SetShedding("reports-*", true)
+	defer futures.SetShedding("reports-*", false)
+
+	report := futures.New(func(ctx context.Context) (string, error) {
+		return "monthly report", nil
+	}, futures.WithName("reports-monthly"))
+	checkout := futures.New(func(ctx context.Context) (string, error) {
+		return "order placed", nil
+	}, futures.WithName("checkout"))
+	_, err := report.Get()
+	fmt.Println(err)
+	fmt.Println(checkout.Get())
+	// Output:
+	// futures: shedding load
+	// order placed <nil>
+}
+
+func ExampleEnableHistory() {
+	futures.EnableHistory(100)
+	defer futures.EnableHistory(0)
+
+	for _, name := range []string{"fetch-profile", "fetch-orders"} {
+		futures.New(func(ctx context.Context) (int, error) {
+			if strings.HasSuffix(name, "orders") {
+				return 0, errors.New("connection reset")
+			}
+			return 1, nil
+		}, futures.WithName(name), futures.WithExecutor(futures.SyncExecutor))
+	}
+	for _, r := range futures.History() {
+		if strings.HasPrefix(r.Name, "fetch-") {
+			fmt.Printf("%s %q\n", r.Name, r.Err)
+		}
+	}
+	// Output:
+	// fetch-profile ""
+	// fetch-orders "connection reset"
+}
+
+func ExampleWriteHistory() {
+	futures.EnableHistory(100)
+	defer futures.EnableHistory(0)
+
+	futures.New(func(ctx context.Context) (int, error) {
+		return 1, nil
+	}, futures.WithName("fetch-profile")).Wait(context.Background())
+	// Prints a table with the columns SEQ, SETTLED, NAME, LABEL, DURATION,
+	// and ERROR.
+	futures.WriteHistory(os.Stdout)
+}
+
+func ExampleSetLatencyOverride() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	futures.SetLatencyOverride("payments", 200*time.Millisecond)
+	defer futures.SetLatencyOverride("payments", 0)
+	fmt.Println(futures.LatencyOverrides())
+
+	f := futures.New(func(ctx context.Context) (string, error) {
+		return "paid", nil
+	}, futures.WithName("payments"), futures.WithClock(clock))
+	// The result is held back until the fake clock has moved on.
+	clock.BlockUntil(1)
+	fmt.Println(f.State())
+	clock.Advance(200 * time.Millisecond)
+	fmt.Println(f.Get())
+	fmt.Println(f.Duration())
+	// Output:
+	// map[payments:200ms]
+	// unsettled
+	// paid <nil>
+	// 200ms true
+}
+
+// creations is a GlobalInterceptor that prints the life of the futures
+// with the given name.
+type creations struct {
+	name string
+	ids  map[string]bool
+}
+
+func (c *creations) OnCreate(id, name string) {
+	if name == c.name {
+		c.ids[id] = true
+		fmt.Println("created", name)
+	}
+}
+
+func (c *creations) OnResolve(id string, elapsed time.Duration) {
+	if c.ids[id] {
+		fmt.Println("resolved", c.name)
+	}
+}
+
+func (c *creations) OnError(id string, err error, elapsed time.Duration) {
+	if c.ids[id] {
+		fmt.Println("failed", c.name+":", err)
+	}
+}
+
+func ExampleAddInterceptor() {
+	remove := futures.AddInterceptor(&creations{name: "checkout", ids: map[string]bool{}})
+	defer remove()
+
+	futures.New(func(ctx context.Context) (int, error) {
+		return 0, errors.New("card declined")
+	}, futures.WithName("checkout"), futures.WithExecutor(futures.SyncExecutor))
+	// Output:
+	// created checkout
+	// failed checkout: card declined
+}
+
+func ExampleSetStrict() {
+	futures.SetStrict(futures.StrictCallbacks)
+	defer futures.SetStrict(0)
+
+	config := futures.NewPromise[string](futures.WithName("config"))
+	defer config.Resolve("")
+	// An inline continuation that blocks on a pending Future would stall
+	// the goroutine that settles its source. Strict mode makes it panic.
+	f := futures.ThenInline(futures.Completed(1), func(int) (string, error) {
+		return config.Future().Get()
+	})
+	_, err := f.Get()
+	var pe *futures.PanicError
+	if errors.As(err, &pe) {
+		fmt.Println(pe.Value())
+	}
+	// Output:
+	// futures: strict mode: blocking read of pending future "config" from an inline continuation of unnamed future
+}
+
+func ExampleSplitBudgetWithFloor() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// A 1% share would leave the quick lookup only 10ms; the floor grants
+	// it at least 100ms.
+	phases := futures.SplitBudgetWithFloor(ctx, 100*time.Millisecond, 0.01, 0.99)
+	lookup, _ := phases[0].Deadline()
+	fmt.Println(time.Until(lookup) > 50*time.Millisecond)
+	// Output:
+	// true
+}
+
+func ExampleInspect() {
+	// Built with -tags futures_debug, a timeout error or a drop report of f
+	// names this line. Without the tag, Inspect returns f unchanged.
+	f := futures.Inspect(futures.Completed("ok"))
+	fmt.Println(f.Get())
+	// Output:
+	// ok <nil>
+}
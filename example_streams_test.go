@@ -0,0 +1,146 @@
+package futures_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+// sliceStream returns a Stream of vs.
+func sliceStream[T any](vs ...T) *futures.Stream[T] {
+	ch := make(chan T, len(vs))
+	for _, v := range vs {
+		ch <- v
+	}
+	close(ch)
+	return futures.StreamOf(ch)
+}
+
+func ExampleCompact() {
+	s := futures.Compact(sliceStream("a", "", "b", "", "c"), func(s string) bool { return s == "" })
+	for v := range s.C() {
+		fmt.Println(v)
+	}
+	fmt.Println(s.Err())
+	// Output:
+	// a
+	// b
+	// c
+	// <nil>
+}
+
+func ExampleCompactNil() {
+	one, two := 1, 2
+	s := futures.CompactNil(sliceStream(&one, nil, &two, nil))
+	for p := range s.C() {
+		fmt.Println(*p)
+	}
+	// Output:
+	// 1
+	// 2
+}
+
+func ExampleConditionalStream() {
+	control := sliceStream(true, false, false, true)
+	s := futures.ConditionalStream(control,
+		sliceStream("primary 1", "primary 2"),
+		sliceStream("replica 1", "replica 2"))
+	for v := range s.C() {
+		fmt.Println(v)
+	}
+	// Output:
+	// primary 1
+	// replica 1
+	// replica 2
+	// primary 2
+}
+
+func ExampleGroupBy() {
+	type event struct {
+		level, msg string
+	}
+	s := sliceStream(
+		event{"info", "started"},
+		event{"error", "disk full"},
+		event{"info", "retrying"},
+	)
+	// Buffered sub-streams let them be drained one after the other.
+	groups := futures.GroupBy(s, func(e event) string { return e.level },
+		[]string{"info", "error"}, nil, futures.WithResultBuffer(3))
+	for _, level := range []string{"info", "error"} {
+		for e := range groups[level].C() {
+			fmt.Println(level, e.msg)
+		}
+	}
+	// Output:
+	// info started
+	// info retrying
+	// error disk full
+}
+
+func ExampleSortedStream() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := make(chan int)
+	s := futures.SortedStream(futures.StreamOf(ch), func(a, b int) bool { return a < b },
+		time.Second, futures.WithStreamClock(clock))
+
+	// Values that arrive within the window of the oldest one come out sorted.
+	ch <- 3
+	clock.BlockUntil(1)
+	ch <- 1
+	ch <- 2
+	ch <- 10 // makes sure that 2 has been taken in
+	clock.Advance(time.Second)
+	for i := 0; i < 3; i++ {
+		fmt.Println(<-s.C())
+	}
+	// When the source ends, any values still held are emitted.
+	close(ch)
+	for v := range s.C() {
+		fmt.Println(v)
+	}
+	// Output:
+	// 1
+	// 2
+	// 3
+	// 10
+}
+
+func ExampleRateWindow() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ch := make(chan string)
+	rates := futures.RateWindow(futures.StreamOf(ch), 2*time.Second, futures.WithStreamClock(clock))
+
+	for _, gap := range []time.Duration{0, 500 * time.Millisecond, 500 * time.Millisecond, 3 * time.Second} {
+		clock.Advance(gap)
+		ch <- "request"
+		fmt.Println(<-rates.C(), "per second")
+	}
+	close(ch)
+	// Output:
+	// 0.5 per second
+	// 1 per second
+	// 1.5 per second
+	// 0.5 per second
+}
+
+func ExampleWithOverflow() {
+	ch := make(chan int)
+	s := futures.StreamOf(ch, futures.WithResultBuffer(2), futures.WithOverflow(futures.OverflowFail))
+	go func() {
+		defer close(ch)
+		for i := 1; i <= 5; i++ {
+			ch <- i
+		}
+	}()
+	// Nobody reads, so the buffer fills up and the stream fails.
+	for s.Err() == nil {
+		time.Sleep(time.Millisecond)
+	}
+	fmt.Println(errors.Is(s.Err(), futures.ErrOverflow))
+	// Output:
+	// true
+}
@@ -0,0 +1,475 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func ExampleNewSemaphore() {
+	sem := futures.NewSemaphore(2)
+	var mu sync.Mutex
+	running, peak := 0, 0
+	var futs []*futures.Future[int]
+	for i := 0; i < 6; i++ {
+		i := i
+		futs = append(futs, futures.LimitedNew(sem, func(ctx context.Context) (int, error) {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return i, nil
+		}))
+	}
+	for _, f := range futs {
+		f.Wait(context.Background())
+	}
+	fmt.Println("at most", peak, "at a time")
+	// Output:
+	// at most 2 at a time
+}
+
+func ExampleSemaphore_Acquire() {
+	sem := futures.NewSemaphore(1)
+	if err := sem.Acquire(context.Background()); err != nil {
+		return
+	}
+	// The only permit is taken, so a second Acquire gives up at the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	fmt.Println(sem.Acquire(ctx))
+	sem.Release()
+	// Output:
+	// futures: timeout
+}
+
+func ExampleLimitedNew() {
+	// A Semaphore can be shared between futures of different types that
+	// talk to the same backend.
+	db := futures.NewSemaphore(4)
+	count := futures.LimitedNew(db, func(ctx context.Context) (int, error) {
+		return 12, nil
+	})
+	name := futures.LimitedNew(db, func(ctx context.Context) (string, error) {
+		return "orders", nil
+	})
+	fmt.Println(futures.Zip(name, count).Get())
+	// Output:
+	// {orders 12} <nil>
+}
+
+func ExampleNewPool() {
+	pool := futures.NewPool[int]()
+	defer pool.Close()
+	var futs []*futures.Future[int]
+	for i := 1; i <= 3; i++ {
+		i := i
+		futs = append(futs, pool.Submit(func(ctx context.Context) (int, error) {
+			return i * i, nil
+		}))
+	}
+	sum := futures.Reduce(futs, 0, func(acc, v int) int { return acc + v })
+	fmt.Println(sum.Get())
+	// Output:
+	// 14 <nil>
+}
+
+// countSink is a MetricsSink that keeps the counters only.
+type countSink struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (s *countSink) Count(name string, delta int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counts == nil {
+		s.counts = map[string]int64{}
+	}
+	s.counts[name] += delta
+}
+
+func (s *countSink) count(name string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counts[name]
+}
+
+func (s *countSink) Gauge(string, float64)   {}
+func (s *countSink) Observe(string, float64) {}
+
+func ExampleNewPoolWithMetrics() {
+	sink := &countSink{}
+	pool := futures.NewPoolWithMetrics[string](2, sink)
+	ok := pool.Submit(func(ctx context.Context) (string, error) { return "ok", nil })
+	bad := pool.Submit(func(ctx context.Context) (string, error) { return "", errors.New("bad") })
+	ok.Wait(context.Background())
+	bad.Wait(context.Background())
+	pool.Close()
+
+	for _, name := range []string{
+		futures.MetricPoolTasksSubmitted,
+		futures.MetricPoolTasksCompleted,
+		futures.MetricPoolTasksFailed,
+	} {
+		fmt.Println(name, sink.count(name))
+	}
+	// Output:
+	// pool_tasks_submitted 2
+	// pool_tasks_completed 1
+	// pool_tasks_failed 1
+}
+
+func ExampleNewArena() {
+	a := futures.NewArena()
+	var futs []*futures.Future[int]
+	for i := 0; i < 3; i++ {
+		i := i
+		futs = append(futs, futures.New(func(ctx context.Context) (int, error) {
+			return i, nil
+		}, futures.WithArena(a)))
+	}
+	total := 0
+	for _, f := range futs {
+		v, _ := f.Get()
+		total += v
+	}
+	fmt.Println(total)
+	// All futures have settled and nobody uses them anymore.
+	fmt.Println(a.Release())
+	// Output:
+	// 3
+	// <nil>
+}
+
+func ExampleNewContextKey() {
+	userKey := futures.NewContextKey[string]("user")
+	ctx := userKey.Set(context.Background(), futures.Completed("gopher"))
+
+	// Further down the middleware chain:
+	if f, ok := userKey.Get(ctx); ok {
+		v, err := f.Get()
+		fmt.Println(userKey, v, err)
+	}
+	_, ok := futures.NewContextKey[int]("user").Get(ctx)
+	fmt.Println(ok)
+	// Output:
+	// futures.ContextKey(user) gopher <nil>
+	// false
+}
+
+func ExampleAwaiterFromContext() {
+	f := futures.New(func(ctx context.Context) (string, error) {
+		a, _ := futures.AwaiterFromContext(ctx)
+		if !a.WasAwaited() {
+			// Nobody is reading the result yet, so skip the optional work.
+			return "summary", nil
+		}
+		return "summary with details", nil
+	}, futures.WithExecutor(futures.SyncExecutor))
+	fmt.Println(f.Get())
+	// Output:
+	// summary <nil>
+}
+
+// queryFuture is a future type of its own, built on Core.
+type queryFuture struct {
+	futures.Core[[]string]
+	rows int
+}
+
+func ExampleCore() {
+	q := &queryFuture{}
+	q.AddCallback(func(rows []string, err error) {
+		fmt.Println("callback:", len(rows), "rows")
+	})
+	go func() {
+		q.rows = 2
+		q.Settle([]string{"alice", "bob"}, nil)
+	}()
+	rows, err := q.Get()
+	fmt.Println(rows, err, q.rows)
+	fmt.Println(q.Settle(nil, errors.New("too late")))
+	// Output:
+	// callback: 2 rows
+	// [alice bob] <nil> 2
+	// false
+}
+
+func ExampleAggregateProgress() {
+	type copied struct{ done, total int64 }
+	fraction := func(c copied) float64 { return float64(c.done) / float64(c.total) }
+	a := make(chan copied, 1)
+	b := make(chan copied, 1)
+	overall := futures.AggregateProgress(fraction, a, b)
+
+	a <- copied{done: 50, total: 100}
+	b <- copied{done: 5, total: 10}
+	// Reports are coalesced, so wait for the one that includes both.
+	for f := range overall {
+		if f == 0.5 {
+			fmt.Println("half done")
+			break
+		}
+	}
+	close(a)
+	close(b)
+	for f := range overall {
+		if f == 1 {
+			fmt.Println("all done")
+		}
+	}
+	// Output:
+	// half done
+	// all done
+}
+
+func ExampleNewUpdatable() {
+	u := futures.NewUpdatable[string]()
+	u.Set("v1")
+
+	v, rev, notModified := u.TryGetIfNewer(0)
+	fmt.Println(v, rev, notModified)
+	_, _, notModified = u.TryGetIfNewer(rev)
+	fmt.Println(notModified)
+
+	go u.Set("v2")
+	v, rev, _, err := u.GetIfNewer(context.Background(), rev)
+	fmt.Println(v, rev, err)
+	fmt.Println(u.Current())
+	// Output:
+	// v1 1 false
+	// true
+	// v2 2 <nil>
+	// {v2 2}
+}
+
+func ExampleRefreshCache_GetFresh() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	loads := 0
+	c := futures.NewRefreshCache(func(ctx context.Context, k string) (string, error) {
+		loads++
+		return fmt.Sprintf("%s#%d", k, loads), nil
+	}, futures.WithClock(clock), futures.WithExecutor(futures.SyncExecutor))
+	ctx := context.Background()
+
+	r, _ := c.GetFresh(ctx, "rates", time.Minute)
+	fmt.Println(r.Value, r.Rev, r.Stale == nil)
+	clock.Advance(30 * time.Second)
+	r, _ = c.GetFresh(ctx, "rates", time.Minute)
+	fmt.Println(r.Value, r.Rev)
+	// Too old for this reader: GetFresh loads the entry again.
+	clock.Advance(time.Minute)
+	r, _ = c.GetFresh(ctx, "rates", time.Minute)
+	fmt.Println(r.Value, r.Rev)
+	// Output:
+	// rates#1 1 true
+	// rates#1 1
+	// rates#2 2
+}
+
+func ExampleRefreshCache_TryGetIfNewer() {
+	c := futures.NewRefreshCache(func(ctx context.Context, k string) (int, error) {
+		return len(k), nil
+	}, futures.WithExecutor(futures.SyncExecutor))
+
+	_, _, notModified := c.TryGetIfNewer("config", 0)
+	fmt.Println(notModified)
+	c.GetFresh(context.Background(), "config", time.Hour)
+	v, rev, notModified := c.TryGetIfNewer("config", 0)
+	fmt.Println(v, rev, notModified)
+	// Output:
+	// true
+	// 6 1 false
+}
+
+func ExampleNewReplayable() {
+	r := futures.NewReplayable(func() string {
+		fmt.Println("computing")
+		return "snapshot"
+	})
+	fmt.Println(r.Subscribe().Get())
+	// Late subscribers get the same value without a recomputation.
+	fmt.Println(r.Subscribe().Get())
+	// Output:
+	// computing
+	// snapshot <nil>
+	// snapshot <nil>
+}
+
+func ExampleNewShared() {
+	s := futures.NewShared(func() int {
+		fmt.Println("computing")
+		return 42
+	})
+	var wg sync.WaitGroup
+	results := make([]int, 3)
+	for i := range results {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.Get()
+		}()
+	}
+	wg.Wait()
+	fmt.Println(results)
+	// Output:
+	// computing
+	// [42 42 42]
+}
+
+func ExampleNewWave() {
+	ctx := context.Background()
+	for round := 1; round <= 2; round++ {
+		w := futures.NewWave[string](ctx, time.Now().Add(time.Second))
+		fast := w.Go(func(ctx context.Context) (string, error) {
+			return "reconciled", nil
+		})
+		w.Go(func(ctx context.Context) (string, error) {
+			// Stuck; the end of the round cancels it.
+			<-ctx.Done()
+			return "", ctx.Err()
+		})
+		fmt.Println(fast.Get())
+		fmt.Printf("round %d: %+v\n", round, w.Close())
+	}
+	// Output:
+	// reconciled <nil>
+	// round 1: {Finished:1 Canceled:1 Lingering:0}
+	// reconciled <nil>
+	// round 2: {Finished:1 Canceled:1 Lingering:0}
+}
+
+func ExampleNewWatcher() {
+	input := make(chan string)
+	w := futures.NewWatcher(input, func(query string) *futures.Future[int] {
+		return futures.Completed(len(query))
+	})
+	results := w.Results().C()
+	for _, q := range []string{"g", "go", "gopher"} {
+		input <- q
+		fmt.Println((<-results).Get())
+	}
+	close(input)
+	fmt.Println(w.Current().Get())
+	// Output:
+	// 1 <nil>
+	// 2 <nil>
+	// 6 <nil>
+	// 6 <nil>
+}
+
+func ExampleNewProgressPromise() {
+	p := futures.NewProgressPromise[string]()
+	go func() {
+		p.Notify(futures.Progress{Fraction: 0.5, Message: "halfway"})
+		time.Sleep(10 * time.Millisecond)
+		p.Resolve("uploaded")
+	}()
+	// Progress is coalesced, so a reader may skip reports, but it always
+	// sees the channel close once the Promise has settled.
+	for range p.Progress() {
+	}
+	fmt.Println(p.Future().Get())
+	// Output:
+	// uploaded <nil>
+}
+
+func ExampleNewPromiseWithProgress() {
+	type transfer struct{ done, total int64 }
+	p := futures.NewPromiseWithProgress[int64, transfer]()
+	p.Notify(transfer{done: 512, total: 1024})
+	fmt.Println(<-p.Progress())
+	p.Resolve(1024)
+	_, open := <-p.Progress()
+	fmt.Println(open)
+	fmt.Println(p.Future().Get())
+	// Output:
+	// {512 1024}
+	// false
+	// 1024 <nil>
+}
+
+func ExampleAggregateFractions() {
+	download := make(chan futures.Progress, 1)
+	unpack := make(chan futures.Progress, 1)
+	overall := futures.AggregateFractions(download, unpack)
+
+	download <- futures.Progress{Fraction: 1}
+	close(download)
+	unpack <- futures.Progress{Fraction: 0.5}
+	close(unpack)
+	var last float64
+	for f := range overall {
+		last = f
+	}
+	fmt.Println(last)
+	// Output:
+	// 1
+}
+
+func ExamplePromise_Producer() {
+	p := futures.NewPromise[string]()
+	go func() {
+		prod := p.Producer()
+		defer prod.Close()
+		// Returns early without a result, by mistake.
+	}()
+	_, err := p.Future().Get()
+	fmt.Println(errors.Is(err, futures.ErrBrokenPromise), p.Settlement())
+	// Output:
+	// true rejected
+}
+
+func ExamplePromise_RejectAfter() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	errNoWebhook := errors.New("webhook did not arrive")
+	p := futures.NewPromise[string](futures.WithClock(clock))
+	p.RejectAfter(10*time.Minute, errNoWebhook)
+
+	clock.Advance(10 * time.Minute)
+	_, err := p.Future().Get()
+	fmt.Println(errors.Is(err, futures.ErrProducerTimeout), errors.Is(err, errNoWebhook))
+	fmt.Println(p.Resolve("late") == futures.ErrAlreadySettled)
+	// Output:
+	// true true
+	// true
+}
+
+func ExamplePromise_Cancel() {
+	p := futures.NewPromise[int]()
+	p.Cancel("user aborted the upload")
+	_, err := p.Future().Get()
+	var cerr *futures.CancellationError
+	fmt.Println(errors.Is(err, futures.ErrCanceled), errors.Is(err, context.Canceled))
+	if errors.As(err, &cerr) {
+		fmt.Println(cerr.Reason)
+	}
+	// Output:
+	// true false
+	// user aborted the upload
+}
+
+func ExampleExecutorFunc() {
+	logging := futures.ExecutorFunc(func(fn func()) {
+		fmt.Println("starting computation")
+		fn()
+	})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		return 1, nil
+	}, futures.WithExecutor(logging))
+	fmt.Println(f.Get())
+	// Output:
+	// starting computation
+	// 1 <nil>
+}
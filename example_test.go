@@ -0,0 +1,400 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func ExampleNew() {
+	f := futures.New(func(ctx context.Context) (int, error) {
+		return 6 * 7, nil
+	})
+	// Get blocks until the computation has finished. The result is stored,
+	// so it can be read any number of times.
+	v, err := f.Get()
+	fmt.Println(v, err)
+	v, err = f.Get()
+	fmt.Println(v, err)
+	// Output:
+	// 42 <nil>
+	// 42 <nil>
+}
+
+func ExampleNew_syncExecutor() {
+	f := futures.New(func(ctx context.Context) (string, error) {
+		return "done", nil
+	}, futures.WithExecutor(futures.SyncExecutor))
+	// With SyncExecutor, New returns only after the Future has settled.
+	fmt.Println(f.Err() == nil)
+	fmt.Println(f.Get())
+	// Output:
+	// true
+	// done <nil>
+}
+
+func ExampleNewWithContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := futures.NewWithContext(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	cancel()
+	_, err := f.Get()
+	fmt.Println(err)
+	// Output:
+	// futures: canceled
+}
+
+func ExampleCompleted() {
+	cached := futures.Completed("from cache")
+	failed := futures.Failed[string](errors.New("not found"))
+	fmt.Println(cached.Get())
+	fmt.Println(failed.Err())
+	// Output:
+	// from cache <nil>
+	// not found
+}
+
+func ExampleFuture_Wait() {
+	f := futures.New(func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, errors.New("disk full")
+	})
+	fmt.Println(f.Wait(context.Background()))
+	// Output:
+	// disk full
+}
+
+func ExampleFuture_GetWithContext() {
+	release := make(chan struct{})
+	defer close(release)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// Giving up waiting does not affect the Future.
+	_, err := f.GetWithContext(ctx)
+	fmt.Println(err)
+	// Output:
+	// futures: canceled
+}
+
+func ExampleFuture_Cancel() {
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	f.Cancel()
+	fmt.Println(f.Get())
+	// Output:
+	// 0 futures: canceled
+}
+
+func ExampleFuture_Fallback() {
+	primary := futures.Failed[string](errors.New("service unavailable"))
+	cached := futures.Lazy(func(ctx context.Context) (string, error) {
+		return "cached value", nil
+	})
+	fmt.Println(primary.Fallback(cached).Get())
+	// Output:
+	// cached value <nil>
+}
+
+func ExampleLazy() {
+	f := futures.Lazy(func(ctx context.Context) (string, error) {
+		fmt.Println("computing")
+		return "result", nil
+	}, futures.WithExecutor(futures.SyncExecutor))
+	fmt.Println("created")
+	fmt.Println(f.Get())
+	fmt.Println(f.Get())
+	// Output:
+	// created
+	// computing
+	// result <nil>
+	// result <nil>
+}
+
+func ExampleRace() {
+	fast := futures.Completed("fast")
+	slow := futures.New(func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	fmt.Println(futures.Race(fast, slow).Get())
+	// The loser is canceled.
+	fmt.Println(slow.Wait(context.Background()))
+	// Output:
+	// fast <nil>
+	// futures: canceled
+}
+
+func ExampleZip() {
+	name := futures.Completed("gopher")
+	age := futures.Completed(13)
+	p, err := futures.Zip(name, age).Get()
+	fmt.Println(p.First, p.Second, err)
+	// Output:
+	// gopher 13 <nil>
+}
+
+func ExampleZip3() {
+	t, _ := futures.Zip3(futures.Completed(1), futures.Completed("two"), futures.Completed(3.0)).Get()
+	fmt.Println(t.First, t.Second, t.Third)
+	// Output:
+	// 1 two 3
+}
+
+func ExampleReduce() {
+	var futs []*futures.Future[int]
+	for i := 1; i <= 4; i++ {
+		futs = append(futs, futures.Completed(i))
+	}
+	// Addition is commutative, so the completion order does not matter.
+	sum := futures.Reduce(futs, 0, func(acc, v int) int { return acc + v })
+	fmt.Println(sum.Get())
+	// Output:
+	// 10 <nil>
+}
+
+func ExampleRetry() {
+	attempt := 0
+	f := futures.Retry(func() *futures.Future[string] {
+		attempt++
+		if attempt < 3 {
+			return futures.Failed[string](fmt.Errorf("attempt %d failed", attempt))
+		}
+		return futures.Completed(fmt.Sprintf("attempt %d succeeded", attempt))
+	}, 5)
+	fmt.Println(f.Get())
+	// Output:
+	// attempt 3 succeeded <nil>
+}
+
+func ExampleRetryWithBackoff() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	start := clock.Now()
+	policy := futures.ExponentialBackoff(time.Second, 2, time.Minute).WithMaxAttempts(4)
+	f := futures.RetryWithBackoff(func() *futures.Future[int] {
+		fmt.Println("attempt after", clock.Now().Sub(start))
+		return futures.Failed[int](errors.New("unavailable"))
+	}, policy, futures.WithClock(clock))
+
+	// Move the fake clock forward whenever the retry loop waits.
+	for attempt := 1; attempt < 4; attempt++ {
+		clock.BlockUntil(1)
+		clock.Advance(policy.Delay(attempt))
+	}
+	fmt.Println(f.Get())
+	// Output:
+	// attempt after 0s
+	// attempt after 1s
+	// attempt after 3s
+	// attempt after 7s
+	// 0 unavailable
+}
+
+func ExampleExponentialBackoff() {
+	b := futures.ExponentialBackoff(100*time.Millisecond, 2, time.Second)
+	for attempt := 1; attempt <= 5; attempt++ {
+		fmt.Println(b.Delay(attempt))
+	}
+	// Output:
+	// 100ms
+	// 200ms
+	// 400ms
+	// 800ms
+	// 1s
+}
+
+func ExampleAsCompleted() {
+	// Each computation waits for its turn, so the completion order is
+	// known: second, third, first.
+	turn := map[string]chan struct{}{}
+	var futs []*futures.Future[string]
+	for _, name := range []string{"first", "second", "third"} {
+		name := name
+		ch := make(chan struct{})
+		turn[name] = ch
+		futs = append(futs, futures.New(func(ctx context.Context) (string, error) {
+			<-ch
+			return name, nil
+		}))
+	}
+
+	s := futures.AsCompleted(futs)
+	for _, name := range []string{"second", "third", "first"} {
+		close(turn[name])
+		f := <-s.C()
+		fmt.Println(f.Get())
+	}
+	// Output:
+	// second <nil>
+	// third <nil>
+	// first <nil>
+}
+
+func ExampleStreamOf() {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= 3; i++ {
+			ch <- i
+		}
+	}()
+	s := futures.StreamOf(ch)
+	for v := range s.C() {
+		fmt.Println(v)
+	}
+	fmt.Println(s.Err())
+	// Output:
+	// 1
+	// 2
+	// 3
+	// <nil>
+}
+
+func ExampleNewPromise() {
+	p := futures.NewPromise[string]()
+	go func() {
+		prod := p.Producer()
+		defer prod.Close() // rejects p with ErrBrokenPromise if we forget to settle it
+		prod.Resolve("message received")
+	}()
+	fmt.Println(p.Future().Get())
+	// Output:
+	// message received <nil>
+}
+
+func ExampleNewDetached() {
+	cleanedUp := make(chan struct{})
+	f := futures.NewDetached(func(ctx context.Context, resolve func(string, error)) {
+		resolve("result", nil)
+		// The reader does not have to wait for this.
+		close(cleanedUp)
+	})
+	fmt.Println(f.Get())
+	<-cleanedUp
+	// Output:
+	// result <nil>
+}
+
+func ExampleAwaitStruct() {
+	var page struct {
+		UserF  *futures.Future[string]
+		User   string
+		CountF *futures.Future[int]
+		Count  int
+	}
+	page.UserF = futures.Completed("gopher")
+	page.CountF = futures.Completed(3)
+	if err := futures.AwaitStruct(context.Background(), &page); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(page.User, page.Count)
+	// Output:
+	// gopher 3
+}
+
+func ExampleVersionedCache() {
+	c := futures.NewVersionedCache[string, int]()
+	entry, _ := c.Get("counter").Get()
+
+	// Two writers read version 0; only the first update succeeds.
+	fmt.Println(c.Set("counter", entry.Value+1, entry.Version).Wait(context.Background()))
+	err := c.Set("counter", entry.Value+1, entry.Version).Wait(context.Background())
+	fmt.Println(errors.Is(err, futures.ErrVersionConflict))
+
+	// The second writer re-reads and retries.
+	entry, _ = c.Get("counter").Get()
+	fmt.Println(c.Set("counter", entry.Value+1, entry.Version).Wait(context.Background()))
+	entry, _ = c.Get("counter").Get()
+	fmt.Println(entry.Value, entry.Version)
+	// Output:
+	// <nil>
+	// true
+	// <nil>
+	// 2 2
+}
+
+func ExampleBroadcast() {
+	config := futures.New(func(ctx context.Context) (string, error) {
+		return "config loaded", nil
+	})
+	consumers, err := futures.Broadcast(config, 2)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, c := range consumers {
+		fmt.Println(c.Get())
+	}
+	// Output:
+	// config loaded <nil>
+	// config loaded <nil>
+}
+
+func ExampleSplitBudget() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	phases := futures.SplitBudget(ctx, 0.6, 0.4)
+
+	// Phase A may take up to 60% of the remaining time.
+	a, err := futures.NewWithContext(phases[0], func(ctx context.Context) (int, error) {
+		return 20, nil
+	}).Get()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// Phase B gets the rest, including whatever phase A left unused.
+	b, err := futures.NewWithContext(phases[1], func(ctx context.Context) (int, error) {
+		return a + 22, nil
+	}).Get()
+	fmt.Println(b, err)
+	// Output: 42 <nil>
+}
+
+func ExampleThen() {
+	user := futures.Completed("gopher")
+	greeting := futures.Then(user, func(name string) (string, error) {
+		return "hello, " + name, nil
+	})
+	length := futures.Then(greeting, func(s string) (int, error) {
+		return len(s), nil
+	})
+	fmt.Println(length.Get())
+	// Output: 13 <nil>
+}
+
+func ExampleCatch() {
+	quota := futures.Failed[int](errors.New("quota service unavailable"))
+	withDefault := futures.Catch(quota, func(err error) (int, error) {
+		if errors.Is(err, futures.ErrCanceled) {
+			return 0, err
+		}
+		return 100, nil
+	})
+	fmt.Println(withDefault.Get())
+	// Output: 100 <nil>
+}
+
+func ExampleMemoize() {
+	loadConfig := futures.Memoize(func() *futures.Future[string] {
+		fmt.Println("loading config")
+		return futures.New(func(context.Context) (string, error) { return "debug=true", nil })
+	})
+	fmt.Println(loadConfig().Get())
+	fmt.Println(loadConfig().Get())
+	// Output:
+	// loading config
+	// debug=true <nil>
+	// debug=true <nil>
+}
@@ -0,0 +1,26 @@
+package futures
+
+// Executor decides where the computation of a Future runs.
+type Executor interface {
+	// Go runs fn. It may run fn in a new goroutine or before returning.
+	Go(fn func())
+}
+
+// ExecutorFunc adapts an ordinary function to the Executor interface.
+type ExecutorFunc func(fn func())
+
+// Go calls e(fn).
+func (e ExecutorFunc) Go(fn func()) {
+	e(fn)
+}
+
+var (
+	// GoroutineExecutor runs each computation in a new goroutine.
+	// This is the default.
+	GoroutineExecutor Executor = ExecutorFunc(func(fn func()) { go fn() })
+
+	// SyncExecutor runs each computation on the calling goroutine, so that
+	// New returns only after the Future has settled. This is mainly useful
+	// for tests and examples that need deterministic behavior.
+	SyncExecutor Executor = ExecutorFunc(func(fn func()) { fn() })
+)
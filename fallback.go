@@ -0,0 +1,37 @@
+package futures
+
+// Fallback returns a Future that resolves to the value of f if f resolves.
+// If f fails, it awaits alternative instead and settles with its outcome,
+// so if both fail, the returned Future fails with the error of
+// alternative.
+//
+// alternative is awaited only once f has failed. Pass a Future created
+// by Lazy to keep it from computing anything before then:
+//
+//	user := fetchUser(id).Fallback(futures.Lazy(loadCachedUser))
+//
+// Canceling the returned Future cancels both f and alternative. No
+// goroutine waits for either of them.
+func (f *Future[T]) Fallback(alternative *Future[T]) *Future[T] {
+	out := newFuture[T]()
+	out.cancel = func(cause error) {
+		f.CancelWithCause(cause)
+		alternative.CancelWithCause(cause)
+	}
+	ChainOf(f).add(out)
+	f.markAwaited()
+	f.ensureStarted()
+	f.core.AddCallback(func(v T, err error) {
+		if isSettled(out) {
+			return
+		}
+		if err == nil {
+			out.settle(v, nil)
+			return
+		}
+		alternative.markAwaited()
+		alternative.ensureStarted()
+		alternative.core.AddCallback(func(v T, err error) { out.settle(v, err) })
+	})
+	return out
+}
@@ -0,0 +1,88 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestFallback(t *testing.T) {
+	var altRuns atomic.Int32
+	alternative := func(v int, err error) *futures.Future[int] {
+		return futures.Lazy(func(ctx context.Context) (int, error) {
+			altRuns.Add(1)
+			return v, err
+		})
+	}
+	errPrimary, errAlt := errors.New("primary"), errors.New("alternative")
+
+	tests := []struct {
+		name     string
+		primary  *futures.Future[int]
+		alt      *futures.Future[int]
+		want     int
+		wantErr  error
+		wantRuns int32
+	}{
+		{"primary resolves", futures.Completed(1), alternative(2, nil), 1, nil, 0},
+		{"primary fails", futures.Failed[int](errPrimary), alternative(2, nil), 2, nil, 1},
+		{"both fail", futures.Failed[int](errPrimary), alternative(0, errAlt), 0, errAlt, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			altRuns.Store(0)
+			v, err := tt.primary.Fallback(tt.alt).Get()
+			if v != tt.want || err != tt.wantErr {
+				t.Errorf("Get = %d, %v; want %d, %v", v, err, tt.want, tt.wantErr)
+			}
+			if n := altRuns.Load(); n != tt.wantRuns {
+				t.Errorf("alternative ran %d times, want %d", n, tt.wantRuns)
+			}
+		})
+	}
+}
+
+func TestFallbackWaitsForPrimary(t *testing.T) {
+	release := make(chan struct{})
+	var altStarted atomic.Bool
+	primary := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 0, errors.New("failed")
+	})
+	f := primary.Fallback(futures.Lazy(func(ctx context.Context) (int, error) {
+		altStarted.Store(true)
+		return 2, nil
+	}))
+	if altStarted.Load() {
+		t.Fatal("alternative started before the primary failed")
+	}
+	close(release)
+	if v, err := f.Get(); v != 2 || err != nil {
+		t.Errorf("Get = %d, %v; want 2, nil", v, err)
+	}
+}
+
+func TestFallbackCancel(t *testing.T) {
+	altStarted := make(chan struct{})
+	altStopped := make(chan struct{})
+	f := futures.Failed[int](errors.New("failed")).Fallback(futures.New(func(ctx context.Context) (int, error) {
+		close(altStarted)
+		<-ctx.Done()
+		close(altStopped)
+		return 0, ctx.Err()
+	}))
+	<-altStarted
+	f.Cancel()
+	select {
+	case <-altStopped:
+	case <-time.After(time.Second):
+		t.Fatal("canceling the Fallback Future did not cancel the alternative")
+	}
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want ErrCanceled", err)
+	}
+}
@@ -0,0 +1,95 @@
+package futures
+
+import (
+	"context"
+	"sync"
+)
+
+// FanOut calls fn for each of inputs and returns a Future for the values
+// of the futures fn returns, in the order of inputs. All calls happen
+// right away, so the futures run concurrently; use FanOutWithLimit to
+// bound how many run at the same time.
+//
+// Like errgroup.Group, FanOut fails fast: once one of the futures fails,
+// the returned Future fails with its error, and the other futures are
+// canceled. A panic in fn, or fn returning a nil Future, fails the
+// element; a panic fails it with a *PanicError. Canceling the returned
+// Future cancels all futures started by FanOut. Without inputs, the
+// returned Future resolves to an empty slice.
+func FanOut[T, U any](inputs []T, fn func(T) *Future[U], opts ...Option) *Future[[]U] {
+	return FanOutWithLimit(inputs, 0, fn, opts...)
+}
+
+// FanOutWithLimit is like FanOut but calls fn only while fewer than
+// maxConcurrency of the futures it returned are pending. The remaining
+// inputs wait, in order, until a future settles. A maxConcurrency below
+// 1 means no limit.
+func FanOutWithLimit[T, U any](inputs []T, maxConcurrency int, fn func(T) *Future[U], opts ...Option) *Future[[]U] {
+	if maxConcurrency < 1 {
+		maxConcurrency = max(len(inputs), 1)
+	}
+	return New(func(ctx context.Context) ([]U, error) {
+		return fanOut(ctx, inputs, maxConcurrency, fn)
+	}, opts...)
+}
+
+func fanOut[T, U any](ctx context.Context, inputs []T, limit int, fn func(T) *Future[U]) ([]U, error) {
+	vs := make([]U, len(inputs))
+	sem := make(chan struct{}, limit)
+	failed := make(chan error, 1)
+	var pending sync.WaitGroup
+	started := make([]*Future[U], 0, len(inputs))
+	defer func() {
+		for _, f := range started {
+			if !isSettled(f) {
+				f.Cancel()
+			}
+		}
+	}()
+
+	for i, in := range inputs {
+		i := i
+		select {
+		case sem <- struct{}{}:
+		case err := <-failed:
+			return nil, err
+		case <-ctx.Done():
+			return nil, contextError(ctx)
+		}
+		f := attemptFuture(func() *Future[U] { return fn(in) })
+		started = append(started, f)
+		f.markAwaited()
+		f.ensureStarted()
+		pending.Add(1)
+		f.core.AddCallback(func(v U, err error) {
+			vs[i] = v
+			if err != nil {
+				select {
+				case failed <- err:
+				default:
+				}
+			}
+			<-sem
+			pending.Done()
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		pending.Wait()
+		close(done)
+	}()
+	select {
+	case err := <-failed:
+		return nil, err
+	case <-done:
+	case <-ctx.Done():
+		return nil, contextError(ctx)
+	}
+	select {
+	case err := <-failed:
+		return nil, err
+	default:
+		return vs, nil
+	}
+}
@@ -0,0 +1,140 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestFanOutKeepsInputOrder(t *testing.T) {
+	inputs := []int{30, 10, 20}
+	v, err := futures.FanOut(inputs, func(ms int) *futures.Future[string] {
+		return futures.New(func(ctx context.Context) (string, error) {
+			time.Sleep(time.Duration(ms) * time.Millisecond)
+			return strconv.Itoa(ms), nil
+		})
+	}).Get()
+	if err != nil || !reflect.DeepEqual(v, []string{"30", "10", "20"}) {
+		t.Errorf("Get = %v, %v; want [30 10 20], nil", v, err)
+	}
+}
+
+func TestFanOutRunsConcurrently(t *testing.T) {
+	const n = 5
+	var running atomic.Int32
+	all := make(chan struct{})
+	f := futures.FanOut(make([]int, n), func(int) *futures.Future[int] {
+		return futures.New(func(ctx context.Context) (int, error) {
+			if running.Add(1) == n {
+				close(all)
+			}
+			<-all
+			return 1, nil
+		})
+	})
+	select {
+	case <-f.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the futures did not all run at the same time")
+	}
+}
+
+func TestFanOutFailsFast(t *testing.T) {
+	errBoom := errors.New("boom")
+	slowStarted := make(chan struct{})
+	slowCanceled := make(chan struct{})
+	_, err := futures.FanOut([]int{0, 1}, func(i int) *futures.Future[int] {
+		if i == 1 {
+			return futures.New(func(ctx context.Context) (int, error) {
+				<-slowStarted
+				return 0, errBoom
+			})
+		}
+		return futures.New(func(ctx context.Context) (int, error) {
+			close(slowStarted)
+			<-ctx.Done()
+			close(slowCanceled)
+			return 0, ctx.Err()
+		})
+	}).Get()
+	if err != errBoom {
+		t.Errorf("err = %v, want %v", err, errBoom)
+	}
+	select {
+	case <-slowCanceled:
+	case <-time.After(time.Second):
+		t.Error("the pending future was not canceled")
+	}
+}
+
+func TestFanOutEmpty(t *testing.T) {
+	v, err := futures.FanOut[int, int](nil, func(int) *futures.Future[int] { return nil }).Get()
+	if err != nil || v == nil || len(v) != 0 {
+		t.Errorf("Get = %#v, %v; want an empty slice, nil", v, err)
+	}
+}
+
+func TestFanOutWithLimit(t *testing.T) {
+	const limit = 3
+	var running, peak atomic.Int32
+	inputs := make([]int, 20)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	v, err := futures.FanOutWithLimit(inputs, limit, func(i int) *futures.Future[int] {
+		return futures.New(func(ctx context.Context) (int, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				m := peak.Load()
+				if n <= m || peak.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return i * i, nil
+		})
+	}).Get()
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	for i, got := range v {
+		if got != i*i {
+			t.Fatalf("v[%d] = %d, want %d", i, got, i*i)
+		}
+	}
+	if n := peak.Load(); n > limit {
+		t.Errorf("%d futures ran at once, limit is %d", n, limit)
+	}
+}
+
+func TestFanOutCancel(t *testing.T) {
+	started := make(chan struct{}, 2)
+	var canceled atomic.Int32
+	f := futures.FanOutWithLimit([]int{1, 2, 3}, 2, func(int) *futures.Future[int] {
+		return futures.New(func(ctx context.Context) (int, error) {
+			started <- struct{}{}
+			<-ctx.Done()
+			canceled.Add(1)
+			return 0, ctx.Err()
+		})
+	})
+	<-started
+	<-started
+	f.Cancel()
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want ErrCanceled", err)
+	}
+	eventually(t, func() bool { return canceled.Load() == 2 }, "the running futures were not canceled")
+	select {
+	case <-started:
+		t.Error("fn was called for an input after cancellation")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
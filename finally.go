@@ -0,0 +1,37 @@
+package futures
+
+// Finally returns a Future that settles like f, but only after fn has
+// returned. fn is called exactly once when f settles, whether f resolves,
+// fails, or is canceled, so it is the place to release resources that
+// were acquired before starting f, such as a connection from a pool:
+//
+//	conn := pool.Acquire()
+//	rows := futures.Finally(query(conn), func() { pool.Release(conn) })
+//
+// Unlike the Finally method, which returns f itself, readers of the
+// returned Future know that the cleanup is done. If fn panics, the
+// returned Future fails with a *PanicError instead of the outcome of f.
+//
+// fn runs on a goroutine of its own. Canceling the returned Future
+// cancels f, and fn still runs once f has settled.
+func Finally[T any](f *Future[T], fn func()) *Future[T] {
+	out := newFuture[T]()
+	out.cancel = f.CancelWithCause
+	ChainOf(f).add(out)
+	f.markAwaited()
+	f.ensureStarted()
+	f.core.AddCallback(func(v T, err error) {
+		go func() {
+			_, perr := catchPanic(func() (struct{}, error) {
+				runStrict(StrictCallbacks, "a callback of "+describeFuture(f.name), fn)
+				return struct{}{}, nil
+			})
+			if perr != nil {
+				var zero T
+				v, err = zero, perr
+			}
+			out.settle(v, err)
+		}()
+	})
+	return out
+}
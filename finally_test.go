@@ -0,0 +1,94 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestFinallyFuncMirrorsOutcome(t *testing.T) {
+	errBoom := errors.New("boom")
+	var calls atomic.Int32
+	cleanup := func() { calls.Add(1) }
+
+	if v, err := futures.Finally(futures.Completed(1), cleanup).Get(); v != 1 || err != nil {
+		t.Errorf("resolved: Get = %d, %v; want 1, nil", v, err)
+	}
+	if _, err := futures.Finally(futures.Failed[int](errBoom), cleanup).Get(); err != errBoom {
+		t.Errorf("failed: err = %v, want %v", err, errBoom)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("cleanup ran %d times, want 2", n)
+	}
+}
+
+func TestFinallyFuncRunsBeforeDownstreamSettles(t *testing.T) {
+	var cleaned atomic.Bool
+	f := futures.Finally(futures.Completed(1), func() { cleaned.Store(true) })
+	f.Get()
+	if !cleaned.Load() {
+		t.Error("the returned Future settled before cleanup ran")
+	}
+}
+
+func TestFinallyFuncPanic(t *testing.T) {
+	_, err := futures.Finally(futures.Completed(1), func() { panic("cleanup failed") }).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) || pe.Value() != "cleanup failed" {
+		t.Errorf("err = %v, want a PanicError", err)
+	}
+}
+
+func TestFinallyFuncOnCancel(t *testing.T) {
+	started := make(chan struct{})
+	cleaned := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	out := futures.Finally(f, func() { close(cleaned) })
+	<-started
+	out.Cancel()
+	if _, err := out.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want ErrCanceled", err)
+	}
+	if !errors.Is(f.Err(), futures.ErrCanceled) {
+		t.Errorf("upstream err = %v, want ErrCanceled", f.Err())
+	}
+	<-cleaned
+}
+
+// TestFinallyFuncExactlyOnce races settlement, attaching Finally, and readers.
+func TestFinallyFuncExactlyOnce(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		var calls atomic.Int32
+		p := futures.NewPromise[int]()
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			p.Resolve(1)
+		}()
+		go func() {
+			defer wg.Done()
+			p.Reject(errors.New("late"))
+		}()
+		out := futures.Finally(p.Future(), func() { calls.Add(1) })
+		for r := 0; r < 3; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				out.Get()
+			}()
+		}
+		wg.Wait()
+		if n := calls.Load(); n != 1 {
+			t.Fatalf("iteration %d: cleanup ran %d times, want 1", i, n)
+		}
+	}
+}
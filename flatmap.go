@@ -0,0 +1,62 @@
+package futures
+
+import "sync"
+
+// FlatMap is like Then for continuations that are asynchronous
+// themselves: fn returns a Future, and the returned Future settles with
+// its result instead of holding a Future of a Future. fn is called on a
+// goroutine of its own once f has resolved; if f fails, fn is not called,
+// and the returned Future fails with the error of f. A panic in fn, or fn
+// returning a nil Future, fails the returned Future; a panic fails it
+// with a *PanicError.
+//
+// Canceling the returned Future cancels f if it is still pending, and the
+// Future returned by fn if fn has been called. fn is not called after the
+// returned Future was canceled, so canceling a stage in the middle of a
+// FlatMap chain keeps all later stages from starting.
+func FlatMap[A, B any](f *Future[A], fn func(A) *Future[B]) *Future[B] {
+	out := newFuture[B]()
+	// mu guards inner, the Future returned by fn, and canceled, which
+	// records that out was canceled.
+	var mu sync.Mutex
+	var inner *Future[B]
+	var canceled bool
+	out.cancel = func(cause error) {
+		f.CancelWithCause(cause)
+		mu.Lock()
+		canceled = true
+		g := inner
+		mu.Unlock()
+		if g != nil {
+			g.CancelWithCause(cause)
+		}
+	}
+	ChainOf(f).add(out)
+	f.markAwaited()
+	f.ensureStarted()
+	f.core.AddCallback(func(v A, err error) {
+		if isSettled(out) {
+			return
+		}
+		if err != nil {
+			var zero B
+			out.settle(zero, err)
+			return
+		}
+		go func() {
+			g := attemptFuture(func() *Future[B] { return fn(v) })
+			mu.Lock()
+			inner = g
+			c := canceled
+			mu.Unlock()
+			if c {
+				g.Cancel()
+				return
+			}
+			g.markAwaited()
+			g.ensureStarted()
+			g.core.AddCallback(func(w B, err error) { out.settle(w, err) })
+		}()
+	})
+	return out
+}
@@ -0,0 +1,110 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+// asyncStage returns a FlatMap continuation that runs fn as a Future of
+// its own and counts how often it was started.
+func asyncStage[A, B any](started *atomic.Int32, fn func(ctx context.Context, v A) (B, error)) func(A) *futures.Future[B] {
+	return func(v A) *futures.Future[B] {
+		started.Add(1)
+		return futures.New(func(ctx context.Context) (B, error) { return fn(ctx, v) })
+	}
+}
+
+func TestFlatMapChain(t *testing.T) {
+	var started atomic.Int32
+	user := futures.New(func(context.Context) (int, error) { return 7, nil })
+	profile := futures.FlatMap(user, asyncStage(&started, func(_ context.Context, id int) (string, error) {
+		return "user-" + strconv.Itoa(id), nil
+	}))
+	greeting := futures.FlatMap(profile, asyncStage(&started, func(_ context.Context, name string) (string, error) {
+		return "hello, " + name, nil
+	}))
+	length := futures.FlatMap(greeting, asyncStage(&started, func(_ context.Context, s string) (int, error) {
+		return len(s), nil
+	}))
+	if v, err := length.Get(); v != 13 || err != nil {
+		t.Errorf("Get = %d, %v; want 13, nil", v, err)
+	}
+	if n := started.Load(); n != 3 {
+		t.Errorf("%d stages started, want 3", n)
+	}
+}
+
+func TestFlatMapPropagatesErrors(t *testing.T) {
+	errOuter := errors.New("outer")
+	var started atomic.Int32
+	next := asyncStage(&started, func(_ context.Context, v int) (int, error) { return v, nil })
+	if _, err := futures.FlatMap(futures.Failed[int](errOuter), next).Get(); err != errOuter {
+		t.Errorf("outer failure: err = %v, want %v", err, errOuter)
+	}
+	if started.Load() != 0 {
+		t.Error("fn was called although f failed")
+	}
+
+	errInner := errors.New("inner")
+	failing := asyncStage(&started, func(context.Context, int) (int, error) { return 0, errInner })
+	if _, err := futures.FlatMap(futures.Completed(1), failing).Get(); err != errInner {
+		t.Errorf("inner failure: err = %v, want %v", err, errInner)
+	}
+
+	_, err := futures.FlatMap(futures.Completed(1), func(int) *futures.Future[int] { panic("bad") }).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) {
+		t.Errorf("panic: err = %v, want a PanicError", err)
+	}
+}
+
+func TestFlatMapCancelMidChain(t *testing.T) {
+	var started atomic.Int32
+	firstStarted := make(chan struct{})
+	first := futures.New(func(ctx context.Context) (int, error) {
+		close(firstStarted)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	second := futures.FlatMap(first, asyncStage(&started, func(_ context.Context, v int) (int, error) { return v, nil }))
+	third := futures.FlatMap(second, asyncStage(&started, func(_ context.Context, v int) (int, error) { return v, nil }))
+
+	<-firstStarted
+	second.Cancel()
+	if _, err := third.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("third stage: err = %v, want ErrCanceled", err)
+	}
+	if _, err := first.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("first stage: err = %v, want ErrCanceled", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if n := started.Load(); n != 0 {
+		t.Errorf("%d later stages started after cancellation", n)
+	}
+}
+
+func TestFlatMapCancelInner(t *testing.T) {
+	innerStarted := make(chan struct{})
+	innerCanceled := make(chan struct{})
+	out := futures.FlatMap(futures.Completed(1), func(int) *futures.Future[int] {
+		return futures.New(func(ctx context.Context) (int, error) {
+			close(innerStarted)
+			<-ctx.Done()
+			close(innerCanceled)
+			return 0, ctx.Err()
+		})
+	})
+	<-innerStarted
+	out.Cancel()
+	select {
+	case <-innerCanceled:
+	case <-time.After(time.Second):
+		t.Fatal("the inner Future was not canceled")
+	}
+}
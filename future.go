@@ -0,0 +1,390 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Future is a proxy for a result that is initially unknown because its
+// computation has not yet completed.
+//
+// A Future settles exactly once, either with a value or with an error.
+// After settlement, the result is stored inside the Future, so reading it
+// never blocks and can happen any number of times.
+//
+// All methods of Future are safe for concurrent use. Any number of
+// goroutines may call Get, GetWithContext, TryGet, Wait, Done, Err, and
+// State at the same time, before or after settlement, and all of them
+// observe the same result. There is no need to fan out the result by hand.
+//
+// Future is built on Core. Use Core to build future types of your own.
+type Future[T any] struct {
+	// core is not embedded so that the readers of a Future cannot settle
+	// it. The Done channel of core is created along with the Future.
+	core   Core[T]
+	name   string
+	label  string
+	cancel context.CancelCauseFunc
+
+	// clock, started, and hooks serve the settle hooks. started and ended
+	// are guarded by core.mu; they also serve Duration and the history.
+	clock   Clock
+	started time.Time
+	ended   time.Time
+	hooks   []func(SettleInfo)
+
+	// requireNonZero is set by WithRequireNonZero.
+	requireNonZero bool
+	// repanic is set by WithRepanic; repanicked records that a reader
+	// has panicked already.
+	repanic    bool
+	repanicked atomic.Bool
+	// panicHandler is set by WithPanicHandler.
+	panicHandler func(*PanicError)
+
+	awaited atomic.Bool
+	// dropGuarded is set once EnsureNoDrop or Inspect has set up the
+	// finalizer.
+	dropGuarded atomic.Bool
+	// insp is what Inspect records in debug builds.
+	insp inspectState
+	// fromArena is set for futures allocated from an Arena.
+	fromArena bool
+
+	// start starts the computation of a lazy Future.
+	start     func()
+	startOnce sync.Once
+
+	// chain is the Chain the Future belongs to, if any.
+	chain atomic.Pointer[Chain]
+
+	// closeOnce and closeErr serve Close.
+	closeOnce sync.Once
+	closeErr  error
+
+	// icpt is set if interceptors were registered when the Future was
+	// created.
+	icpt *interception
+
+	// timers serves the timers of the Future; see afterFunc.
+	timers atomic.Pointer[timerMux]
+}
+
+// New runs fn in a new goroutine and returns a Future for its result.
+// Use WithExecutor to run fn elsewhere.
+//
+// The context passed to fn is canceled once the Future has settled. If fn
+// panics, the panic is recovered and the Future fails with a *PanicError.
+func New[T any](fn func(ctx context.Context) (T, error), opts ...Option) *Future[T] {
+	return NewWithContext(context.Background(), fn, opts...)
+}
+
+// NewWithContext is like New but derives the context passed to fn from ctx.
+// If ctx is done before fn returns, the Future fails right away with
+// ErrCanceled or ErrTimeout, and the eventual result of fn is discarded. If ctx has no deadline,
+// PackageOptions.DefaultTimeout applies.
+func NewWithContext[T any](ctx context.Context, fn func(ctx context.Context) (T, error), opts ...Option) *Future[T] {
+	f, start := prepare(ctx, fn, newOptions(opts))
+	start()
+	return f
+}
+
+// Lazy returns a Future whose computation starts only when the Future is
+// first awaited through Get, GetWithContext, Wait, or Done. fn runs at most
+// once, no matter how many readers race to be first. If the Future is
+// canceled before anyone awaits it, fn never runs.
+func Lazy[T any](fn func(ctx context.Context) (T, error), opts ...Option) *Future[T] {
+	f, start := prepare(context.Background(), fn, newOptions(opts))
+	f.start = start
+	return f
+}
+
+// NewLazy is like Lazy for computations that cannot fail and do not need a
+// context. The result of fn is cached; later reads do not call fn again.
+// Use it for expensive values that might not be needed, such as a fallback
+// that is only used if the primary source fails.
+func NewLazy[T any](fn func() T, opts ...Option) *Future[T] {
+	return Lazy(func(context.Context) (T, error) { return fn(), nil }, opts...)
+}
+
+// prepare returns a new Future for the result of fn and the function that
+// starts the computation.
+func prepare[T any](parent context.Context, fn func(ctx context.Context) (T, error), o *options) (*Future[T], func()) {
+	f := configuredFuture[T](o)
+	if shedding(o.name, o.label) {
+		var zero T
+		f.settle(zero, ErrShedding)
+		return f, func() {}
+	}
+	for i := 0; i < len(o.values); i += 2 {
+		parent = context.WithValue(parent, o.values[i], o.values[i+1])
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	f.cancel = cancel
+	start := func() {
+		f.markStarted()
+		ctx, cancelTimeout := withDefaultTimeout(ctx)
+		ctx = context.WithValue(ctx, awaiterKey{}, Awaiter(f))
+		stop := context.AfterFunc(ctx, func() {
+			var zero T
+			f.settle(zero, contextError(ctx))
+		})
+		run := func(ctx context.Context) {
+			returned := false
+			defer func() {
+				if returned {
+					return
+				}
+				// fn panicked or called runtime.Goexit. Don't leave the
+				// readers waiting forever.
+				err := ErrBrokenPromise
+				if r := recover(); r != nil {
+					err = newPanicError(r)
+				}
+				if stop() {
+					var zero T
+					f.settle(zero, err)
+				}
+			}()
+			v, err := fn(ctx)
+			returned = true
+			if err == nil {
+				checkContexts(f.name, v)
+			} else if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+				// fn saw ctx done before the AfterFunc above did.
+				err = contextError(ctx)
+			}
+			if stop() {
+				f.settleComputed(v, err)
+			}
+		}
+		o.executor.Go(func() {
+			defer cancelTimeout()
+			defer cancel(nil)
+			if !slots.acquire(ctx) {
+				return
+			}
+			defer slots.release()
+			if ctx.Err() != nil {
+				return
+			}
+			if o.label == "" {
+				run(ctx)
+				return
+			}
+			pprof.Do(ctx, pprof.Labels("future", o.label), run)
+		})
+	}
+	return f, start
+}
+
+// configuredFuture returns a new pending Future set up according to o.
+func configuredFuture[T any](o *options) *Future[T] {
+	f := allocFuture[T](o.arena)
+	f.name = o.name
+	f.label = o.label
+	f.clock = o.clock
+	f.hooks = o.hooks
+	f.requireNonZero = o.requireNonZero
+	f.repanic = o.repanic
+	f.panicHandler = o.panicHandler
+	f.intercept()
+	return f
+}
+
+// markStarted records the start of the computation.
+func (f *Future[T]) markStarted() {
+	now := f.timerClock().Now()
+	f.core.mu.Lock()
+	f.started = now
+	f.core.mu.Unlock()
+}
+
+// newFuture returns a pending Future without options, as used for the
+// futures that combinators derive from others.
+func newFuture[T any]() *Future[T] {
+	f := rawFuture[T]()
+	f.intercept()
+	return f
+}
+
+// rawFuture is newFuture without reporting to the interceptors, for
+// constructors that do so once they have applied their options.
+func rawFuture[T any]() *Future[T] {
+	f := &Future[T]{}
+	f.core.done = make(chan struct{})
+	return f
+}
+
+// closedChan is the Done channel of all futures that are created in a
+// settled state.
+var closedChan = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// Completed returns a Future that has already resolved to v. No goroutine
+// is started.
+func Completed[T any](v T) *Future[T] {
+	return settled(v, nil)
+}
+
+// Failed returns a Future that has already failed with err. No goroutine
+// is started.
+func Failed[T any](err error) *Future[T] {
+	var zero T
+	return settled(zero, err)
+}
+
+func settled[T any](v T, err error) *Future[T] {
+	f := &Future[T]{}
+	f.core.done = closedChan
+	f.core.settled = true
+	f.core.resolved.Store(true)
+	f.core.value, f.core.err = v, err
+	f.intercept()
+	if f.icpt != nil {
+		f.icpt.settled(err, f.icpt.created)
+	}
+	return f
+}
+
+// settle stores the result and wakes up all readers. Only the first call
+// has an effect; settle reports whether it was that call.
+func (f *Future[T]) settle(v T, err error) bool {
+	if f.requireNonZero && err == nil && isZero(v) {
+		err = ErrZeroValue
+	}
+	err = f.insp.annotate(err)
+	runHooks := len(f.hooks) > 0
+	if !f.core.settle(v, err, f.timerClock(), &f.ended) {
+		return false
+	}
+	if runHooks {
+		f.runHooks(err)
+	}
+	if h := history.Load(); h != nil {
+		f.recordHistory(h, err)
+	}
+	if f.icpt != nil {
+		f.icpt.settled(err, f.ended)
+	}
+	return true
+}
+
+// Name returns the name set by WithName.
+func (f *Future[T]) Name() string {
+	return f.name
+}
+
+// Cancel cancels the Future. If it is still pending, it fails with
+// ErrCanceled, and the context of its computation is canceled so the
+// computation can stop early. Pending calls to Get and friends return
+// right away. A computation that has not started yet never runs.
+//
+// Canceling a Future that has already settled does not change its
+// outcome. Calling Cancel more than once is harmless.
+func (f *Future[T]) Cancel() {
+	f.CancelWithCause(nil)
+}
+
+// CancelWithCause is like Cancel but records why the Future was canceled.
+// If cause is not nil, the error of the Future matches ErrCanceled in
+// errors.Is, and errors.Unwrap returns cause. The context of the
+// computation reports cause through context.Cause.
+func (f *Future[T]) CancelWithCause(cause error) {
+	err := ErrCanceled
+	if cause != nil {
+		err = &causeError{kind: ErrCanceled, cause: cause}
+	}
+	var zero T
+	f.settle(zero, err)
+	if f.cancel != nil {
+		f.cancel(cause)
+	}
+}
+
+// markAwaited records that f has been awaited. It only writes once, so
+// that many readers of a settled Future do not contend for the flag.
+func (f *Future[T]) markAwaited() {
+	if !f.awaited.Load() {
+		f.awaited.Store(true)
+	}
+}
+
+// ensureStarted starts the computation of a lazy Future.
+func (f *Future[T]) ensureStarted() {
+	if f.start != nil {
+		f.startOnce.Do(f.start)
+	}
+}
+
+// Done returns a channel that is closed when the Future has settled.
+// Use it to wait for the Future inside a select statement. All callers get
+// the same channel, so any number of goroutines can wait on it.
+func (f *Future[T]) Done() <-chan struct{} {
+	f.markAwaited()
+	f.ensureStarted()
+	return f.core.done
+}
+
+// Get blocks until the Future has settled and returns its value and error.
+// Any number of goroutines may wait in Get at the same time; all of them
+// return the same value and error.
+//
+// If the Future was created with WithRepanic and its computation
+// panicked, the first read through Get or GetWithContext panics with an
+// error that wraps the *PanicError and whose message includes the stack
+// of the computation. Later reads return the *PanicError as usual.
+func (f *Future[T]) Get() (T, error) {
+	f.markAwaited()
+	f.ensureStarted()
+	f.checkStrict()
+	v, err := f.core.Get()
+	if f.repanic {
+		f.maybeRepanic(err)
+	}
+	return v, err
+}
+
+// Err returns the error the Future failed with, without blocking.
+//
+// Like context.Context.Err, Err returns nil while the Future is pending.
+// After settlement, it returns nil if the Future resolved successfully, the
+// computation's error if it failed, and ErrCanceled or ErrTimeout if it
+// was canceled.
+func (f *Future[T]) Err() error {
+	return f.core.Err()
+}
+
+// GetWithContext is like Get but gives up waiting when ctx is done, in
+// which case it returns the zero value and ErrCanceled or ErrTimeout,
+// depending on ctx.Err(). Giving up does not affect the Future; it can
+// still be read later. The computation never waits for readers, so it
+// completes and ends even if every reader has given up.
+//
+// Like Get, GetWithContext panics on the first read of a Future created
+// with WithRepanic whose computation panicked.
+func (f *Future[T]) GetWithContext(ctx context.Context) (T, error) {
+	f.markAwaited()
+	f.ensureStarted()
+	f.checkStrict()
+	v, err := f.core.GetWithContext(ctx)
+	if f.repanic {
+		f.maybeRepanic(err)
+	}
+	return v, err
+}
+
+// Wait blocks until the Future has settled or ctx is done, whichever
+// happens first. It returns the error of the Future, or the error of
+// GetWithContext if ctx is done first. Use Wait for futures that run for their side effects only.
+func (f *Future[T]) Wait(ctx context.Context) error {
+	_, err := f.GetWithContext(ctx)
+	return err
+}
@@ -0,0 +1,529 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestNewResolves(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+	for i := 0; i < 3; i++ {
+		v, err := f.Get()
+		if v != 42 || err != nil {
+			t.Fatalf("Get #%d = %v, %v; want 42, nil", i, v, err)
+		}
+	}
+}
+
+func panickingComputation(ctx context.Context) (int, error) {
+	panic("computation failed")
+}
+
+func TestNewRecoversPanic(t *testing.T) {
+	f := futures.New(panickingComputation, futures.WithGoroutineLabel("panics"))
+	_, err := f.Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("err = %v, want a *PanicError", err)
+	}
+	if pe.Value() != "computation failed" {
+		t.Errorf("Value() = %v, want the panic value", pe.Value())
+	}
+	if !strings.Contains(string(pe.Stack()), "panickingComputation") {
+		t.Errorf("Stack() does not show where the panic happened:\n%s", pe.Stack())
+	}
+}
+
+func TestNewRecoversErrorPanic(t *testing.T) {
+	cause := errors.New("cause")
+	_, err := futures.New(func(ctx context.Context) (int, error) { panic(cause) }).Get()
+	if !errors.Is(err, cause) {
+		t.Errorf("err = %v, want it to wrap the panic value", err)
+	}
+}
+
+func writeNilMap(ctx context.Context) (int, error) {
+	var m map[string]int
+	m["x"] = 1
+	return len(m), nil
+}
+
+func TestNewRecoversRuntimePanic(t *testing.T) {
+	_, err := futures.New(writeNilMap).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("err = %v, want a *PanicError", err)
+	}
+	var re runtime.Error
+	if !errors.As(err, &re) {
+		t.Errorf("err = %v does not wrap the runtime.Error", err)
+	}
+	if !strings.Contains(string(pe.Stack()), "writeNilMap") {
+		t.Errorf("Stack() does not show where the panic happened:\n%s", pe.Stack())
+	}
+}
+
+func TestWithRepanic(t *testing.T) {
+	f := futures.New(panickingComputation, futures.WithRepanic())
+	<-f.Done()
+
+	r := func() (r any) {
+		defer func() { r = recover() }()
+		f.Get()
+		return nil
+	}()
+	err, ok := r.(error)
+	if !ok {
+		t.Fatalf("the first Get recovered %v, want a panic with an error", r)
+	}
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) || pe.Value() != "computation failed" {
+		t.Errorf("panic value %v does not wrap the PanicError", err)
+	}
+	if !strings.Contains(err.Error(), "panickingComputation") {
+		t.Errorf("panic message does not show the stack of the computation:\n%s", err)
+	}
+
+	// Later reads do not panic.
+	for i := 0; i < 2; i++ {
+		if _, err := f.Get(); !errors.As(err, &pe) {
+			t.Errorf("Get #%d: err = %v, want the *PanicError", i+2, err)
+		}
+	}
+	if _, err := f.GetWithContext(context.Background()); !errors.As(err, &pe) {
+		t.Errorf("GetWithContext: err = %v, want the *PanicError", err)
+	}
+}
+
+func TestWithRepanicOnlyPanics(t *testing.T) {
+	errBoom := errors.New("boom")
+	f := futures.New(func(ctx context.Context) (int, error) { return 0, errBoom }, futures.WithRepanic())
+	if _, err := f.Get(); !errors.Is(err, errBoom) {
+		t.Errorf("err = %v, want %v", err, errBoom)
+	}
+}
+
+func TestNewLazy(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	f := futures.NewLazy(func() string {
+		calls.Add(1)
+		<-release
+		return "fallback"
+	})
+	time.Sleep(10 * time.Millisecond)
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("fn ran %d times before the first Get", n)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := f.GetWithContext(context.Background()); v != "fallback" || err != nil {
+				t.Errorf("GetWithContext = %q, %v; want fallback, nil", v, err)
+			}
+		}()
+	}
+	eventually(t, func() bool { return calls.Load() == 1 }, "fn did not start")
+	close(release)
+	wg.Wait()
+	f.Get()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fn ran %d times, want 1", n)
+	}
+}
+
+func TestNewWithContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := futures.NewWithContext(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 1, nil
+	})
+	cancel()
+	if _, err := f.Get(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Get: err = %v, want context.Canceled", err)
+	}
+}
+
+func TestGetWithContextGivesUp(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := f.GetWithContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetWithContext: err = %v, want context.Canceled", err)
+	}
+	if err := f.Err(); err != nil {
+		t.Fatalf("giving up affected the Future: Err = %v", err)
+	}
+}
+
+func TestGetWithContextSubMillisecondTimeout(t *testing.T) {
+	p := futures.NewPromise[int]()
+	defer p.Resolve(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Microsecond)
+	defer cancel()
+	if _, err := p.Future().GetWithContext(ctx); !errors.Is(err, futures.ErrTimeout) {
+		t.Fatalf("GetWithContext: err = %v, want ErrTimeout", err)
+	}
+}
+
+func TestErr(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	t.Run("pending", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		f := futures.New(func(ctx context.Context) (int, error) {
+			<-release
+			return 1, nil
+		})
+		if err := f.Err(); err != nil {
+			t.Errorf("Err = %v, want nil", err)
+		}
+	})
+	t.Run("resolved", func(t *testing.T) {
+		f := futures.New(func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+		f.Get()
+		if err := f.Err(); err != nil {
+			t.Errorf("Err = %v, want nil", err)
+		}
+	})
+	t.Run("failed", func(t *testing.T) {
+		f := futures.New(func(ctx context.Context) (int, error) {
+			return 0, errBoom
+		})
+		f.Get()
+		if err := f.Err(); err != errBoom {
+			t.Errorf("Err = %v, want %v", err, errBoom)
+		}
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		f := futures.NewWithContext(ctx, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, nil
+		})
+		cancel()
+		<-f.Done()
+		if err := f.Err(); !errors.Is(err, context.Canceled) {
+			t.Errorf("Err = %v, want context.Canceled", err)
+		}
+	})
+}
+
+func TestErrConcurrentWithGet(t *testing.T) {
+	release := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 0, errors.New("boom")
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for f.Err() == nil {
+		}
+	}()
+	close(release)
+	f.Get()
+	<-done
+}
+
+func TestManyReadersNoLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+	release := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 7, nil
+	})
+	const readers = 1000
+	var wg sync.WaitGroup
+	var wrong atomic.Int32
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := f.Get(); v != 7 || err != nil {
+				wrong.Add(1)
+			}
+		}()
+	}
+	close(release)
+	wg.Wait()
+	if n := wrong.Load(); n > 0 {
+		t.Errorf("%d of %d readers got a wrong result", n, readers)
+	}
+	// The stored result serves further readers without a producer.
+	if v, _ := f.Get(); v != 7 {
+		t.Errorf("Get after completion = %d, want 7", v)
+	}
+	eventually(t, func() bool { return runtime.NumGoroutine() <= before },
+		"goroutines are left over after the Future settled")
+}
+
+func TestGiveUpDoesNotStrandProducer(t *testing.T) {
+	release := make(chan struct{})
+	exited := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		defer close(exited)
+		<-release
+		return 1, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := f.GetWithContext(ctx); !errors.Is(err, futures.ErrTimeout) {
+		t.Fatalf("GetWithContext: err = %v, want ErrTimeout", err)
+	}
+	// Nobody reads f anymore; the producer must finish anyway.
+	close(release)
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the producer did not exit after the reader gave up")
+	}
+}
+
+func TestWaitManyWaiters(t *testing.T) {
+	release := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	const waiters = 10
+	errs := make(chan error, waiters)
+	for i := 0; i < waiters; i++ {
+		go func() { errs <- f.Wait(context.Background()) }()
+	}
+	close(release)
+	for i := 0; i < waiters; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("Wait: %v", err)
+		}
+	}
+}
+
+func TestWaitReturnsError(t *testing.T) {
+	errBoom := errors.New("boom")
+	if err := futures.Failed[int](errBoom).Wait(context.Background()); err != errBoom {
+		t.Errorf("Wait = %v, want %v", err, errBoom)
+	}
+	release := make(chan struct{})
+	defer close(release)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := f.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait with canceled ctx = %v, want context.Canceled", err)
+	}
+}
+
+func TestCompletedAndFailed(t *testing.T) {
+	before := runtime.NumGoroutine()
+	c := futures.Completed(3)
+	errBoom := errors.New("boom")
+	f := futures.Failed[int](errBoom)
+	if n := runtime.NumGoroutine(); n != before {
+		t.Errorf("goroutines: %d before, %d after", before, n)
+	}
+	for _, fut := range []*futures.Future[int]{c, f} {
+		select {
+		case <-fut.Done():
+		default:
+			t.Fatal("Done is not closed")
+		}
+	}
+	if v, err := c.Get(); v != 3 || err != nil {
+		t.Errorf("Completed(3).Get() = %v, %v", v, err)
+	}
+	if _, err := f.Get(); err != errBoom {
+		t.Errorf("Failed(err).Get(): err = %v, want %v", err, errBoom)
+	}
+}
+
+func TestLazyStartsOnFirstRead(t *testing.T) {
+	var calls atomic.Int32
+	f := futures.Lazy(func(ctx context.Context) (int, error) {
+		calls.Add(1)
+		return 7, nil
+	})
+	time.Sleep(10 * time.Millisecond)
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("fn ran %d times before the first read", n)
+	}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			var v int
+			var err error
+			switch i % 3 {
+			case 0:
+				v, err = f.Get()
+			case 1:
+				v, err = f.GetWithContext(context.Background())
+			default:
+				<-f.Done()
+				v, err = f.Get()
+			}
+			if v != 7 || err != nil {
+				t.Errorf("read = %d, %v; want 7, nil", v, err)
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fn ran %d times, want 1", n)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	started := make(chan struct{})
+	stopped := make(chan error, 1)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return 1, nil
+	})
+	<-started
+	f.Cancel()
+	if _, err := f.Get(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Get after Cancel: err = %v, want context.Canceled", err)
+	}
+	if err := <-stopped; !errors.Is(err, context.Canceled) {
+		t.Errorf("computation saw ctx.Err() = %v, want context.Canceled", err)
+	}
+	f.Cancel() // harmless
+	if _, err := f.Get(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Get after second Cancel: err = %v, want context.Canceled", err)
+	}
+}
+
+func TestCancelWakesReaders(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release // ignores ctx on purpose
+		return 1, nil
+	})
+	got := make(chan error)
+	go func() {
+		_, err := f.Get()
+		got <- err
+	}()
+	eventually(t, f.HasWaiters, "reader did not block")
+	f.Cancel()
+	if err := <-got; !errors.Is(err, context.Canceled) {
+		t.Errorf("blocked Get: err = %v, want context.Canceled", err)
+	}
+}
+
+func TestCancelAfterSettle(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) { return 1, nil }, futures.WithExecutor(futures.SyncExecutor))
+	f.Cancel()
+	if v, err := f.Get(); v != 1 || err != nil {
+		t.Errorf("Get = %d, %v; want 1, nil", v, err)
+	}
+	c := futures.Completed(2)
+	c.Cancel()
+	if v, err := c.Get(); v != 2 || err != nil {
+		t.Errorf("Completed after Cancel: Get = %d, %v; want 2, nil", v, err)
+	}
+}
+
+func TestCancelBeforeStart(t *testing.T) {
+	var ran atomic.Bool
+	fn := func(ctx context.Context) (int, error) {
+		ran.Store(true)
+		return 1, nil
+	}
+	// The executor holds the computation back until the Future has been
+	// canceled.
+	var queued []func()
+	held := futures.ExecutorFunc(func(fn func()) { queued = append(queued, fn) })
+	f := futures.New(fn, futures.WithExecutor(held))
+	f.Cancel()
+	for _, q := range queued {
+		q()
+	}
+	if _, err := f.Get(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Get: err = %v, want context.Canceled", err)
+	}
+
+	lazy := futures.Lazy(fn, futures.WithExecutor(futures.SyncExecutor))
+	lazy.Cancel()
+	if _, err := lazy.Get(); !errors.Is(err, context.Canceled) {
+		t.Errorf("lazy Get: err = %v, want context.Canceled", err)
+	}
+	if ran.Load() {
+		t.Error("fn ran although its Future was canceled before it started")
+	}
+}
+
+func BenchmarkCompleted(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		futures.Completed(i).Get()
+	}
+}
+
+func BenchmarkFailed(b *testing.B) {
+	errBoom := errors.New("boom")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		futures.Failed[int](errBoom).Get()
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		futures.New(func(ctx context.Context) (int, error) { return 1, nil }).Get()
+	}
+}
+
+// BenchmarkGetWithTimeout reads a value that arrives right away with a
+// long timeout. The timer of each read is released as soon as the read
+// returns, so the live heap after the loop does not grow with b.N.
+func BenchmarkGetWithTimeout(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p := futures.NewPromise[int]()
+		f := p.Future()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		p.Resolve(i)
+		f.GetWithContext(ctx)
+		cancel()
+	}
+	b.StopTimer()
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	b.ReportMetric(float64(m.HeapInuse)/(1<<20), "heap-MiB")
+}
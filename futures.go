@@ -1,3 +1,5 @@
+//go:build ignore
+
 /*
 <!--
 Copyright (c) 2019 Christoph Berger. Some rights reserved.
@@ -113,32 +115,34 @@ c := make(chan int, 1)
 
 Now the spawned goroutine can pass the result to the channel and continue immediately, maybe computing other futures that depend on the one just delivered. Or doing cleanup or whatever.
 
-However, here lies a catch: On a single-core CPU where the concurrent execution cannot be effectively parallelized, the computing goroutine may block the reading goroutine while it continues computing things.
+The goroutine does not need to stay alive for the reader, either. Once it has sent the result, it can return, and the buffered value waits in the channel until someone reads it.
 
 ### Read the computed future more than once
 
 Assumption #2 is also just fine in most cases. However, sometimes you might have multiple goroutines that shall receive the computed value.
 
-Again, this is trivial in Go. We only need to make the computing goroutine send the result to the channel over and over again.
+A tempting approach is to make the computing goroutine send the result to the channel over and over again, in an endless loop. Don't do that. The goroutine never ends, so it leaks for the life of the program, and it serves only one reader at a time.
+
+Instead, let the computing goroutine store the result in a variable and then close a channel. Receiving from a closed channel never blocks, and every reader that is waiting on the channel unblocks at the same time. The goroutine returns right after closing the channel.
 
 ```go
-go func(input int, result chan<- int) {
-	value := compute(input)
-	for {
-		result <- value
-	}
-}(256, c)
+var value int
+done := make(chan struct{})
+go func(input int) {
+	value = compute(input)
+	close(done)
+}(256)
 ```
 
-On the consuming side, noting needs to change. We can repeatedly reading from the channel and get the same value back, once it has been computed.
+A reader waits for the channel to be closed and then reads the variable. The Go memory model guarantees that the write to `value` happens before the close of `done`, and the close happens before any receive that returns because the channel is closed. Hence every reader sees the complete value, no matter how many readers there are.
 
 ```go
-value1 := <-c
-value2 := <-c  // Read again, get the same value again
+<-done
+value1 := value
+<-done // Read again, get the same value again
+value2 := value
 ```
 
-And in case you wonder -- no, there is no busy-looping happening here. The loop blocks on every attempt to write to the channel until a reader retrieves a value from the channel.
-
 
 
 ### Limit the time to wait for the future
@@ -149,16 +153,20 @@ Obviously, we need to be able to set an upper limit for the time to wait for the
 
 This time we need to change the caller/reader side. The computing goroutine can remain unchanged.
 
-To be able to watch for both the result of the future and a timeout, we define a `get()` function to retrieve the value. Inside the function, a `select` statement observes both the result channel and a timer that we start by calling `time.After()`. This method returns a channel upon calling, and sends the current time through that channel when the time is up. We do not need that time, so the result is discarded.
+To be able to watch for both the result of the future and a timeout, we define a `get()` function to retrieve the value. Inside the function, a `select` statement observes both the result channel and the channel of a timer that we start by calling `time.NewTimer()`. The timer sends the current time through its channel when the time is up. We do not need that time, so the result is discarded.
+
+Why not the shorter `time.After()`? It creates a timer, too, but hands out only its channel, so there is no way to stop the timer. Before Go 1.23, such a timer stays in memory until it fires, even if the result arrived long before. With long timeouts and many reads, these timers pile up. A deferred `Stop()` releases the timer as soon as `get()` returns.
 
 When the timer triggers, we need to indicate failure to the caller. For this, we can add a second return parameter that turns true if a timeout occurs.
 
 ```go
 	get := func(s int) (result int, timedout bool) {
+		timer := time.NewTimer(time.Duration(s) * time.Second)
+		defer timer.Stop()
 		select {
 		case result = <-c3:
 			return result, false
-		case <-time.After(time.Duration(s) * time.Second):
+		case <-timer.C:
 			return 0, true
 		}
 	}
@@ -173,6 +181,12 @@ if timedOut {
 }
 ```
 
+There is one more thing to watch out for. If the reader times out and goes away, nobody will ever receive from the channel. With an unbuffered channel, the computing goroutine then blocks forever on its send. In a long-running server, this leaks one goroutine per timeout. Give the channel a buffer of 1, so that the computing goroutine can always deliver its result and end, whether or not anyone is still waiting for it:
+
+```go
+c3 := make(chan int, 1)
+```
+
 ## More "futureness"
 
 Futures in other languages usually have a couple more methods as the authors strived to cover every imaginable use case. You do not need those at all costs. If you do, here are suggestions for mapping these methods to Go features.
@@ -272,46 +286,46 @@ func main() {
 	fmt.Println("\nReading the future multiple times")
 	fmt.Println("-----------------------------\n")
 
-	c2 := make(chan int)
-
-	// We modify the calculating goroutine a bit. After calculating the result, the goroutine goes into an infinite loop to pass the result to the channel as often as some other code wants to read it. Note that this is not a busy loop, as each iteration blocks until the channel is free to write to.
-	go func(input int, res chan<- int) {
+	// We modify the calculating goroutine a bit. It stores the result in a variable and closes a channel to signal that the result is ready. Then it ends; no goroutine stays behind to serve the readers.
+	var value2 int
+	done2 := make(chan struct{})
+	go func(input int) {
 		fmt.Println("Calculating")
 		time.Sleep(1 * time.Second)
-		for {
-			fmt.Println("Writing result")
-			res <- input * 4
-		}
-	}(1, c2)
+		value2 = input * 4
+		close(done2)
+	}(1)
 
-	// Now we can read repeatedly from the channel and get the same result as often as we want.
+	// Now any number of readers can wait for the channel to be closed and read the same result as often as they want.
 	fmt.Println("Waiting")
-	fmt.Println("got", <-c2)
-	fmt.Println("got", <-c2)
+	<-done2
+	fmt.Println("got", value2)
+	<-done2
+	fmt.Println("got", value2)
 
 	// ## Read with a timeout
 
 	fmt.Println("\nReading with a timeout")
 	fmt.Println("-----------------------------\n")
 
-	c3 := make(chan int)
+	// A buffer of 1 lets the computing goroutine deliver its result and end even if the reader has given up waiting.
+	c3 := make(chan int, 1)
 
-	// The computing goroutine can remain unchanged.
 	go func(input int, res chan<- int) {
 		fmt.Println("Calculating")
 		time.Sleep(2 * time.Second)
-		for {
-			fmt.Println("Writing result")
-			res <- input * 8
-		}
+		fmt.Println("Writing result")
+		res <- input * 8
 	}(1, c3)
 
 	// The select statement allows reading from multiple channels simultaneously. Here, we use it to block until either the future is ready to read or the timer triggers, whichever happens first.
 	get := func(s int) (result int, timedout bool) {
+		timer := time.NewTimer(time.Duration(s) * time.Second)
+		defer timer.Stop()
 		select {
 		case result = <-c3:
 			return result, false
-		case <-time.After(time.Duration(s) * time.Second):
+		case <-timer.C:
 			return 0, true
 		}
 	}
@@ -339,6 +353,8 @@ Step 2: `cd` to the source code directory and run the code.
 
 Or run the code directly in the [Go Playground](https://play.golang.org/p/gyLQb5mMKl_V).
 
+The three futures are also available as importable, tested functions in package [`tutorial`](https://pkg.go.dev/github.com/appliedgo/futures/tutorial), complete with stop functions that end the computing goroutines.
+
 **Happy coding!**
 
 ___
@@ -347,4 +363,10 @@ Changelog
 
 2023-10-17 Small improvements to the code for "Read the future more than once"
 
+2026-10-17 "Read the future more than once" stores the result and closes a channel instead of sending the result in an endless loop; the timeout example no longer strands the computing goroutine
+
+2026-10-17 The timeout example stops its timer instead of using `time.After()`
+
+2026-10-17 Package `tutorial` contains the three futures as leak-free functions
+
 */
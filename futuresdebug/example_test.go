@@ -0,0 +1,28 @@
+package futuresdebug_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuresdebug"
+)
+
+func ExampleHistoryHandler() {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/futures/history", futuresdebug.HistoryHandler())
+
+	futures.SetLatencyOverride("payments", 200*time.Millisecond)
+	defer futures.SetLatencyOverride("payments", 0)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/futures/history", nil))
+	fmt.Print(rec.Body)
+	// Output:
+	// ACTIVE LATENCY OVERRIDES
+	//   payments: +200ms
+	//
+	// futures: history is off; call EnableHistory to turn it on
+}
@@ -0,0 +1,39 @@
+// Package futuresdebug serves the debugging state of package futures over
+// HTTP. It lives apart from package futures to keep net/http out of the
+// core.
+package futuresdebug
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/appliedgo/futures"
+)
+
+// HistoryHandler returns an HTTP handler that renders the active latency
+// overrides and the settlements recorded by futures.EnableHistory as
+// plain text. Mount it on a debug endpoint.
+func HistoryHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if overrides := futures.LatencyOverrides(); len(overrides) > 0 {
+			names := make([]string, 0, len(overrides))
+			for name := range overrides {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			io.WriteString(w, "ACTIVE LATENCY OVERRIDES\n")
+			for _, name := range names {
+				fmt.Fprintf(w, "  %s: +%v\n", name, overrides[name])
+			}
+			io.WriteString(w, "\n")
+		}
+		if futures.History() == nil {
+			io.WriteString(w, "futures: history is off; call EnableHistory to turn it on\n")
+			return
+		}
+		futures.WriteHistory(w)
+	})
+}
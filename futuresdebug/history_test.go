@@ -0,0 +1,56 @@
+package futuresdebug_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuresdebug"
+)
+
+func serve(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	futuresdebug.HistoryHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/futures/history", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q, want text/plain", ct)
+	}
+	return rec.Body.String()
+}
+
+func TestHistoryHandler(t *testing.T) {
+	futures.EnableHistory(3)
+	defer futures.EnableHistory(0)
+	for i := 1; i <= 5; i++ {
+		p := futures.NewPromise[int](futures.WithName(fmt.Sprintf("f%d", i)))
+		if i == 5 {
+			p.Reject(fmt.Errorf("boom"))
+		} else {
+			p.Resolve(i)
+		}
+	}
+	body := serve(t)
+	if !strings.Contains(body, "f5") || !strings.Contains(body, "boom") || strings.Contains(body, "f2") {
+		t.Errorf("handler output does not show the last three settlements:\n%s", body)
+	}
+}
+
+func TestHistoryHandlerOff(t *testing.T) {
+	if body := serve(t); !strings.Contains(body, "off") {
+		t.Errorf("handler output = %q, want a note that the history is off", body)
+	}
+}
+
+func TestHistoryHandlerLatencyOverrides(t *testing.T) {
+	futures.SetLatencyOverride("checkout-tax", 800*time.Millisecond)
+	if body := serve(t); !strings.Contains(body, "checkout-tax: +800ms") {
+		t.Errorf("handler output does not show the override:\n%s", body)
+	}
+	futures.SetLatencyOverride("checkout-tax", 0)
+	if body := serve(t); strings.Contains(body, "OVERRIDES") {
+		t.Errorf("handler output shows a removed override:\n%s", body)
+	}
+}
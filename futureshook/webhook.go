@@ -0,0 +1,162 @@
+// Package futureshook sends notifications about settled futures to
+// external systems. It lives apart from package futures to keep net/http
+// out of the core.
+package futureshook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+// Notification is the JSON document that WithWebhook posts.
+type Notification struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	// State is one of "resolved", "failed", "canceled", and "timeout".
+	State      string `json:"state"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// WebhookOption configures WithWebhook.
+type WebhookOption func(*webhook)
+
+// WithAttempts sets how many times a notification is sent before giving
+// up. The default is 3.
+func WithAttempts(n int) WebhookOption {
+	return func(w *webhook) {
+		w.attempts = max(n, 1)
+	}
+}
+
+// WithBackoff sets the delay between attempts. The default is an
+// exponential backoff starting at 100ms.
+func WithBackoff(p futures.BackoffPolicy) WebhookOption {
+	return func(w *webhook) {
+		w.backoff = p
+	}
+}
+
+// WithNotifyTimeout caps the total time spent on one notification,
+// including all attempts and the delays between them. The default is 10s.
+func WithNotifyTimeout(d time.Duration) WebhookOption {
+	return func(w *webhook) {
+		w.timeout = d
+	}
+}
+
+type webhook struct {
+	url      string
+	client   *http.Client
+	attempts int
+	backoff  futures.BackoffPolicy
+	timeout  time.Duration
+}
+
+// WithWebhook makes a Future post a Notification to url when it settles.
+// A nil client means http.DefaultClient.
+//
+// The notification is sent in the background, so it never delays the
+// Future or its other settle hooks. Failed attempts are retried; once all
+// attempts have failed or the notification timeout has passed, the error
+// goes to the unobserved-error handler of package futures.
+func WithWebhook(url string, client *http.Client, opts ...WebhookOption) futures.Option {
+	w := &webhook{
+		url:      url,
+		client:   client,
+		attempts: 3,
+		backoff:  futures.ExponentialBackoff(100*time.Millisecond, 2, 2*time.Second),
+		timeout:  10 * time.Second,
+	}
+	if w.client == nil {
+		w.client = http.DefaultClient
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return futures.WithSettleHook(func(info futures.SettleInfo) {
+		go w.notify(info)
+	})
+}
+
+func (w *webhook) notify(info futures.SettleInfo) {
+	n := Notification{
+		Name:       info.Name,
+		State:      state(info.Err),
+		DurationMS: info.Duration.Milliseconds(),
+	}
+	if info.Label != "" {
+		n.Labels = map[string]string{"future": info.Label}
+	}
+	if info.Err != nil {
+		n.Error = info.Err.Error()
+	}
+	body, err := json.Marshal(n)
+	if err != nil {
+		futures.ReportUnobservedError(fmt.Errorf("futureshook: encoding notification for future %q: %w", info.Name, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+	_, err = futures.RetryWithBackoffContext(ctx, func() *futures.Future[struct{}] {
+		return futures.NewWithContext(ctx, func(ctx context.Context) (struct{}, error) {
+			return struct{}{}, w.post(ctx, body)
+		})
+	}, limit{w.backoff, w.attempts}).Get()
+	if err != nil {
+		futures.ReportUnobservedError(fmt.Errorf("futureshook: notifying %s about future %q: %w", w.url, info.Name, err))
+	}
+}
+
+func (w *webhook) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func state(err error) string {
+	switch {
+	case err == nil:
+		return "resolved"
+	case errors.Is(err, futures.ErrCanceled):
+		return "canceled"
+	case errors.Is(err, futures.ErrTimeout):
+		return "timeout"
+	default:
+		return "failed"
+	}
+}
+
+// limit stops a backoff policy after a number of attempts.
+type limit struct {
+	futures.BackoffPolicy
+	attempts int
+}
+
+func (l limit) Delay(attempt int) time.Duration {
+	if attempt >= l.attempts {
+		return -1
+	}
+	return l.BackoffPolicy.Delay(attempt)
+}
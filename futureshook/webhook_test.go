@@ -0,0 +1,139 @@
+package futureshook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futureshook"
+)
+
+// captureUnobserved collects errors reported to the unobserved-error
+// handler until the test ends.
+func captureUnobserved(t *testing.T) <-chan error {
+	errs := make(chan error, 10)
+	futures.SetUnobservedErrorHandler(func(err error) { errs <- err })
+	t.Cleanup(func() { futures.SetUnobservedErrorHandler(nil) })
+	return errs
+}
+
+var noDelay = futureshook.WithBackoff(futures.ConstantBackoff(0))
+
+func TestWebhookRetriesFlakyEndpoint(t *testing.T) {
+	errs := captureUnobserved(t)
+	var calls atomic.Int32
+	got := make(chan futureshook.Notification, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+			return
+		}
+		var n futureshook.Notification
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			t.Errorf("decoding notification: %v", err)
+		}
+		got <- n
+	}))
+	defer srv.Close()
+
+	f := futures.New(func(ctx context.Context) (int, error) {
+		return 0, errors.New("job failed")
+	}, futures.WithName("nightly-job"), futures.WithGoroutineLabel("jobs"),
+		futureshook.WithWebhook(srv.URL, srv.Client(), noDelay))
+	f.Wait(context.Background())
+
+	select {
+	case n := <-got:
+		if n.Name != "nightly-job" || n.State != "failed" || n.Error != "job failed" || n.Labels["future"] != "jobs" {
+			t.Errorf("notification = %+v", n)
+		}
+	case err := <-errs:
+		t.Fatalf("notification failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification")
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("%d requests, want 3", n)
+	}
+}
+
+func TestWebhookStates(t *testing.T) {
+	got := make(chan futureshook.Notification, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n futureshook.Notification
+		json.NewDecoder(r.Body).Decode(&n)
+		got <- n
+	}))
+	defer srv.Close()
+	hook := futureshook.WithWebhook(srv.URL, nil)
+
+	futures.New(func(ctx context.Context) (int, error) { return 1, nil }, hook).Get()
+	if n := <-got; n.State != "resolved" || n.Error != "" {
+		t.Errorf("resolved future: notification = %+v", n)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	}, hook)
+	f.Cancel()
+	if n := <-got; n.State != "canceled" {
+		t.Errorf("canceled future: notification = %+v", n)
+	}
+}
+
+func TestWebhookGivesUp(t *testing.T) {
+	errs := captureUnobserved(t)
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "down", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	futures.New(func(ctx context.Context) (int, error) { return 1, nil },
+		futureshook.WithWebhook(srv.URL, nil, noDelay, futureshook.WithAttempts(2))).Get()
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Fatal("nil error reported")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("failed notification was not reported")
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("%d requests, want 2", n)
+	}
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	errs := captureUnobserved(t)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	futures.New(func(ctx context.Context) (int, error) { return 1, nil },
+		futureshook.WithWebhook(srv.URL, nil, futureshook.WithNotifyTimeout(50*time.Millisecond))).Get()
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("reported error = %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the notification timeout did not apply")
+	}
+}
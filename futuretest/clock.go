@@ -0,0 +1,132 @@
+package futuretest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+// FakeClock is a futures.Clock whose time only moves when told to.
+//
+// Functions scheduled with AfterFunc run synchronously on the goroutine
+// that calls Advance, in the order of their due time. This makes timeouts
+// and delays fully deterministic.
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	seq    int
+	timers []*fakeTimer
+}
+
+var _ futures.Clock = (*FakeClock)(nil)
+
+// NewFakeClock returns a FakeClock set to start.
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the fake current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc schedules f to be called once the clock has been advanced by
+// at least d. If d is not positive, f is called before AfterFunc returns.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) futures.Timer {
+	c.mu.Lock()
+	t := &fakeTimer{clock: c, when: c.now.Add(d), seq: c.seq, f: f}
+	c.seq++
+	if d <= 0 {
+		c.mu.Unlock()
+		f()
+		return t
+	}
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	c.mu.Unlock()
+	return t
+}
+
+// Advance moves the clock forward by d and runs all functions that became
+// due, including those scheduled by the functions themselves.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	for {
+		t := c.next(end)
+		if t == nil {
+			break
+		}
+		c.now = t.when
+		c.mu.Unlock()
+		t.f()
+		c.mu.Lock()
+	}
+	c.now = end
+	c.mu.Unlock()
+}
+
+// next removes and returns the earliest timer due at or before end.
+// c.mu must be held.
+func (c *FakeClock) next(end time.Time) *fakeTimer {
+	if len(c.timers) == 0 {
+		return nil
+	}
+	sort.Slice(c.timers, func(i, j int) bool {
+		a, b := c.timers[i], c.timers[j]
+		if a.when.Equal(b.when) {
+			return a.seq < b.seq
+		}
+		return a.when.Before(b.when)
+	})
+	t := c.timers[0]
+	if t.when.After(end) {
+		return nil
+	}
+	c.timers = c.timers[1:]
+	return t
+}
+
+// Pending returns the number of scheduled functions that have not run yet.
+func (c *FakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// BlockUntil blocks until at least n functions are scheduled. Use it to
+// make sure that a goroutine has armed its timer before calling Advance.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	when  time.Time
+	seq   int
+	f     func()
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package futuretest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+// Conformer is the reading side of a future type, as tested by
+// RunConformance. Future and every type that embeds futures.Core
+// implement it.
+type Conformer[T any] interface {
+	Done() <-chan struct{}
+	Get() (T, error)
+	GetWithContext(ctx context.Context) (T, error)
+	Err() error
+}
+
+// RunConformance checks that a future type behaves like futures.Future:
+// it settles once, readers all see the same result without blocking after
+// settlement, and giving up a read leaves the future intact.
+//
+// newFuture returns a pending future of the type under test and a function
+// that settles it. value is a non-zero value to settle futures with.
+func RunConformance[T any](t *testing.T, newFuture func() (f Conformer[T], settle func(T, error)), value T) {
+	t.Run("Pending", func(t *testing.T) {
+		f, settle := newFuture()
+		defer settle(value, nil)
+		if err := f.Err(); err != nil {
+			t.Errorf("Err() = %v on a pending future, want nil", err)
+		}
+		select {
+		case <-f.Done():
+			t.Error("Done is closed on a pending future")
+		default:
+		}
+	})
+
+	t.Run("Resolve", func(t *testing.T) {
+		f, settle := newFuture()
+		settle(value, nil)
+		select {
+		case <-f.Done():
+		default:
+			t.Fatal("Done is not closed after settling")
+		}
+		for i := 0; i < 3; i++ {
+			if v, err := f.Get(); !reflect.DeepEqual(v, value) || err != nil {
+				t.Fatalf("Get #%d = %v, %v; want %v, nil", i, v, err, value)
+			}
+		}
+		if err := f.Err(); err != nil {
+			t.Errorf("Err() = %v after resolving, want nil", err)
+		}
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		f, settle := newFuture()
+		settle(value, errBoom)
+		if _, err := f.Get(); !errors.Is(err, errBoom) {
+			t.Errorf("Get: err = %v, want %v", err, errBoom)
+		}
+		if err := f.Err(); !errors.Is(err, errBoom) {
+			t.Errorf("Err() = %v, want %v", err, errBoom)
+		}
+	})
+
+	t.Run("SettlesOnce", func(t *testing.T) {
+		f, settle := newFuture()
+		settle(value, nil)
+		var zero T
+		settle(zero, errors.New("too late"))
+		if v, err := f.Get(); !reflect.DeepEqual(v, value) || err != nil {
+			t.Errorf("Get = %v, %v after settling twice; want the first result %v, nil", v, err, value)
+		}
+	})
+
+	t.Run("ConcurrentReaders", func(t *testing.T) {
+		f, settle := newFuture()
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if v, err := f.Get(); !reflect.DeepEqual(v, value) || err != nil {
+					t.Errorf("Get = %v, %v; want %v, nil", v, err, value)
+				}
+			}()
+		}
+		settle(value, nil)
+		wg.Wait()
+	})
+
+	t.Run("ConcurrentSettle", func(t *testing.T) {
+		f, settle := newFuture()
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if i%2 == 0 {
+					settle(value, nil)
+				} else {
+					var zero T
+					settle(zero, errors.New("rejected"))
+				}
+			}()
+		}
+		wg.Wait()
+		v1, err1 := f.Get()
+		v2, err2 := f.Get()
+		if !reflect.DeepEqual(v1, v2) || err1 != err2 {
+			t.Errorf("Get returned %v, %v and then %v, %v", v1, err1, v2, err2)
+		}
+	})
+
+	t.Run("GetWithContextGivesUp", func(t *testing.T) {
+		f, settle := newFuture()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := f.GetWithContext(ctx); !errors.Is(err, futures.ErrTimeout) {
+			t.Errorf("GetWithContext: err = %v, want ErrTimeout", err)
+		}
+		settle(value, nil)
+		if v, err := f.GetWithContext(context.Background()); !reflect.DeepEqual(v, value) || err != nil {
+			t.Errorf("GetWithContext after giving up = %v, %v; want %v, nil", v, err, value)
+		}
+	})
+}
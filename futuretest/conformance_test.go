@@ -0,0 +1,42 @@
+package futuretest_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestFutureConformance(t *testing.T) {
+	futuretest.RunConformance(t, func() (futuretest.Conformer[string], func(string, error)) {
+		p := futures.NewPromise[string]()
+		return p.Future(), func(v string, err error) {
+			if err != nil {
+				p.Reject(err)
+				return
+			}
+			p.Resolve(v)
+		}
+	}, "value")
+}
+
+// queryFuture is a custom future type with a method of its own.
+type queryFuture struct {
+	futures.Core[[]string]
+	partial atomic.Int64
+}
+
+func (q *queryFuture) PartialRows() int64 {
+	return q.partial.Load()
+}
+
+func TestCoreConformance(t *testing.T) {
+	futuretest.RunConformance(t, func() (futuretest.Conformer[[]string], func([]string, error)) {
+		q := &queryFuture{}
+		return q, func(rows []string, err error) {
+			q.partial.Store(int64(len(rows)))
+			q.Settle(rows, err)
+		}
+	}, []string{"a", "b"})
+}
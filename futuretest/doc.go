@@ -0,0 +1,3 @@
+// Package futuretest provides helpers for testing code that uses package
+// futures.
+package futuretest
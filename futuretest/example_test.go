@@ -0,0 +1,40 @@
+package futuretest_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func ExampleFakeClock() {
+	clock := futuretest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clock.AfterFunc(2*time.Second, func() { fmt.Println("two seconds") })
+	clock.AfterFunc(time.Second, func() { fmt.Println("one second") })
+
+	fmt.Println("pending:", clock.Pending())
+	clock.Advance(5 * time.Second)
+	fmt.Println("pending:", clock.Pending())
+	fmt.Println(clock.Now().Format(time.TimeOnly))
+	// Output:
+	// pending: 2
+	// one second
+	// two seconds
+	// pending: 0
+	// 00:00:05
+}
+
+func ExampleTestFuture() {
+	tf := futuretest.NewTestFuture[string]().
+		Step(func(string) (string, error) { return "draft", nil }).
+		Step(func(s string) (string, error) { return s + ", reviewed", nil })
+	f := tf.Future()
+
+	tf.Advance()
+	fmt.Println(f.State())
+	tf.Advance()
+	fmt.Println(f.Get())
+	// Output:
+	// unsettled
+	// draft, reviewed <nil>
+}
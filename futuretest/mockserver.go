@@ -0,0 +1,110 @@
+package futuretest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+// MockServer is an HTTP test server whose responses come from futures.
+// Use it to test code that creates futures from HTTP responses.
+//
+// For each expected request, the server awaits the registered Future. If
+// the Future resolves, the server responds with status 200 and the value
+// encoded as JSON. If it fails, the server responds with status 500 and the
+// error message. Requests that match no expectation fail the test.
+type MockServer[T any] struct {
+	*httptest.Server
+
+	t        testing.TB
+	mu       sync.Mutex
+	matchers []*ResponseMatcher[T]
+}
+
+// NewMockServer starts a MockServer that is closed when the test ends.
+func NewMockServer[T any](t testing.TB) *MockServer[T] {
+	s := &MockServer[T]{t: t}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Expect registers an expected request.
+func (s *MockServer[T]) Expect(method, path string) *ResponseMatcher[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := &ResponseMatcher[T]{method: method, path: path}
+	s.matchers = append(s.matchers, m)
+	return m
+}
+
+func (s *MockServer[T]) handle(w http.ResponseWriter, r *http.Request) {
+	m := s.match(r)
+	if m == nil {
+		s.t.Errorf("futuretest: unexpected request %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+		return
+	}
+	m.calls.Add(1)
+	f := m.future()
+	if f == nil {
+		s.t.Errorf("futuretest: no response registered for %s %s", r.Method, r.URL.Path)
+		http.Error(w, "no response registered", http.StatusNotImplemented)
+		return
+	}
+	v, err := f.GetWithContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.t.Errorf("futuretest: encoding response for %s %s: %v", r.Method, r.URL.Path, err)
+	}
+}
+
+func (s *MockServer[T]) match(r *http.Request) *ResponseMatcher[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range s.matchers {
+		if m.method == r.Method && m.path == r.URL.Path {
+			return m
+		}
+	}
+	return nil
+}
+
+// ResponseMatcher is an expected request of a MockServer.
+type ResponseMatcher[T any] struct {
+	method string
+	path   string
+	calls  atomic.Int32
+
+	mu  sync.Mutex
+	fut *futures.Future[T]
+}
+
+// RespondWith makes the server answer matching requests with the outcome
+// of f. Awaiting f starts it if it is lazy.
+func (m *ResponseMatcher[T]) RespondWith(f *futures.Future[T]) *ResponseMatcher[T] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fut = f
+	return m
+}
+
+// Calls returns the number of requests that matched.
+func (m *ResponseMatcher[T]) Calls() int {
+	return int(m.calls.Load())
+}
+
+func (m *ResponseMatcher[T]) future() *futures.Future[T] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.fut
+}
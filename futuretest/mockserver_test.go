@@ -0,0 +1,103 @@
+package futuretest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+type user struct {
+	Name string `json:"name"`
+}
+
+// fetchUser is the kind of code MockServer is meant to test.
+func fetchUser(url string) *futures.Future[user] {
+	return futures.New(func(ctx context.Context) (user, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return user{}, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return user{}, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return user{}, errors.New(strings.TrimSpace(string(body)))
+		}
+		var u user
+		err = json.NewDecoder(resp.Body).Decode(&u)
+		return u, err
+	})
+}
+
+func TestMockServerResolves(t *testing.T) {
+	server := futuretest.NewMockServer[user](t)
+	started := make(chan struct{})
+	m := server.Expect("GET", "/api/user").RespondWith(futures.Lazy(func(ctx context.Context) (user, error) {
+		close(started)
+		return user{Name: "gopher"}, nil
+	}))
+
+	u, err := fetchUser(server.URL + "/api/user").Get()
+	if err != nil || u.Name != "gopher" {
+		t.Fatalf("fetchUser = %+v, %v; want gopher, nil", u, err)
+	}
+	select {
+	case <-started:
+	default:
+		t.Error("the request did not start the registered lazy Future")
+	}
+	if n := m.Calls(); n != 1 {
+		t.Errorf("Calls = %d, want 1", n)
+	}
+}
+
+func TestMockServerRejects(t *testing.T) {
+	server := futuretest.NewMockServer[user](t)
+	server.Expect("GET", "/api/user").RespondWith(futures.Failed[user](errors.New("no such user")))
+
+	_, err := fetchUser(server.URL + "/api/user").Get()
+	if err == nil || err.Error() != "no such user" {
+		t.Fatalf("fetchUser: err = %v, want \"no such user\"", err)
+	}
+}
+
+func TestMockServerUnexpectedRequest(t *testing.T) {
+	ft := &fakeT{TB: t}
+	server := futuretest.NewMockServer[user](ft)
+	resp, err := http.Get(server.URL + "/other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+	if !ft.failed() {
+		t.Error("an unexpected request did not fail the test")
+	}
+}
+
+// fakeT records errors instead of failing the test.
+type fakeT struct {
+	testing.TB
+	errored atomic.Bool
+}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errored.Store(true)
+}
+
+func (f *fakeT) failed() bool {
+	return f.errored.Load()
+}
@@ -0,0 +1,75 @@
+package futuretest
+
+import (
+	"sync"
+
+	"github.com/appliedgo/futures"
+)
+
+// TestFuture is a Future whose computation is a series of steps that only
+// run when a test calls Advance. Like the functions scheduled on a
+// FakeClock, each step runs synchronously on the goroutine that calls
+// Advance, so a test can check the state of the code under test between
+// any two steps without sleeping or racing.
+//
+// A TestFuture starts with the zero value of T. Each step receives the
+// value of the previous one. The Future resolves to the value of the last
+// step once Advance has run it, or fails with the error of the first step
+// that fails.
+type TestFuture[T any] struct {
+	mu      sync.Mutex
+	promise *futures.Promise[T]
+	steps   []func(T) (T, error)
+	next    int
+	value   T
+}
+
+// NewTestFuture returns a suspended TestFuture without steps. The options
+// apply to the Future as in futures.NewPromise.
+func NewTestFuture[T any](opts ...futures.Option) *TestFuture[T] {
+	return &TestFuture[T]{promise: futures.NewPromise[T](opts...)}
+}
+
+// Step appends fn to the steps of tf and returns tf, so that calls can be
+// chained.
+func (tf *TestFuture[T]) Step(fn func(T) (T, error)) *TestFuture[T] {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+	tf.steps = append(tf.steps, fn)
+	return tf
+}
+
+// Future returns the Future of tf. It stays pending until Advance has run
+// the last step or a step has failed.
+func (tf *TestFuture[T]) Future() *futures.Future[T] {
+	return tf.promise.Future()
+}
+
+// Advance runs the next step and settles the Future if that step was the
+// last one or failed. It reports whether a step ran; it does nothing once
+// the Future has settled, including when it was canceled.
+func (tf *TestFuture[T]) Advance() bool {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+	if tf.next == len(tf.steps) || tf.Future().State() != futures.Unsettled {
+		return false
+	}
+	v, err := tf.steps[tf.next](tf.value)
+	tf.next++
+	switch {
+	case err != nil:
+		tf.promise.Reject(err)
+	case tf.next == len(tf.steps):
+		tf.promise.Resolve(v)
+	default:
+		tf.value = v
+	}
+	return true
+}
+
+// Remaining returns the number of steps that Advance has not run yet.
+func (tf *TestFuture[T]) Remaining() int {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+	return len(tf.steps) - tf.next
+}
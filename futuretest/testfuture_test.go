@@ -0,0 +1,77 @@
+package futuretest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestTestFutureAdvancesOneStepAtATime(t *testing.T) {
+	var trace []string
+	tf := futuretest.NewTestFuture[int]().
+		Step(func(v int) (int, error) {
+			trace = append(trace, "fetch")
+			return 2, nil
+		}).
+		Step(func(v int) (int, error) {
+			trace = append(trace, "double")
+			return v * 2, nil
+		}).
+		Step(func(v int) (int, error) {
+			trace = append(trace, "increment")
+			return v + 1, nil
+		})
+	f := tf.Future()
+
+	for i, want := range []string{"fetch", "double", "increment"} {
+		if f.State() != futures.Unsettled {
+			t.Fatalf("before step %d: the Future has settled", i+1)
+		}
+		if !tf.Advance() {
+			t.Fatalf("Advance %d ran no step", i+1)
+		}
+		if len(trace) != i+1 || trace[i] != want {
+			t.Fatalf("after Advance %d: trace = %v, want step %q to have run last", i+1, trace, want)
+		}
+	}
+	if v, err := f.Get(); v != 5 || err != nil {
+		t.Errorf("Get = %d, %v; want 5, nil", v, err)
+	}
+	if tf.Advance() {
+		t.Error("Advance ran a step after the last one")
+	}
+	if n := tf.Remaining(); n != 0 {
+		t.Errorf("Remaining() = %d, want 0", n)
+	}
+}
+
+func TestTestFutureStepFails(t *testing.T) {
+	errBoom := errors.New("boom")
+	called := false
+	tf := futuretest.NewTestFuture[int]().
+		Step(func(int) (int, error) { return 0, errBoom }).
+		Step(func(v int) (int, error) {
+			called = true
+			return v, nil
+		})
+	tf.Advance()
+	if _, err := tf.Future().Get(); err != errBoom {
+		t.Errorf("err = %v, want %v", err, errBoom)
+	}
+	if tf.Advance() || called {
+		t.Error("Advance ran a step after a failure")
+	}
+}
+
+func TestTestFutureCanceled(t *testing.T) {
+	tf := futuretest.NewTestFuture[int]().Step(func(v int) (int, error) { return 1, nil })
+	tf.Future().Cancel()
+	if tf.Advance() {
+		t.Error("Advance ran a step after cancellation")
+	}
+	if n := tf.Remaining(); n != 1 {
+		t.Errorf("Remaining() = %d, want 1", n)
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/appliedgo/futures
 
-go 1.14
+go 1.21
+
+require go.uber.org/goleak v1.3.0
@@ -0,0 +1,49 @@
+package futures
+
+// GroupBy splits s into sub-streams, one per key that classify returns
+// for the values of s. Each value of s goes to the sub-stream of its key.
+//
+// The sub-streams for keys are created right away and returned in a map
+// that GroupBy does not change afterwards. When a value has a key that is
+// not in the map and has not been seen before, GroupBy creates a
+// sub-stream for it and passes it to onNewKey, which runs in the
+// goroutine that routes the values; the value and later values with the
+// same key go to that sub-stream. If onNewKey is nil, values with new keys
+// are discarded.
+//
+// Every sub-stream delivers its values in the order of s. A sub-stream
+// whose consumer falls behind holds up all the others, unless opts give
+// the sub-streams a buffer or an overflow policy. Values for a sub-stream
+// that has been closed are discarded. When s ends, all sub-streams end
+// with the error of s.
+func GroupBy[K comparable, T any](s *Stream[T], classify func(T) K, keys []K, onNewKey func(K, *Stream[T]), opts ...StreamOption) map[K]*Stream[T] {
+	o := newStreamOptions(0, opts)
+	groups := make(map[K]*Stream[T], len(keys))
+	for _, k := range keys {
+		groups[k] = newStream[T](o)
+	}
+	routes := make(map[K]*Stream[T], len(groups))
+	for k, g := range groups {
+		routes[k] = g
+	}
+	go func() {
+		for v := range s.C() {
+			k := classify(v)
+			g, ok := routes[k]
+			if !ok {
+				if onNewKey == nil {
+					continue
+				}
+				g = newStream[T](o)
+				routes[k] = g
+				onNewKey(k, g)
+			}
+			g.send(v)
+		}
+		err := s.Err()
+		for _, g := range routes {
+			g.close(err)
+		}
+	}()
+	return groups
+}
@@ -0,0 +1,64 @@
+package futures_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func parity(n int) string {
+	if n%2 == 0 {
+		return "even"
+	}
+	return "odd"
+}
+
+// collect returns all values of s.
+func collect[T any](s *futures.Stream[T]) []T {
+	var vs []T
+	for v := range s.C() {
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := futures.GroupBy(streamOf(1, 2, 3, 4, 5, 6, 7), parity, []string{"even", "odd"}, nil)
+	var even, odd []int
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); even = collect(groups["even"]) }()
+	go func() { defer wg.Done(); odd = collect(groups["odd"]) }()
+	wg.Wait()
+	if !slices.Equal(even, []int{2, 4, 6}) {
+		t.Errorf("even = %v, want [2 4 6]", even)
+	}
+	if !slices.Equal(odd, []int{1, 3, 5, 7}) {
+		t.Errorf("odd = %v, want [1 3 5 7]", odd)
+	}
+}
+
+func TestGroupByNewKeys(t *testing.T) {
+	type group struct {
+		key string
+		s   *futures.Stream[int]
+	}
+	news := make(chan group, 2)
+	groups := futures.GroupBy(streamOf(1, 2, 3, 4), parity, nil, func(k string, s *futures.Stream[int]) {
+		news <- group{k, s}
+	}, futures.WithResultBuffer(4))
+	if len(groups) != 0 {
+		t.Errorf("GroupBy returned %d groups without keys, want 0", len(groups))
+	}
+	for _, want := range []struct {
+		key string
+		vs  []int
+	}{{"odd", []int{1, 3}}, {"even", []int{2, 4}}} {
+		g := <-news
+		if vs := collect(g.s); g.key != want.key || !slices.Equal(vs, want.vs) {
+			t.Errorf("new group %q: %v, want %q: %v", g.key, vs, want.key, want.vs)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package futures
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+// SettleRecord describes a settlement kept by the history that
+// EnableHistory turns on. It holds no value, only metadata.
+type SettleRecord struct {
+	// Seq numbers the settlements in the order they were recorded,
+	// starting at 1.
+	Seq uint64
+	// Name and Label are the name and goroutine label of the Future.
+	Name  string
+	Label string
+	// Settled is the time of the settlement.
+	Settled time.Time
+	// Duration is the time from the start of the computation to the
+	// settlement. It is zero if the computation had not started.
+	Duration time.Duration
+	// Err is the text of the error the Future failed with, or "" if it
+	// resolved.
+	Err string
+}
+
+// historyRing is a fixed-size ring of settlement records. Writers claim a
+// slot with a single atomic increment and never wait for each other.
+type historyRing struct {
+	next  atomic.Uint64
+	slots []atomic.Pointer[SettleRecord]
+}
+
+var history atomic.Pointer[historyRing]
+
+// EnableHistory keeps records of the last n settlements of all futures,
+// for History to return. Use it to find out after the fact how a
+// slow or failed request went. Calling EnableHistory again discards the
+// records so far; n <= 0 turns the history off, which is the default.
+// While the history is off, recording costs one atomic load per
+// settlement.
+func EnableHistory(n int) {
+	if n <= 0 {
+		history.Store(nil)
+		return
+	}
+	history.Store(&historyRing{slots: make([]atomic.Pointer[SettleRecord], n)})
+}
+
+// History returns the recorded settlements, oldest first. It returns nil
+// if the history is off.
+func History() []SettleRecord {
+	h := history.Load()
+	if h == nil {
+		return nil
+	}
+	recs := make([]SettleRecord, 0, len(h.slots))
+	for i := range h.slots {
+		if r := h.slots[i].Load(); r != nil {
+			recs = append(recs, *r)
+		}
+	}
+	slices.SortFunc(recs, func(a, b SettleRecord) int {
+		switch {
+		case a.Seq < b.Seq:
+			return -1
+		case a.Seq > b.Seq:
+			return 1
+		}
+		return 0
+	})
+	return recs
+}
+
+// WriteHistory writes the recorded settlements to w as a table, oldest
+// first.
+func WriteHistory(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "SEQ\tSETTLED\tNAME\tLABEL\tDURATION\tERROR")
+	for _, r := range History() {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%v\t%s\n",
+			r.Seq, r.Settled.Format(time.RFC3339Nano), r.Name, r.Label, r.Duration, r.Err)
+	}
+	return tw.Flush()
+}
+
+// recordHistory records the settlement of f with the error err in h.
+func (f *Future[T]) recordHistory(h *historyRing, err error) {
+	rec := &SettleRecord{Name: f.name, Label: f.label}
+	f.core.mu.Lock()
+	rec.Settled = f.ended
+	if !f.started.IsZero() {
+		rec.Duration = f.ended.Sub(f.started)
+	}
+	f.core.mu.Unlock()
+	if err != nil {
+		rec.Err = err.Error()
+	}
+	rec.Seq = h.next.Add(1)
+	h.slots[(rec.Seq-1)%uint64(len(h.slots))].Store(rec)
+}
@@ -0,0 +1,88 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestHistory(t *testing.T) {
+	futures.EnableHistory(3)
+	defer futures.EnableHistory(0)
+
+	clock := futuretest.NewFakeClock(time.Now())
+	errBoom := errors.New("boom")
+	for i := 1; i <= 5; i++ {
+		p := futures.NewPromise[int](futures.WithName(fmt.Sprintf("f%d", i)), futures.WithClock(clock))
+		clock.Advance(time.Duration(i) * time.Second)
+		if i == 5 {
+			p.Reject(errBoom)
+		} else {
+			p.Resolve(i)
+		}
+	}
+
+	recs := futures.History()
+	if len(recs) != 3 {
+		t.Fatalf("History has %d records, want 3", len(recs))
+	}
+	for i, r := range recs {
+		if want := fmt.Sprintf("f%d", i+3); r.Name != want || r.Seq != uint64(i+3) {
+			t.Errorf("record %d = %s #%d, want %s #%d", i, r.Name, r.Seq, want, i+3)
+		}
+		if want := time.Duration(i+3) * time.Second; r.Duration != want {
+			t.Errorf("record %d: Duration = %v, want %v", i, r.Duration, want)
+		}
+	}
+	if recs[2].Err != "boom" || recs[1].Err != "" {
+		t.Errorf("errors = %q, %q; want \"\", \"boom\"", recs[1].Err, recs[2].Err)
+	}
+
+	var buf strings.Builder
+	if err := futures.WriteHistory(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.Contains(out, "f5") || !strings.Contains(out, "boom") || strings.Contains(out, "f2") {
+		t.Errorf("WriteHistory does not show the last three settlements:\n%s", out)
+	}
+}
+
+func TestHistoryOff(t *testing.T) {
+	futures.New(func(ctx context.Context) (int, error) { return 1, nil }).Get()
+	if recs := futures.History(); recs != nil {
+		t.Errorf("History() = %v while off, want nil", recs)
+	}
+}
+
+func TestHistoryConcurrent(t *testing.T) {
+	futures.EnableHistory(16)
+	defer futures.EnableHistory(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				futures.New(func(ctx context.Context) (int, error) { return 1, nil }).Get()
+				futures.History()
+			}
+		}()
+	}
+	wg.Wait()
+	recs := futures.History()
+	if len(recs) != 16 {
+		t.Fatalf("History has %d records, want 16", len(recs))
+	}
+	for i := 1; i < len(recs); i++ {
+		if recs[i].Seq <= recs[i-1].Seq {
+			t.Fatalf("records are not ordered by Seq: %d after %d", recs[i].Seq, recs[i-1].Seq)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package futures
+
+import (
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// SettleInfo describes how a Future settled. It is passed to the functions
+// registered with WithSettleHook.
+type SettleInfo struct {
+	// Name is the name set by WithName.
+	Name string
+	// Label is the goroutine label set by WithGoroutineLabel.
+	Label string
+	// Err is the error the Future failed with, or nil if it resolved.
+	Err error
+	// Started is the time the computation started. It is zero if the
+	// Future settled before its computation started.
+	Started time.Time
+	// Duration is the time from Started to the settlement.
+	Duration time.Duration
+}
+
+// WithSettleHook registers fn to be called when the Future settles, with
+// a description of the settlement but not the value. fn runs on the
+// goroutine that settles the Future, which may be the computation or a
+// goroutine calling Cancel; it must not block. Start a goroutine for
+// anything slow.
+//
+// WithSettleHook can be given more than once; the functions are called in
+// order. A panic in fn is reported to the unobserved-error handler.
+func WithSettleHook(fn func(SettleInfo)) Option {
+	return func(o *options) {
+		o.hooks = append(o.hooks, fn)
+	}
+}
+
+// runHooks calls the settle hooks of f.
+func (f *Future[T]) runHooks(err error) {
+	info := SettleInfo{Name: f.name, Label: f.label, Err: err}
+	f.core.mu.Lock()
+	info.Started = f.started
+	if !info.Started.IsZero() {
+		info.Duration = f.ended.Sub(info.Started)
+	}
+	f.core.mu.Unlock()
+	for _, h := range f.hooks {
+		callHook(h, info)
+	}
+}
+
+func callHook(h func(SettleInfo), info SettleInfo) {
+	defer func() {
+		if r := recover(); r != nil {
+			ReportUnobservedError(fmt.Errorf("futures: settle hook of future %q panicked: %v", info.Name, r))
+		}
+	}()
+	h(info)
+}
+
+var unobservedHandler atomic.Pointer[func(error)]
+
+// SetUnobservedErrorHandler replaces the handler for errors that occur
+// where no caller can receive them, such as a failing settle hook. The
+// default handler logs an error; a nil h restores it.
+func SetUnobservedErrorHandler(h func(error)) {
+	if h == nil {
+		unobservedHandler.Store(nil)
+		return
+	}
+	unobservedHandler.Store(&h)
+}
+
+// ReportUnobservedError passes err to the unobserved-error handler.
+// Packages that extend futures use it to report errors that have no
+// caller to return to.
+func ReportUnobservedError(err error) {
+	if h := unobservedHandler.Load(); h != nil {
+		(*h)(err)
+		return
+	}
+	logf(slog.LevelError, "futures: unobserved error", "error", err)
+}
@@ -0,0 +1,55 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestWithSettleHook(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	release := make(chan struct{})
+	infos := make(chan futures.SettleInfo, 2)
+	errBoom := errors.New("boom")
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 0, errBoom
+	},
+		futures.WithName("job"), futures.WithGoroutineLabel("batch"), futures.WithClock(clock),
+		futures.WithSettleHook(func(i futures.SettleInfo) { infos <- i }),
+		futures.WithSettleHook(func(i futures.SettleInfo) { infos <- i }))
+
+	clock.Advance(3 * time.Second)
+	close(release)
+	f.Wait(context.Background())
+	for i := 0; i < 2; i++ {
+		info := <-infos
+		if info.Name != "job" || info.Label != "batch" || info.Err != errBoom || info.Duration != 3*time.Second {
+			t.Errorf("hook %d: info = %+v", i, info)
+		}
+	}
+}
+
+func TestSettleHookPanic(t *testing.T) {
+	reported := make(chan error, 1)
+	futures.SetUnobservedErrorHandler(func(err error) { reported <- err })
+	defer futures.SetUnobservedErrorHandler(nil)
+
+	f := futures.New(func(ctx context.Context) (int, error) { return 1, nil },
+		futures.WithSettleHook(func(futures.SettleInfo) { panic("hook failed") }))
+	if v, err := f.Get(); v != 1 || err != nil {
+		t.Fatalf("Get = %d, %v; want 1, nil", v, err)
+	}
+	select {
+	case err := <-reported:
+		if err == nil {
+			t.Error("nil error reported")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the panic was not reported")
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !futures_debug
+
+package futures
+
+// Inspect returns f. Built with the build tag futures_debug, it also
+// records the stack at the call site, and reports that stack if f is
+// dropped unread or fails with ErrTimeout: the misuse report of a dropped
+// Future and the error message of a timed-out one include it. Without the
+// tag, Inspect costs nothing.
+func Inspect[T any](f *Future[T]) *Future[T] {
+	return f
+}
+
+// inspectState holds what Inspect records. It is empty without the build
+// tag futures_debug.
+type inspectState struct{}
+
+// annotate returns err, or, in debug builds, err together with the stack
+// recorded by Inspect.
+func (*inspectState) annotate(err error) error { return err }
+
+// site returns the stack recorded by Inspect for messages, or "".
+func (*inspectState) site() string { return "" }
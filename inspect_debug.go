@@ -0,0 +1,55 @@
+//go:build futures_debug
+
+package futures
+
+import (
+	"errors"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// Inspect returns f and records the stack at the call site. If f is
+// dropped unread or fails with ErrTimeout, the misuse report of the
+// dropped Future and the error message of the timed-out one include that
+// stack. Without the build tag futures_debug, Inspect costs nothing.
+func Inspect[T any](f *Future[T]) *Future[T] {
+	stack := debug.Stack()
+	f.insp.stack.Store(&stack)
+	f.guardDrop()
+	return f
+}
+
+// inspectState holds what Inspect records.
+type inspectState struct {
+	stack atomic.Pointer[[]byte]
+}
+
+// annotate adds the stack recorded by Inspect to a timeout error.
+func (s *inspectState) annotate(err error) error {
+	p := s.stack.Load()
+	if p == nil || err == nil || !errors.Is(err, ErrTimeout) {
+		return err
+	}
+	return &inspectedError{err: err, stack: *p}
+}
+
+// site returns the stack recorded by Inspect for messages, or "".
+func (s *inspectState) site() string {
+	if p := s.stack.Load(); p != nil {
+		return "\nfuture inspected at:\n" + string(*p)
+	}
+	return ""
+}
+
+// inspectedError is an error of an inspected Future with the stack of
+// the call to Inspect.
+type inspectedError struct {
+	err   error
+	stack []byte
+}
+
+func (e *inspectedError) Error() string {
+	return e.err.Error() + "\nfuture inspected at:\n" + string(e.stack)
+}
+
+func (e *inspectedError) Unwrap() error { return e.err }
@@ -0,0 +1,70 @@
+//go:build futures_debug
+
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+// dropInspected creates an inspected Future and lets it go out of scope.
+//
+//go:noinline
+func dropInspected() {
+	futures.Inspect(futures.Completed(1))
+}
+
+func TestInspectDropped(t *testing.T) {
+	misuses := make(chan futures.Misuse, 1)
+	futures.SetMisuseHandler(func(m futures.Misuse) { misuses <- m })
+	defer futures.SetMisuseHandler(nil)
+
+	dropInspected()
+	var m futures.Misuse
+	if !collectUntil(func() bool {
+		select {
+		case m = <-misuses:
+			return true
+		default:
+			return false
+		}
+	}) {
+		t.Fatal("dropping an inspected future was not reported")
+	}
+	if !strings.Contains(m.Message, "dropInspected") {
+		t.Errorf("report does not include the stack of the Inspect call:\n%s", m.Message)
+	}
+}
+
+func TestInspectTimeout(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	p := futures.NewPromise[int](futures.WithClock(clock))
+	f := futures.Inspect(p.Future().WithTimeout(time.Second))
+	clock.Advance(time.Second)
+	_, err := f.Get()
+	if !errors.Is(err, futures.ErrTimeout) {
+		t.Fatalf("err = %v, want ErrTimeout", err)
+	}
+	var te *futures.TimeoutError
+	if !errors.As(err, &te) {
+		t.Errorf("err = %v no longer unwraps to the *TimeoutError", err)
+	}
+	if !strings.Contains(err.Error(), "TestInspectTimeout") {
+		t.Errorf("error message does not include the stack of the Inspect call:\n%s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = futures.Inspect(futures.NewWithContext(ctx, func(ctx context.Context) (int, error) {
+		return 0, ctx.Err()
+	})).Get()
+	if strings.Contains(err.Error(), "inspected at") {
+		t.Errorf("a canceled future reports the stack: %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+package futures_test
+
+import (
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestInspectReturnsFuture(t *testing.T) {
+	f := futures.Completed(1)
+	g := futures.Inspect(f)
+	if g != f {
+		t.Error("Inspect does not return its argument")
+	}
+	g.Get()
+}
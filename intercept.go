@@ -0,0 +1,122 @@
+package futures
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// GlobalInterceptor observes the life of every Future, for cross-cutting
+// concerns such as tracing or auditing. Register it with AddInterceptor.
+//
+// The methods are called synchronously: OnCreate on the goroutine that
+// creates a Future, OnResolve and OnError on the goroutine that settles
+// it. They must not block. The futureID is unique within the process and
+// ties the calls for one Future together; elapsed is the time from
+// creation to settlement. A panic in a method is reported to the
+// unobserved-error handler.
+type GlobalInterceptor interface {
+	OnCreate(futureID, name string)
+	OnResolve(futureID string, elapsed time.Duration)
+	OnError(futureID string, err error, elapsed time.Duration)
+}
+
+// interceptorEntry gives each registration an identity, so that the same
+// interceptor can be registered twice and removed once.
+type interceptorEntry struct {
+	i GlobalInterceptor
+}
+
+var (
+	// interceptors holds the registered interceptors. It is nil while
+	// there are none, so that creating a Future only pays for an atomic
+	// load.
+	interceptors  atomic.Pointer[[]*interceptorEntry]
+	interceptorMu sync.Mutex // serializes AddInterceptor and removal
+	lastFutureID  atomic.Uint64
+)
+
+// AddInterceptor registers i for all futures created from now on,
+// including derived futures such as those returned by Then, and returns
+// a function that removes it again. Futures created before the call are
+// not observed, and removing i does not stop the calls for futures that
+// were created while it was registered.
+func AddInterceptor(i GlobalInterceptor) (remove func()) {
+	e := &interceptorEntry{i: i}
+	updateInterceptors(func(es []*interceptorEntry) []*interceptorEntry {
+		return append(es, e)
+	})
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			updateInterceptors(func(es []*interceptorEntry) []*interceptorEntry {
+				return slices.DeleteFunc(es, func(x *interceptorEntry) bool { return x == e })
+			})
+		})
+	}
+}
+
+func updateInterceptors(update func([]*interceptorEntry) []*interceptorEntry) {
+	interceptorMu.Lock()
+	defer interceptorMu.Unlock()
+	var es []*interceptorEntry
+	if p := interceptors.Load(); p != nil {
+		es = slices.Clone(*p)
+	}
+	es = update(es)
+	if len(es) == 0 {
+		interceptors.Store(nil)
+		return
+	}
+	interceptors.Store(&es)
+}
+
+// interception is what a Future needs to report its settlement to the
+// interceptors that were registered when it was created.
+type interception struct {
+	id      string
+	created time.Time
+	entries []*interceptorEntry
+}
+
+// intercept reports the creation of f to the registered interceptors, if
+// there are any.
+func (f *Future[T]) intercept() {
+	p := interceptors.Load()
+	if p == nil {
+		return
+	}
+	ic := &interception{
+		id:      strconv.FormatUint(lastFutureID.Add(1), 10),
+		created: f.timerClock().Now(),
+		entries: *p,
+	}
+	f.icpt = ic
+	for _, e := range ic.entries {
+		ic.call(func() { e.i.OnCreate(ic.id, f.name) })
+	}
+}
+
+// settled reports a settlement at the time ended.
+func (ic *interception) settled(err error, ended time.Time) {
+	elapsed := ended.Sub(ic.created)
+	for _, e := range ic.entries {
+		if err != nil {
+			ic.call(func() { e.i.OnError(ic.id, err, elapsed) })
+		} else {
+			ic.call(func() { e.i.OnResolve(ic.id, elapsed) })
+		}
+	}
+}
+
+func (ic *interception) call(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			ReportUnobservedError(fmt.Errorf("futures: interceptor panicked for future %s: %v", ic.id, r))
+		}
+	}()
+	fn()
+}
@@ -0,0 +1,132 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+// recordingInterceptor records the calls for futures whose name starts
+// with prefix, so that futures of other tests do not interfere.
+type recordingInterceptor struct {
+	prefix string
+
+	mu       sync.Mutex
+	names    map[string]string // future ID -> name
+	created  []string
+	resolved map[string]time.Duration
+	failed   map[string]error
+}
+
+func newRecordingInterceptor(prefix string) *recordingInterceptor {
+	return &recordingInterceptor{
+		prefix:   prefix,
+		names:    map[string]string{},
+		resolved: map[string]time.Duration{},
+		failed:   map[string]error{},
+	}
+}
+
+func (r *recordingInterceptor) OnCreate(id, name string) {
+	if !strings.HasPrefix(name, r.prefix) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names[id] = name
+	r.created = append(r.created, name)
+}
+
+func (r *recordingInterceptor) OnResolve(id string, elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if name, ok := r.names[id]; ok {
+		r.resolved[name] = elapsed
+	}
+}
+
+func (r *recordingInterceptor) OnError(id string, err error, elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if name, ok := r.names[id]; ok {
+		r.failed[name] = err
+	}
+}
+
+func (r *recordingInterceptor) createdCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.created)
+}
+
+func TestInterceptorsSeeEveryCreation(t *testing.T) {
+	a := newRecordingInterceptor("icpt-")
+	b := newRecordingInterceptor("icpt-")
+	defer futures.AddInterceptor(a)()
+	defer futures.AddInterceptor(b)()
+
+	f1 := futures.New(func(context.Context) (int, error) { return 1, nil }, futures.WithName("icpt-new"))
+	f2 := futures.Lazy(func(context.Context) (int, error) { return 2, nil }, futures.WithName("icpt-lazy"))
+	p := futures.NewPromise[int](futures.WithName("icpt-promise"))
+	f1.Get()
+	f2.Get()
+	p.Resolve(3)
+
+	if n := a.createdCount() + b.createdCount(); n != 6 {
+		t.Errorf("OnCreate was called %d times for 3 futures and 2 interceptors, want 6", n)
+	}
+	for _, r := range []*recordingInterceptor{a, b} {
+		r.mu.Lock()
+		if len(r.resolved) != 3 {
+			t.Errorf("OnResolve was called for %v, want all three futures", r.resolved)
+		}
+		r.mu.Unlock()
+	}
+}
+
+func TestInterceptorElapsedAndErrors(t *testing.T) {
+	r := newRecordingInterceptor("icpt-")
+	defer futures.AddInterceptor(r)()
+
+	clock := futuretest.NewFakeClock(time.Unix(0, 0))
+	ok := futures.NewPromise[int](futures.WithName("icpt-ok"), futures.WithClock(clock))
+	bad := futures.NewPromise[int](futures.WithName("icpt-bad"), futures.WithClock(clock))
+	clock.Advance(3 * time.Second)
+	ok.Resolve(1)
+	errBoom := errors.New("boom")
+	bad.Reject(errBoom)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if d := r.resolved["icpt-ok"]; d != 3*time.Second {
+		t.Errorf("OnResolve elapsed = %v, want 3s", d)
+	}
+	if err := r.failed["icpt-bad"]; err != errBoom {
+		t.Errorf("OnError err = %v, want %v", err, errBoom)
+	}
+}
+
+func TestInterceptorRemoval(t *testing.T) {
+	r := newRecordingInterceptor("icpt-")
+	remove := futures.AddInterceptor(r)
+	before := futures.NewPromise[int](futures.WithName("icpt-before"))
+	remove()
+	remove()
+	futures.NewPromise[int](futures.WithName("icpt-after"))
+	before.Resolve(1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.created) != 1 || r.created[0] != "icpt-before" {
+		t.Errorf("OnCreate saw %v, want only the future created while registered", r.created)
+	}
+	if _, ok := r.resolved["icpt-before"]; !ok {
+		t.Error("OnResolve was not called for a future created while registered")
+	}
+}
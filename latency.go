@@ -0,0 +1,68 @@
+package futures
+
+import (
+	"log/slog"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// latencyOverrides maps names of futures to extra latency. It is nil
+	// while there are no overrides.
+	latencyOverrides atomic.Pointer[map[string]time.Duration]
+	latencyMu        sync.Mutex // serializes SetLatencyOverride
+)
+
+// SetLatencyOverride delays the settlement of every Future named name by
+// extra, for simulating a slow dependency in a test or staging
+// environment. The delay applies between the end of the computation and
+// the moment the readers are woken up, and it is measured on the clock
+// set by WithClock. An extra of 0 or less removes the override.
+//
+// Overrides affect computations that end after the call. They are logged
+// when set, listed by LatencyOverrides, and shown by
+// futuresdebug.HistoryHandler, so that none stays active unnoticed.
+func SetLatencyOverride(name string, extra time.Duration) {
+	latencyMu.Lock()
+	defer latencyMu.Unlock()
+	m := map[string]time.Duration{}
+	if p := latencyOverrides.Load(); p != nil {
+		m = maps.Clone(*p)
+	}
+	if extra > 0 {
+		m[name] = extra
+		logf(slog.LevelWarn, "futures: latency override set", "name", name, "extra", extra)
+	} else {
+		delete(m, name)
+		logf(slog.LevelInfo, "futures: latency override removed", "name", name)
+	}
+	if len(m) == 0 {
+		latencyOverrides.Store(nil)
+		return
+	}
+	latencyOverrides.Store(&m)
+}
+
+// LatencyOverrides returns the active latency overrides by name of the
+// Future.
+func LatencyOverrides() map[string]time.Duration {
+	p := latencyOverrides.Load()
+	if p == nil {
+		return map[string]time.Duration{}
+	}
+	return maps.Clone(*p)
+}
+
+// settleComputed settles f with the result of its computation, after the
+// latency override for its name, if any.
+func (f *Future[T]) settleComputed(v T, err error) {
+	if p := latencyOverrides.Load(); p != nil && f.name != "" {
+		if extra := (*p)[f.name]; extra > 0 {
+			f.afterFunc(extra, func() { f.settle(v, err) })
+			return
+		}
+	}
+	f.settle(v, err)
+}
@@ -0,0 +1,52 @@
+package futures_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestLatencyOverride(t *testing.T) {
+	futures.SetLatencyOverride("checkout-tax", 800*time.Millisecond)
+	defer futures.SetLatencyOverride("checkout-tax", 0)
+
+	clock := futuretest.NewFakeClock(time.Now())
+	f := futures.New(func(ctx context.Context) (int, error) { return 7, nil },
+		futures.WithName("checkout-tax"), futures.WithClock(clock))
+	clock.BlockUntil(1)
+	if f.State() != futures.Unsettled {
+		t.Fatal("the Future settled before the extra latency passed")
+	}
+	clock.Advance(799 * time.Millisecond)
+	if f.State() != futures.Unsettled {
+		t.Fatal("the Future settled before the extra latency passed")
+	}
+	clock.Advance(time.Millisecond)
+	if v, err := f.Get(); v != 7 || err != nil {
+		t.Errorf("Get = %v, %v; want 7, nil", v, err)
+	}
+	if d, _ := f.Duration(); d != 800*time.Millisecond {
+		t.Errorf("Duration() = %v, want the extra latency", d)
+	}
+
+	other := futures.New(func(ctx context.Context) (int, error) { return 1, nil },
+		futures.WithName("checkout"), futures.WithClock(clock))
+	if v, err := other.Get(); v != 1 || err != nil {
+		t.Errorf("other future: Get = %v, %v; want 1, nil", v, err)
+	}
+}
+
+func TestLatencyOverrides(t *testing.T) {
+	futures.SetLatencyOverride("checkout-tax", 800*time.Millisecond)
+	if got := futures.LatencyOverrides(); got["checkout-tax"] != 800*time.Millisecond {
+		t.Errorf("LatencyOverrides() = %v, want checkout-tax: 800ms", got)
+	}
+
+	futures.SetLatencyOverride("checkout-tax", 0)
+	if got := futures.LatencyOverrides(); len(got) != 0 {
+		t.Errorf("LatencyOverrides() = %v after removal, want none", got)
+	}
+}
@@ -0,0 +1,18 @@
+package futures
+
+// Map returns a Future for fn applied to the value of f. If f fails, fn is
+// not called, and the returned Future fails with the error of f, unchanged.
+// If fn panics, the returned Future fails with a *PanicError.
+//
+// Map is for pure transformations such as conversions. Because fn cannot
+// fail and is expected to be cheap, it runs like a ThenInline
+// continuation: on the goroutine that settles f, without starting one of
+// its own. A chain of Maps therefore runs back to back on a single
+// goroutine once the first Future has settled, as if the functions were
+// composed; each intermediate Future is still settled along the way, so
+// that it can be read. Use Then for functions that may block or fail.
+//
+// Canceling the returned Future cancels f, as with Then.
+func Map[A, B any](f *Future[A], fn func(A) B) *Future[B] {
+	return then(f, true, func(v A) (B, error) { return fn(v), nil })
+}
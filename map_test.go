@@ -0,0 +1,105 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestMapConvertsTypes(t *testing.T) {
+	s := futures.Map(futures.Completed(21), func(v int) string { return strconv.Itoa(2 * v) })
+	b := futures.Map(s, func(s string) []byte { return []byte(s) })
+	n := futures.Map(b, func(b []byte) int { return len(b) })
+	if v, err := s.Get(); v != "42" || err != nil {
+		t.Errorf("int to string: Get = %q, %v; want 42, nil", v, err)
+	}
+	if v, err := b.Get(); string(v) != "42" || err != nil {
+		t.Errorf("string to []byte: Get = %q, %v; want 42, nil", v, err)
+	}
+	if v, err := n.Get(); v != 2 || err != nil {
+		t.Errorf("[]byte to int: Get = %d, %v; want 2, nil", v, err)
+	}
+}
+
+func TestMapPropagatesErrors(t *testing.T) {
+	errBoom := errors.New("boom")
+	called := false
+	f := futures.Map(futures.Failed[int](errBoom), func(v int) string {
+		called = true
+		return ""
+	})
+	if _, err := f.Get(); err != errBoom {
+		t.Errorf("err = %v, want %v unchanged", err, errBoom)
+	}
+	if called {
+		t.Error("fn was called for a failed Future")
+	}
+}
+
+func TestMapPanic(t *testing.T) {
+	_, err := futures.Map(futures.Completed(1), func(int) int { panic("bad") }).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) || pe.Value() != "bad" {
+		t.Errorf("err = %v, want a PanicError", err)
+	}
+}
+
+func TestMapRunsOnSettlingGoroutine(t *testing.T) {
+	p := futures.NewPromise[int]()
+	var mapped bool
+	m := futures.Map(futures.Map(p.Future(), func(v int) int { return v + 1 }), func(v int) int {
+		mapped = true
+		return v * 2
+	})
+	p.Resolve(1)
+	// Both functions ran before Resolve returned.
+	if !mapped || m.State() != futures.Resolved {
+		t.Fatal("the Map chain did not run on the resolving goroutine")
+	}
+	if v, _ := m.Get(); v != 4 {
+		t.Errorf("Get = %d, want 4", v)
+	}
+}
+
+func TestMapCancelsUpstream(t *testing.T) {
+	started := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	m := futures.Map(f, func(v int) int { return v })
+	<-started
+	m.Cancel()
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("upstream err = %v, want ErrCanceled", err)
+	}
+}
+
+func benchmarkChain(b *testing.B, step func(*futures.Future[int]) *futures.Future[int]) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p := futures.NewPromise[int]()
+		f := p.Future()
+		for j := 0; j < 5; j++ {
+			f = step(f)
+		}
+		p.Resolve(i)
+		f.Get()
+	}
+}
+
+func BenchmarkMapChain(b *testing.B) {
+	benchmarkChain(b, func(f *futures.Future[int]) *futures.Future[int] {
+		return futures.Map(f, func(v int) int { return v + 1 })
+	})
+}
+
+func BenchmarkThenChain(b *testing.B) {
+	benchmarkChain(b, func(f *futures.Future[int]) *futures.Future[int] {
+		return futures.Then(f, func(v int) (int, error) { return v + 1, nil })
+	})
+}
@@ -0,0 +1,27 @@
+package futures
+
+// MapError returns a Future that resolves like f if f resolves, and fails
+// with fn(err) if f fails with err. Use it to translate low-level errors
+// into errors of your domain; errors.Is and errors.As work inside fn as
+// usual. fn also sees cancellation, so it can translate ErrCanceled and
+// ErrTimeout as well.
+//
+// If fn returns nil, the returned Future resolves to the zero value of T.
+// fn thereby turns the failure into a success, which is rarely what you
+// want; use Recover to provide a value instead. If fn panics, the returned
+// Future fails with a *PanicError.
+func (f *Future[T]) MapError(fn func(error) error) *Future[T] {
+	return chain(f, func(v T, err error) (T, error) {
+		if err == nil {
+			return v, nil
+		}
+		var zero T
+		return catchPanic(func() (T, error) { return zero, fn(err) })
+	})
+}
+
+// MapError is the function form of the MapError method, for symmetry
+// with Map, Then, and Catch.
+func MapError[T any](f *Future[T], fn func(error) error) *Future[T] {
+	return f.MapError(fn)
+}
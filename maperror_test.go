@@ -0,0 +1,88 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+var errConfigMissing = errors.New("configuration missing")
+
+func translate(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return errConfigMissing
+	}
+	return err
+}
+
+func TestMapError(t *testing.T) {
+	if v, err := futures.Completed("cfg").MapError(translate).Get(); v != "cfg" || err != nil {
+		t.Errorf("resolved: Get = %q, %v; want cfg, nil", v, err)
+	}
+
+	pathErr := &fs.PathError{Op: "open", Path: "app.conf", Err: fs.ErrNotExist}
+	if _, err := futures.Failed[string](pathErr).MapError(translate).Get(); err != errConfigMissing {
+		t.Errorf("failed: err = %v, want %v", err, errConfigMissing)
+	}
+
+	v, err := futures.Failed[string](errors.New("ignored")).MapError(func(error) error { return nil }).Get()
+	if v != "" || err != nil {
+		t.Errorf("fn returning nil: Get = %q, %v; want the zero value and nil", v, err)
+	}
+}
+
+var errUserNotFound = errors.New("user not found")
+
+func TestMapErrorFunc(t *testing.T) {
+	called := false
+	v, err := futures.MapError(futures.Completed(1), func(err error) error {
+		called = true
+		return err
+	}).Get()
+	if v != 1 || err != nil || called {
+		t.Errorf("resolved: Get = %d, %v (fn called: %v); want 1, nil without calling fn", v, err, called)
+	}
+
+	// Wrapping keeps the original error reachable through errors.Is.
+	_, err = futures.MapError(futures.Failed[int](fs.ErrNotExist), func(err error) error {
+		return fmt.Errorf("%w: %w", errUserNotFound, err)
+	}).Get()
+	if !errors.Is(err, errUserNotFound) || !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("err = %v, want it to match both the domain and the original error", err)
+	}
+}
+
+func TestMapErrorTranslatesCancellation(t *testing.T) {
+	errAborted := errors.New("request aborted")
+	started := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	mapped := futures.MapError(f, func(err error) error {
+		if errors.Is(err, futures.ErrCanceled) {
+			return errAborted
+		}
+		return err
+	})
+	<-started
+	f.Cancel()
+	if _, err := mapped.Get(); err != errAborted {
+		t.Errorf("err = %v, want %v", err, errAborted)
+	}
+}
+
+func TestMapErrorPanic(t *testing.T) {
+	_, err := futures.MapError(futures.Failed[int](errors.New("boom")), func(error) error {
+		panic("bad translation")
+	}).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) || pe.Value() != "bad translation" {
+		t.Errorf("err = %v, want a PanicError", err)
+	}
+}
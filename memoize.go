@@ -0,0 +1,45 @@
+package futures
+
+import "sync"
+
+// Memoize wraps fn so that it is called at most once. Every call of the
+// returned function returns the same Future: calls made while fn is
+// running wait for it to return, and later calls return right away. Use
+// it for expensive computations that always have the same result, such
+// as loading a configuration file.
+//
+// Unlike Deduplicate, Memoize also keeps a failed result, and canceling
+// the shared Future affects every caller. If fn panics or returns nil,
+// the shared Future fails.
+func Memoize[T any](fn func() *Future[T]) func() *Future[T] {
+	var (
+		once sync.Once
+		f    *Future[T]
+	)
+	return func() *Future[T] {
+		once.Do(func() { f = attemptFuture(fn) })
+		return f
+	}
+}
+
+// MemoizeWithKey is like Memoize, but it calls fn at most once per key
+// and returns the same Future for every call with that key. Keys are
+// never evicted, so the number of keys should be bounded.
+func MemoizeWithKey[K comparable, T any](fn func(K) *Future[T]) func(K) *Future[T] {
+	var memo sync.Map // K -> *memoEntry[T]
+	return func(k K) *Future[T] {
+		v, ok := memo.Load(k)
+		if !ok {
+			v, _ = memo.LoadOrStore(k, &memoEntry[T]{})
+		}
+		e := v.(*memoEntry[T])
+		e.once.Do(func() { e.f = attemptFuture(func() *Future[T] { return fn(k) }) })
+		return e.f
+	}
+}
+
+// memoEntry holds the Future of one key of MemoizeWithKey.
+type memoEntry[T any] struct {
+	once sync.Once
+	f    *Future[T]
+}
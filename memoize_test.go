@@ -0,0 +1,124 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestMemoize(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := futures.Memoize(func() *futures.Future[string] {
+		calls.Add(1)
+		return futures.New(func(context.Context) (string, error) {
+			<-release
+			return "config", nil
+		})
+	})
+
+	first := load()
+	var wg sync.WaitGroup
+	got := make([]*futures.Future[string], 10)
+	for i := range got {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[i] = load()
+		}()
+	}
+	wg.Wait()
+	for i, f := range got {
+		if f != first {
+			t.Errorf("call %d before resolution returned a different Future", i)
+		}
+	}
+	close(release)
+	if v, err := first.Get(); v != "config" || err != nil {
+		t.Errorf("Get() = %q, %v, want config, nil", v, err)
+	}
+	if load() != first {
+		t.Error("call after resolution returned a different Future")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fn called %d times, want 1", n)
+	}
+}
+
+func TestMemoizeKeepsFailure(t *testing.T) {
+	errLoad := errors.New("load failed")
+	calls := 0
+	load := futures.Memoize(func() *futures.Future[int] {
+		calls++
+		return futures.Failed[int](errLoad)
+	})
+	for i := 0; i < 3; i++ {
+		if _, err := load().Get(); !errors.Is(err, errLoad) {
+			t.Errorf("call %d: err = %v, want %v", i, err, errLoad)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+}
+
+func TestMemoizePanic(t *testing.T) {
+	load := futures.Memoize(func() *futures.Future[int] { panic("boom") })
+	var perr *futures.PanicError
+	if _, err := load().Get(); !errors.As(err, &perr) {
+		t.Errorf("err = %v, want a *PanicError", err)
+	}
+	if load() != load() {
+		t.Error("calls after a panic returned different futures")
+	}
+}
+
+func TestMemoizeWithKey(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	fetch := futures.MemoizeWithKey(func(k string) *futures.Future[int] {
+		mu.Lock()
+		calls[k]++
+		mu.Unlock()
+		return futures.New(func(context.Context) (int, error) { return len(k), nil })
+	})
+
+	keys := []string{"a", "bb", "ccc"}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		k := keys[i%len(keys)]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fetch(k)
+		}()
+	}
+	wg.Wait()
+	for _, k := range keys {
+		f := fetch(k)
+		if fetch(k) != f {
+			t.Errorf("key %q: calls returned different futures", k)
+		}
+		if v, err := f.Get(); v != len(k) || err != nil {
+			t.Errorf("key %q: Get() = %d, %v, want %d, nil", k, v, err, len(k))
+		}
+		if calls[k] != 1 {
+			t.Errorf("key %q: fn called %d times, want 1", k, calls[k])
+		}
+	}
+	if fetch("a") == fetch("bb") {
+		t.Error("different keys returned the same Future")
+	}
+}
+
+func TestMemoizeWithKeyNil(t *testing.T) {
+	fetch := futures.MemoizeWithKey(func(int) *futures.Future[int] { return nil })
+	if _, err := fetch(1).Get(); err == nil {
+		t.Error("a nil Future did not fail")
+	}
+}
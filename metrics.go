@@ -0,0 +1,43 @@
+package futures
+
+import "sync/atomic"
+
+// MetricsSink receives metrics from the package. Adapt it to the metrics
+// library of your choice. Its methods may be called concurrently.
+type MetricsSink interface {
+	// Count adds delta to the counter name.
+	Count(name string, delta int64)
+	// Gauge sets the gauge name to value.
+	Gauge(name string, value float64)
+	// Observe records value in the histogram name.
+	Observe(name string, value float64)
+}
+
+// Metrics reported to the sink set by SetMetricsSink.
+const (
+	// MetricFuturesUnread counts the futures guarded by EnsureNoDrop that
+	// were garbage-collected without being read.
+	MetricFuturesUnread = "futures_unread_total"
+)
+
+var metricsSink atomic.Pointer[MetricsSink]
+
+// SetMetricsSink sets the sink for the metrics that the package reports on
+// its own, as opposed to the metrics of types like PoolWithMetrics that
+// take a sink of their own. A nil s turns the metrics off, which is the
+// default. PackageOptions.DisableMetrics pauses them without unsetting s.
+func SetMetricsSink(s MetricsSink) {
+	if s == nil {
+		metricsSink.Store(nil)
+		return
+	}
+	metricsSink.Store(&s)
+}
+
+// count adds delta to the counter name of the package-level sink, unless
+// the package options disable metrics.
+func count(name string, delta int64) {
+	if s := metricsSink.Load(); s != nil && !pkgOptions.Load().DisableMetrics {
+		(*s).Count(name, delta)
+	}
+}
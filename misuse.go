@@ -0,0 +1,151 @@
+package futures
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync/atomic"
+)
+
+// Misuse describes an incorrect use of the package that was detected at
+// runtime.
+type Misuse struct {
+	// Future is the name of the Future involved, if it has one.
+	Future string
+	// Message describes the problem.
+	Message string
+}
+
+func (m Misuse) String() string {
+	if m.Future == "" {
+		return m.Message
+	}
+	return fmt.Sprintf("future %q: %s", m.Future, m.Message)
+}
+
+// MisuseHandler is called whenever a Misuse is detected.
+type MisuseHandler func(Misuse)
+
+var misuseHandler atomic.Pointer[MisuseHandler]
+
+// SetMisuseHandler replaces the handler for detected misuses. The default
+// handler logs a warning; a nil h restores it.
+func SetMisuseHandler(h MisuseHandler) {
+	if h == nil {
+		misuseHandler.Store(nil)
+		return
+	}
+	misuseHandler.Store(&h)
+}
+
+func reportMisuse(m Misuse) {
+	if h := misuseHandler.Load(); h != nil {
+		(*h)(m)
+		return
+	}
+	logf(slog.LevelWarn, "futures: misuse detected", "future", m.Future, "message", m.Message)
+}
+
+// contextCheckDepth is the maximum depth of the context check; zero
+// disables it.
+var contextCheckDepth atomic.Int32
+
+// maxCheckedElems limits how many elements of a slice or array the context
+// check looks at.
+const maxCheckedElems = 16
+
+// CheckResolvedContexts turns on a debug check that inspects every value a
+// computation resolves to for contexts stored inside it. Each context found
+// is reported to the misuse handler with its field path. Storing the
+// context of a computation in its result keeps the whole request alive and
+// invites using a canceled context later.
+//
+// The check walks structs, pointers, interfaces, and the first few elements
+// of slices and arrays, up to maxDepth levels deep. Maps are not inspected.
+// A maxDepth of 0 turns the check off, which is the default.
+func CheckResolvedContexts(maxDepth int) {
+	contextCheckDepth.Store(int32(maxDepth))
+}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// checkContexts reports any context found in v, if the check is enabled.
+// It is generic so that the common case of a disabled check does not
+// convert v to an interface.
+func checkContexts[T any](name string, v T) {
+	depth := int(contextCheckDepth.Load())
+	if depth <= 0 {
+		return
+	}
+	visited := map[uintptr]bool{}
+	walkContexts(reflect.ValueOf(&v).Elem(), "value", depth, visited, func(path string) {
+		reportMisuse(Misuse{
+			Future:  name,
+			Message: "resolved value contains a context.Context at " + path,
+		})
+	})
+}
+
+// walkContexts calls found for each context in v and reports whether it
+// found any.
+func walkContexts(v reflect.Value, path string, depth int, visited map[uintptr]bool, found func(path string)) bool {
+	if depth < 0 || !v.IsValid() {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return false
+		}
+		return walkContexts(v.Elem(), path, depth, visited, found)
+	case reflect.Pointer:
+		if v.IsNil() || visited[v.Pointer()] {
+			return false
+		}
+		visited[v.Pointer()] = true
+	}
+	if isContext(v.Type()) {
+		found(path)
+		return true
+	}
+	hit := false
+	switch v.Kind() {
+	case reflect.Pointer:
+		hit = walkContexts(v.Elem(), path, depth, visited, found)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if walkContexts(v.Field(i), path+"."+v.Type().Field(i).Name, depth-1, visited, found) {
+				hit = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len() && i < maxCheckedElems; i++ {
+			if walkContexts(v.Index(i), fmt.Sprintf("%s[%d]", path, i), depth-1, visited, found) {
+				hit = true
+			}
+		}
+	}
+	if !hit && v.Type().Implements(contextType) {
+		// A context type of its own that holds no other context.
+		found(path)
+		return true
+	}
+	return hit
+}
+
+// isContext reports whether values of t are contexts to be reported as a
+// whole. A struct that embeds a context implements context.Context too;
+// such structs, and pointers to them, are looked into instead, so that
+// the report names the embedded field. The context types of the standard
+// library are always reported as a whole.
+func isContext(t reflect.Type) bool {
+	if !t.Implements(contextType) {
+		return false
+	}
+	s := t
+	if s.Kind() == reflect.Pointer {
+		s = s.Elem()
+	}
+	return s.Kind() != reflect.Struct || s.PkgPath() == "context"
+}
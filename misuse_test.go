@@ -0,0 +1,115 @@
+package futures_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+// recordMisuses enables the context check and collects the reported
+// misuses until the test ends.
+func recordMisuses(t *testing.T, depth int) func() []futures.Misuse {
+	t.Helper()
+	var mu sync.Mutex
+	var got []futures.Misuse
+	futures.SetMisuseHandler(func(m futures.Misuse) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, m)
+	})
+	futures.CheckResolvedContexts(depth)
+	t.Cleanup(func() {
+		futures.CheckResolvedContexts(0)
+		futures.SetMisuseHandler(nil)
+	})
+	return func() []futures.Misuse {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(got)
+	}
+}
+
+type withContext struct {
+	Name string
+	Ctx  context.Context
+}
+
+type embedsContext struct {
+	context.Context
+	ID int
+}
+
+type nested struct {
+	Inner struct {
+		Items []withContext
+	}
+}
+
+func TestCheckResolvedContexts(t *testing.T) {
+	tests := []struct {
+		name string
+		get  func() (any, error)
+		want []string
+	}{
+		{"plain", func() (any, error) {
+			return futures.New(func(ctx context.Context) (int, error) { return 1, nil }).Get()
+		}, nil},
+		{"context", func() (any, error) {
+			return futures.New(func(ctx context.Context) (context.Context, error) { return ctx, nil }).Get()
+		}, []string{"value"}},
+		{"field", func() (any, error) {
+			return futures.New(func(ctx context.Context) (withContext, error) { return withContext{Ctx: ctx}, nil }).Get()
+		}, []string{"value.Ctx"}},
+		{"embedded", func() (any, error) {
+			return futures.New(func(ctx context.Context) (embedsContext, error) { return embedsContext{Context: ctx}, nil }).Get()
+		}, []string{"value.Context"}},
+		{"embedded pointer", func() (any, error) {
+			return futures.New(func(ctx context.Context) (*embedsContext, error) { return &embedsContext{Context: ctx}, nil }).Get()
+		}, []string{"value.Context"}},
+		{"nested", func() (any, error) {
+			return futures.New(func(ctx context.Context) (nested, error) {
+				var n nested
+				n.Inner.Items = []withContext{{}, {Ctx: ctx}}
+				return n, nil
+			}).Get()
+		}, []string{"value.Inner.Items[1].Ctx"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			misuses := recordMisuses(t, 5)
+			if _, err := tt.get(); err != nil {
+				t.Fatal(err)
+			}
+			var paths []string
+			for _, m := range misuses() {
+				paths = append(paths, m.Message[len("resolved value contains a context.Context at "):])
+			}
+			if !slices.Equal(paths, tt.want) {
+				t.Errorf("reported paths = %q, want %q", paths, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckResolvedContextsDepth(t *testing.T) {
+	misuses := recordMisuses(t, 1)
+	futures.New(func(ctx context.Context) (nested, error) {
+		var n nested
+		n.Inner.Items = []withContext{{Ctx: ctx}}
+		return n, nil
+	}).Get()
+	if got := misuses(); len(got) != 0 {
+		t.Errorf("misuses beyond the maximum depth reported: %v", got)
+	}
+}
+
+func TestCheckResolvedContextsDisabled(t *testing.T) {
+	misuses := recordMisuses(t, 0)
+	futures.New(func(ctx context.Context) (context.Context, error) { return ctx, nil }).Get()
+	if got := misuses(); len(got) != 0 {
+		t.Errorf("disabled check reported %v", got)
+	}
+}
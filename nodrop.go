@@ -0,0 +1,31 @@
+package futures
+
+import "runtime"
+
+// EnsureNoDrop guards against futures whose result nobody reads, which
+// usually means that an error goes unnoticed. If f is garbage-collected
+// before Get, GetWithContext, Wait, or Done has been called on it,
+// EnsureNoDrop reports this to the misuse handler and increments the
+// futures_unread_total metric of the sink set by SetMetricsSink.
+//
+// The check relies on a finalizer, so it happens at some point after f has
+// become unreachable, if at all. It returns f for convenience. Futures
+// allocated from an Arena are returned unguarded.
+func EnsureNoDrop[T any](f *Future[T]) *Future[T] {
+	f.guardDrop()
+	return f
+}
+
+// guardDrop sets up the finalizer of EnsureNoDrop, once.
+func (f *Future[T]) guardDrop() {
+	if f.fromArena || !f.dropGuarded.CompareAndSwap(false, true) {
+		return
+	}
+	runtime.SetFinalizer(f, func(f *Future[T]) {
+		if f.WasAwaited() {
+			return
+		}
+		count(MetricFuturesUnread, 1)
+		reportMisuse(Misuse{Future: f.name, Message: "future was garbage-collected without being read" + f.insp.site()})
+	})
+}
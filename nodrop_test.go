@@ -0,0 +1,71 @@
+package futures_test
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+// dropFuture creates a guarded Future and lets it go out of scope. If
+// read is not nil, it reads the Future with it first.
+//
+//go:noinline
+func dropFuture(read func(*futures.Future[int])) {
+	f := futures.EnsureNoDrop(futures.Completed(1))
+	if read != nil {
+		read(f)
+	}
+}
+
+// collectUntil runs the garbage collector until cond holds or a second
+// has passed.
+func collectUntil(cond func() bool) bool {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		runtime.GC()
+		if cond() {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+func TestEnsureNoDrop(t *testing.T) {
+	sink := newMockSink()
+	futures.SetMetricsSink(sink)
+	defer futures.SetMetricsSink(nil)
+	var misuses atomic.Int32
+	futures.SetMisuseHandler(func(futures.Misuse) { misuses.Add(1) })
+	defer futures.SetMisuseHandler(nil)
+
+	dropFuture(nil)
+	if !collectUntil(func() bool { return sink.counter(futures.MetricFuturesUnread) == 1 }) {
+		t.Fatalf("%s = %d after collecting an unread future, want 1",
+			futures.MetricFuturesUnread, sink.counter(futures.MetricFuturesUnread))
+	}
+	if n := misuses.Load(); n != 1 {
+		t.Errorf("%d misuses reported, want 1", n)
+	}
+}
+
+func TestEnsureNoDropRead(t *testing.T) {
+	sink := newMockSink()
+	futures.SetMetricsSink(sink)
+	defer futures.SetMetricsSink(nil)
+	futures.SetMisuseHandler(func(futures.Misuse) {})
+	defer futures.SetMisuseHandler(nil)
+
+	// The unread future after the read ones shows when finalizers have
+	// run.
+	dropFuture(func(f *futures.Future[int]) { f.Get() })
+	dropFuture(func(f *futures.Future[int]) { f.TryGet() })
+	dropFuture(nil)
+	collectUntil(func() bool { return sink.counter(futures.MetricFuturesUnread) > 0 })
+	if n := sink.counter(futures.MetricFuturesUnread); n != 1 {
+		t.Errorf("%s = %d, want 1 for the unread future only", futures.MetricFuturesUnread, n)
+	}
+}
@@ -0,0 +1,111 @@
+package futures
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// onceFuture is a memoized future built on sync.OnceValues, the
+// alternative to Future that the benchmarks below compare against.
+//
+// Future stays on Core and its closed channel: a Future must be settled
+// from outside its computation, by Cancel and by the context, which
+// sync.OnceValues cannot do, and Done must work in select statements.
+// The benchmarks show that readers blocked on a pending future cost about
+// the same either way; the extra allocations of Future come from the
+// context and the cancellation set up by New, not from the channel. Reads
+// of a settled future were much slower with the channel, which led to the
+// lock-free fast path of Core.
+type onceFuture[T any] struct {
+	get  func() (T, error)
+	done chan struct{}
+}
+
+func newOnceFuture[T any](fn func() (T, error)) *onceFuture[T] {
+	f := &onceFuture[T]{done: make(chan struct{})}
+	f.get = sync.OnceValues(func() (T, error) {
+		defer close(f.done)
+		return fn()
+	})
+	go f.get()
+	return f
+}
+
+func (f *onceFuture[T]) Get() (T, error) {
+	return f.get()
+}
+
+var readerCounts = []int{1, 10, 1000}
+
+// benchmarkReaders measures how long it takes until readers blocked on a
+// pending future have all received its result.
+func benchmarkReaders(b *testing.B, readers int, start func(release <-chan struct{}) func() (int, error)) {
+	b.ReportAllocs()
+	var wg sync.WaitGroup
+	for i := 0; i < b.N; i++ {
+		release := make(chan struct{})
+		get := start(release)
+		wg.Add(readers)
+		for r := 0; r < readers; r++ {
+			go func() {
+				defer wg.Done()
+				get()
+			}()
+		}
+		close(release)
+		wg.Wait()
+	}
+}
+
+func BenchmarkGetChannel(b *testing.B) {
+	for _, n := range readerCounts {
+		b.Run(fmt.Sprintf("readers=%d", n), func(b *testing.B) {
+			benchmarkReaders(b, n, func(release <-chan struct{}) func() (int, error) {
+				return New(func(ctx context.Context) (int, error) {
+					<-release
+					return 1, nil
+				}).Get
+			})
+		})
+	}
+}
+
+func BenchmarkGetOnceValues(b *testing.B) {
+	for _, n := range readerCounts {
+		b.Run(fmt.Sprintf("readers=%d", n), func(b *testing.B) {
+			benchmarkReaders(b, n, func(release <-chan struct{}) func() (int, error) {
+				return newOnceFuture(func() (int, error) {
+					<-release
+					return 1, nil
+				}).Get
+			})
+		})
+	}
+}
+
+// The Settled benchmarks measure reading a future that has settled
+// already, the common case for memoized results.
+
+func BenchmarkGetSettledChannel(b *testing.B) {
+	f := New(func(ctx context.Context) (int, error) { return 1, nil })
+	f.Get()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			f.Get()
+		}
+	})
+}
+
+func BenchmarkGetSettledOnceValues(b *testing.B) {
+	f := newOnceFuture(func() (int, error) { return 1, nil })
+	<-f.done
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			f.Get()
+		}
+	})
+}
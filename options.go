@@ -0,0 +1,109 @@
+package futures
+
+// Option configures a Future created by New or NewWithContext.
+type Option func(*options)
+
+type options struct {
+	executor Executor
+	clock    Clock
+	name     string
+	label    string
+	hooks    []func(SettleInfo)
+	arena    *Arena
+
+	requireNonZero bool
+	repanic        bool
+	// values are the context values set by WithContextValue, in order.
+	values []any
+
+	panicHandler func(*PanicError)
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		executor: GoroutineExecutor,
+		clock:    SystemClock,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithExecutor sets the Executor that runs the computation.
+// The default is GoroutineExecutor.
+func WithExecutor(e Executor) Option {
+	return func(o *options) {
+		o.executor = e
+	}
+}
+
+// WithClock sets the Clock for functions that wait or take timestamps,
+// such as the delays of RetryWithBackoff and the timestamps of
+// NewDiagnostic. The default is SystemClock.
+func WithClock(c Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// WithName gives the Future a name that shows up in diagnostics and
+// error reports. The name does not label the goroutine of the computation;
+// use WithGoroutineLabel for that.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithGoroutineLabel runs the computation with the pprof label
+// future=label, so that its goroutine can be told apart in CPU profiles,
+// goroutine dumps, and execution traces. Goroutines started by the
+// computation inherit the label.
+//
+// WithGoroutineLabel and WithName are independent. To have a named Future
+// show up in profiles under its name, pass the name to both.
+func WithGoroutineLabel(label string) Option {
+	return func(o *options) {
+		o.label = label
+	}
+}
+
+// WithRequireNonZero makes the Future fail with ErrZeroValue instead of
+// resolving successfully to the zero value of T. Use it where a nil
+// pointer, nil interface, or empty struct cannot be a valid result, so
+// that readers need not check for (nil, nil).
+func WithRequireNonZero() Option {
+	return func(o *options) {
+		o.requireNonZero = true
+	}
+}
+
+// WithRepanic makes the first Get, GetWithContext, or Wait that reads the
+// *PanicError of a panicked computation panic in turn, so that the panic
+// reaches the reader as it would in synchronous code. See Future.Get.
+func WithRepanic() Option {
+	return func(o *options) {
+		o.repanic = true
+	}
+}
+
+// WithContextValue adds the value val under key to the context of the
+// computation, for instrumentation such as tracing or logging that reads
+// it from there. Give WithContextValue more than once to add several
+// values; for the same key, the last one wins. The same rules apply as for
+// context.WithValue.
+func WithContextValue(key, val any) Option {
+	return func(o *options) {
+		o.values = append(o.values, key, val)
+	}
+}
+
+// WithPanicHandler sets the function that receives panics of the
+// callbacks registered with OnSuccess and OnFailure. By default, they are
+// passed to the unobserved-error handler.
+func WithPanicHandler(h func(*PanicError)) Option {
+	return func(o *options) {
+		o.panicHandler = h
+	}
+}
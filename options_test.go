@@ -0,0 +1,55 @@
+package futures_test
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"strings"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestWithGoroutineLabel(t *testing.T) {
+	running := make(chan struct{})
+	release := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (string, error) {
+		label, _ := pprof.Label(ctx, "future")
+		close(running)
+		<-release
+		return label, nil
+	}, futures.WithGoroutineLabel("fetch-user"), futures.WithName("user"))
+
+	<-running
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	if !strings.Contains(buf.String(), `"future":"fetch-user"`) {
+		t.Errorf("goroutine dump does not contain the label:\n%s", buf.String())
+	}
+	if label, _ := f.Get(); label != "fetch-user" {
+		t.Errorf("label in context = %q, want fetch-user", label)
+	}
+	if f.Name() != "user" {
+		t.Errorf("Name = %q, want user", f.Name())
+	}
+}
+
+type traceKey struct{}
+type spanKey struct{}
+
+func TestWithContextValue(t *testing.T) {
+	f := futures.New(func(ctx context.Context) ([2]any, error) {
+		return [2]any{ctx.Value(traceKey{}), ctx.Value(spanKey{})}, nil
+	},
+		futures.WithContextValue(traceKey{}, "trace-1"),
+		futures.WithContextValue(spanKey{}, "span-1"),
+		futures.WithContextValue(spanKey{}, "span-2"),
+	)
+	got, err := f.Get()
+	if err != nil || got[0] != "trace-1" || got[1] != "span-2" {
+		t.Errorf("ctx values = %v, %v; want trace-1, span-2", got, err)
+	}
+}
@@ -0,0 +1,75 @@
+package futures
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error of a Future whose computation or callback
+// panicked.
+type PanicError struct {
+	value any
+	stack []byte
+}
+
+// newPanicError must be called by the deferred function that recovered v,
+// so that the stack trace includes the frames that panicked.
+func newPanicError(v any) *PanicError {
+	return &PanicError{value: v, stack: debug.Stack()}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("futures: panic: %v", e.value)
+}
+
+// Value returns the value passed to panic.
+func (e *PanicError) Value() any {
+	return e.value
+}
+
+// Stack returns the stack trace of the goroutine at the time of the panic.
+func (e *PanicError) Stack() []byte {
+	return e.stack
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.value.(error)
+	return err
+}
+
+// catchPanic calls fn and turns a panic into a *PanicError.
+func catchPanic[T any](fn func() (T, error)) (v T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			v, err = zero, newPanicError(r)
+		}
+	}()
+	return fn()
+}
+
+// repanicError is the value that a Future created with WithRepanic panics
+// with. Its message includes the stack of the computation, which would be
+// lost otherwise, because the panic happens in the reader's goroutine.
+type repanicError struct {
+	pe *PanicError
+}
+
+func (e *repanicError) Error() string {
+	return e.pe.Error() + "\n\nstack of the computation:\n" + string(e.pe.stack)
+}
+
+func (e *repanicError) Unwrap() error {
+	return e.pe
+}
+
+// maybeRepanic panics if err is a *PanicError and no reader of f has
+// panicked yet.
+func (f *Future[T]) maybeRepanic(err error) {
+	var pe *PanicError
+	if errors.As(err, &pe) && f.repanicked.CompareAndSwap(false, true) {
+		panic(&repanicError{pe: pe})
+	}
+}
@@ -0,0 +1,50 @@
+package futures
+
+import "context"
+
+// Pipeline passes initial through stages, one after the other, and returns
+// a Future for the value the last stage resolves to. Each stage receives
+// the value of the previous one and returns a Future for the transformed
+// value, so stages may do I/O. Without stages, the returned Future
+// resolves to initial.
+//
+// If a stage fails, the returned Future fails with its error, and the
+// remaining stages are not called. A panic in a stage, or a stage
+// returning a nil Future, fails the pipeline; a panic fails it with a
+// *PanicError. Canceling the returned Future cancels the stage in flight.
+//
+// Like Sequence, Pipeline runs on a single goroutine, no matter how many
+// stages there are.
+func Pipeline[T any](initial T, stages []func(T) *Future[T], opts ...Option) *Future[T] {
+	return New(func(ctx context.Context) (T, error) {
+		return pipeline(ctx, initial, len(stages), func(i int, v T) *Future[T] {
+			return stages[i](v)
+		})
+	}, opts...)
+}
+
+// PipelineWithContext is like Pipeline but passes a context to each
+// stage. The context is derived from ctx and is canceled when the
+// returned Future is canceled; if ctx is done before the last stage
+// has resolved, the returned Future fails with ErrCanceled or ErrTimeout.
+func PipelineWithContext[T any](ctx context.Context, initial T, stages []func(context.Context, T) *Future[T], opts ...Option) *Future[T] {
+	return NewWithContext(ctx, func(ctx context.Context) (T, error) {
+		return pipeline(ctx, initial, len(stages), func(i int, v T) *Future[T] {
+			return stages[i](ctx, v)
+		})
+	}, opts...)
+}
+
+// pipeline calls stage n times, each time with the value of the previous
+// call.
+func pipeline[T any](ctx context.Context, v T, n int, stage func(i int, v T) *Future[T]) (T, error) {
+	for i := 0; i < n; i++ {
+		next, err := awaitStep(ctx, attemptFuture(func() *Future[T] { return stage(i, v) }))
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		v = next
+	}
+	return v, nil
+}
@@ -0,0 +1,86 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestPipeline(t *testing.T) {
+	trim := func(s string) *futures.Future[string] { return futures.Completed(strings.TrimSpace(s)) }
+	upper := func(s string) *futures.Future[string] {
+		return futures.New(func(ctx context.Context) (string, error) { return strings.ToUpper(s), nil })
+	}
+	exclaim := func(s string) *futures.Future[string] { return futures.Completed(s + "!") }
+
+	v, err := futures.Pipeline("  hello ", []func(string) *futures.Future[string]{trim, upper, exclaim}).Get()
+	if v != "HELLO!" || err != nil {
+		t.Errorf("Get = %q, %v; want %q, nil", v, err, "HELLO!")
+	}
+	if v, err := futures.Pipeline("as is", nil).Get(); v != "as is" || err != nil {
+		t.Errorf("without stages: Get = %q, %v; want the initial value", v, err)
+	}
+}
+
+func TestPipelineShortCircuits(t *testing.T) {
+	errBoom := errors.New("boom")
+	called := false
+	_, err := futures.Pipeline(1, []func(int) *futures.Future[int]{
+		func(v int) *futures.Future[int] { return futures.Completed(v + 1) },
+		func(int) *futures.Future[int] { return futures.Failed[int](errBoom) },
+		func(v int) *futures.Future[int] {
+			called = true
+			return futures.Completed(v)
+		},
+	}).Get()
+	if err != errBoom {
+		t.Errorf("err = %v, want %v", err, errBoom)
+	}
+	if called {
+		t.Error("the stage after the failure was called")
+	}
+
+	_, err = futures.Pipeline(1, []func(int) *futures.Future[int]{
+		func(int) *futures.Future[int] { return nil },
+	}).Get()
+	if err == nil {
+		t.Error("a stage returning a nil Future did not fail the pipeline")
+	}
+}
+
+func TestPipelineWithContext(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, 10)
+	add := func(ctx context.Context, v int) *futures.Future[int] {
+		return futures.Completed(v + ctx.Value(key{}).(int))
+	}
+	v, err := futures.PipelineWithContext(ctx, 1, []func(context.Context, int) *futures.Future[int]{add, add}).Get()
+	if v != 21 || err != nil {
+		t.Errorf("Get = %d, %v; want 21, nil", v, err)
+	}
+}
+
+func TestPipelineWithContextTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	stageDone := make(chan error, 1)
+	_, err := futures.PipelineWithContext(ctx, 1, []func(context.Context, int) *futures.Future[int]{
+		func(ctx context.Context, v int) *futures.Future[int] {
+			return futures.New(func(stageCtx context.Context) (int, error) {
+				<-stageCtx.Done()
+				stageDone <- stageCtx.Err()
+				return 0, stageCtx.Err()
+			})
+		},
+	}).Get()
+	if !errors.Is(err, futures.ErrTimeout) {
+		t.Errorf("err = %v, want ErrTimeout", err)
+	}
+	if err := <-stageDone; err == nil {
+		t.Error("the stage in flight was not canceled")
+	}
+}
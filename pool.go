@@ -0,0 +1,76 @@
+package futures
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// poolPollInterval is how often a FuturePool checks GOMAXPROCS.
+const poolPollInterval = 100 * time.Millisecond
+
+// FuturePool runs computations on a pool of worker goroutines whose size
+// follows GOMAXPROCS: it has twice as many workers as GOMAXPROCS allows
+// threads to run Go code at once. If GOMAXPROCS changes, the pool adapts
+// within a fraction of a second. Use it to bound the number of
+// computations that run at the same time without picking a number.
+type FuturePool[T any] struct {
+	pool      *workerPool
+	stop      chan struct{}
+	stopOnce  sync.Once
+	watchDone chan struct{}
+}
+
+// NewPool starts a FuturePool with runtime.GOMAXPROCS(0) * 2 workers.
+// Call Close to stop the workers.
+func NewPool[T any]() *FuturePool[T] {
+	p := &FuturePool[T]{
+		pool:      newWorkerPool(poolSize()),
+		stop:      make(chan struct{}),
+		watchDone: make(chan struct{}),
+	}
+	go p.watch()
+	return p
+}
+
+func poolSize() int {
+	return runtime.GOMAXPROCS(0) * 2
+}
+
+// watch adjusts the number of workers to GOMAXPROCS until p is closed.
+func (p *FuturePool[T]) watch() {
+	defer close(p.watchDone)
+	t := time.NewTicker(poolPollInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if n := poolSize(); n != p.pool.size() {
+				p.pool.resize(n)
+			}
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// Size returns the number of workers that p currently aims for.
+func (p *FuturePool[T]) Size() int {
+	return p.pool.size()
+}
+
+// Submit queues fn and returns a Future for its result. The options apply
+// to the Future, except that WithExecutor is overridden. After Close, the
+// Future fails with ErrPoolClosed.
+func (p *FuturePool[T]) Submit(fn func(ctx context.Context) (T, error), opts ...Option) *Future[T] {
+	return submit(p.pool, fn, opts)
+}
+
+// Close stops accepting computations and waits until the queued ones have
+// run.
+func (p *FuturePool[T]) Close() {
+	p.stopOnce.Do(func() { close(p.stop) })
+	<-p.watchDone
+	p.pool.close()
+}
@@ -0,0 +1,60 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestNewPoolFollowsGOMAXPROCS(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	p := futures.NewPool[int]()
+	defer p.Close()
+	if n := p.Size(); n != 8 {
+		t.Fatalf("Size() = %d with GOMAXPROCS 4, want 8", n)
+	}
+	runtime.GOMAXPROCS(2)
+	eventually(t, func() bool { return p.Size() == 4 }, "the pool did not shrink to 4 workers")
+	runtime.GOMAXPROCS(3)
+	eventually(t, func() bool { return p.Size() == 6 }, "the pool did not grow to 6 workers")
+}
+
+func TestPoolBoundsConcurrency(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+	p := futures.NewPool[int]()
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	var fs []*futures.Future[int]
+	for i := 0; i < 10; i++ {
+		fs = append(fs, p.Submit(func(ctx context.Context) (int, error) {
+			n := running.Add(1)
+			for {
+				m := peak.Load()
+				if n <= m || peak.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			<-release
+			running.Add(-1)
+			return 1, nil
+		}))
+	}
+	eventually(t, func() bool { return running.Load() == 2 }, "the workers did not pick up tasks")
+	close(release)
+	for _, f := range fs {
+		if v, err := f.Get(); v != 1 || err != nil {
+			t.Errorf("Get = %v, %v; want 1, nil", v, err)
+		}
+	}
+	if n := peak.Load(); n != 2 {
+		t.Errorf("%d tasks ran at once with 2 workers", n)
+	}
+	p.Close()
+	if _, err := p.Submit(func(ctx context.Context) (int, error) { return 1, nil }).Get(); !errors.Is(err, futures.ErrPoolClosed) {
+		t.Errorf("Submit after Close: err = %v, want ErrPoolClosed", err)
+	}
+}
@@ -0,0 +1,77 @@
+package futures
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Metrics reported by PoolWithMetrics.
+const (
+	MetricPoolTasksSubmitted    = "pool_tasks_submitted"    // counter
+	MetricPoolTasksCompleted    = "pool_tasks_completed"    // counter
+	MetricPoolTasksFailed       = "pool_tasks_failed"       // counter
+	MetricPoolQueueDepth        = "pool_queue_depth"        // gauge
+	MetricPoolWorkerUtilization = "pool_worker_utilization" // gauge, 0 to 1
+	MetricPoolTaskSeconds       = "pool_task_seconds"       // histogram
+)
+
+// PoolWithMetrics runs computations on a fixed number of worker goroutines
+// and reports its utilization to a MetricsSink.
+type PoolWithMetrics[T any] struct {
+	pool    *workerPool
+	workers int
+	sink    MetricsSink
+	clock   Clock
+	busy    atomic.Int64
+}
+
+// NewPoolWithMetrics starts a pool with the given number of workers, at
+// least one, that reports to sink. Call Close to stop the workers.
+//
+// Of the metrics, pool_tasks_completed counts the tasks that resolved and
+// pool_tasks_failed those that failed or panicked; a task canceled before a worker
+// picked it up counts as neither. Task durations are reported in seconds,
+// measured with the clock set by WithClock in opts.
+func NewPoolWithMetrics[T any](workers int, sink MetricsSink, opts ...Option) *PoolWithMetrics[T] {
+	workers = max(workers, 1)
+	return &PoolWithMetrics[T]{
+		pool:    newWorkerPool(workers),
+		workers: workers,
+		sink:    sink,
+		clock:   newOptions(opts).clock,
+	}
+}
+
+// Submit queues fn and returns a Future for its result. The options apply
+// to the Future, except that WithExecutor is overridden. After Close, the
+// Future fails with ErrPoolClosed.
+func (p *PoolWithMetrics[T]) Submit(fn func(ctx context.Context) (T, error), opts ...Option) *Future[T] {
+	p.sink.Count(MetricPoolTasksSubmitted, 1)
+	f := submit(p.pool, func(ctx context.Context) (v T, err error) {
+		p.sink.Gauge(MetricPoolQueueDepth, float64(p.pool.queued()))
+		p.sink.Gauge(MetricPoolWorkerUtilization, float64(p.busy.Add(1))/float64(p.workers))
+		start := p.clock.Now()
+		returned := false
+		defer func() {
+			// A task that panicked fails; the panic goes on to settle
+			// the Future with a *PanicError.
+			if returned && err == nil {
+				p.sink.Count(MetricPoolTasksCompleted, 1)
+			} else {
+				p.sink.Count(MetricPoolTasksFailed, 1)
+			}
+			p.sink.Observe(MetricPoolTaskSeconds, p.clock.Now().Sub(start).Seconds())
+			p.sink.Gauge(MetricPoolWorkerUtilization, float64(p.busy.Add(-1))/float64(p.workers))
+		}()
+		v, err = fn(ctx)
+		returned = true
+		return v, err
+	}, opts)
+	p.sink.Gauge(MetricPoolQueueDepth, float64(p.pool.queued()))
+	return f
+}
+
+// Close stops accepting tasks and waits until the queued ones have run.
+func (p *PoolWithMetrics[T]) Close() {
+	p.pool.close()
+}
@@ -0,0 +1,144 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+// mockSink records the metrics it receives.
+type mockSink struct {
+	mu       sync.Mutex
+	counters map[string]int64
+	gauges   map[string][]float64
+	observed map[string]int
+}
+
+func newMockSink() *mockSink {
+	return &mockSink{counters: map[string]int64{}, gauges: map[string][]float64{}, observed: map[string]int{}}
+}
+
+func (s *mockSink) Count(name string, delta int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counters[name] += delta
+}
+
+func (s *mockSink) Gauge(name string, value float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gauges[name] = append(s.gauges[name], value)
+}
+
+func (s *mockSink) Observe(name string, value float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observed[name]++
+}
+
+func (s *mockSink) counter(name string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counters[name]
+}
+
+func (s *mockSink) maxGauge(name string) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := 0.0
+	for _, v := range s.gauges[name] {
+		m = max(m, v)
+	}
+	return m
+}
+
+func TestPoolWithMetrics(t *testing.T) {
+	sink := newMockSink()
+	pool := futures.NewPoolWithMetrics[int](3, sink)
+	release := make(chan struct{})
+	var running, peak atomic.Int32
+
+	var futs []*futures.Future[int]
+	for i := 0; i < 20; i++ {
+		i := i
+		futs = append(futs, pool.Submit(func(ctx context.Context) (int, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			<-release
+			if i%4 == 0 {
+				return 0, errors.New("failed")
+			}
+			return i, nil
+		}))
+	}
+	eventually(t, func() bool { return running.Load() == 3 }, "workers did not pick up tasks")
+	close(release)
+	for _, f := range futs {
+		f.Wait(context.Background())
+	}
+	pool.Close()
+
+	if n := sink.counter(futures.MetricPoolTasksSubmitted); n != 20 {
+		t.Errorf("submitted = %d, want 20", n)
+	}
+	if n := sink.counter(futures.MetricPoolTasksCompleted); n != 15 {
+		t.Errorf("completed = %d, want 15", n)
+	}
+	if n := sink.counter(futures.MetricPoolTasksFailed); n != 5 {
+		t.Errorf("failed = %d, want 5", n)
+	}
+	if d := sink.maxGauge(futures.MetricPoolQueueDepth); d < 1 || d > 20 {
+		t.Errorf("maximum queue depth = %v, want between 1 and 20", d)
+	}
+	if u := sink.maxGauge(futures.MetricPoolWorkerUtilization); u != 1 {
+		t.Errorf("maximum utilization = %v, want 1", u)
+	}
+	if n := sink.observed[futures.MetricPoolTaskSeconds]; n != 20 {
+		t.Errorf("%d task durations observed, want 20", n)
+	}
+	if p := peak.Load(); p > 3 {
+		t.Errorf("%d tasks ran at once on 3 workers", p)
+	}
+}
+
+func TestPoolWithMetricsClosed(t *testing.T) {
+	pool := futures.NewPoolWithMetrics[int](1, newMockSink())
+	pool.Close()
+	_, err := pool.Submit(func(ctx context.Context) (int, error) { return 1, nil }).Get()
+	if !errors.Is(err, futures.ErrPoolClosed) {
+		t.Errorf("Submit after Close: err = %v, want ErrPoolClosed", err)
+	}
+}
+
+func TestPoolWithMetricsPanic(t *testing.T) {
+	sink := newMockSink()
+	pool := futures.NewPoolWithMetrics[int](1, sink)
+	defer pool.Close()
+	_, err := pool.Submit(func(ctx context.Context) (int, error) { panic("boom") }).Get()
+	var perr *futures.PanicError
+	if !errors.As(err, &perr) {
+		t.Fatalf("err = %v, want a *PanicError", err)
+	}
+	if _, err := pool.Submit(func(ctx context.Context) (int, error) { return 1, nil }).Get(); err != nil {
+		t.Fatalf("task after the panic: err = %v", err)
+	}
+	if n := sink.counter(futures.MetricPoolTasksFailed); n != 1 {
+		t.Errorf("failed = %d, want 1 for the panicking task", n)
+	}
+	if n := sink.counter(futures.MetricPoolTasksCompleted); n != 1 {
+		t.Errorf("completed = %d, want 1", n)
+	}
+	if u := sink.gauges[futures.MetricPoolWorkerUtilization]; u[len(u)-1] != 0 {
+		t.Errorf("utilization after the tasks = %v, want 0", u[len(u)-1])
+	}
+}
@@ -0,0 +1,124 @@
+package futures
+
+import (
+	"math"
+	"sync"
+)
+
+// PromiseWithProgress is a Promise whose producer also reports progress
+// of type P, such as bytes transferred or the current phase of a job.
+//
+// Progress is coalesced: if the reader falls behind, it misses
+// intermediate reports but always gets the latest one.
+type PromiseWithProgress[T, P any] struct {
+	*Promise[T]
+
+	mu       sync.Mutex
+	closed   bool
+	progress chan P
+}
+
+// NewPromiseWithProgress returns a pending PromiseWithProgress. The options
+// are those of NewPromise.
+func NewPromiseWithProgress[T, P any](opts ...Option) *PromiseWithProgress[T, P] {
+	p := &PromiseWithProgress[T, P]{
+		Promise:  NewPromise[T](opts...),
+		progress: make(chan P, 1),
+	}
+	go func() {
+		<-p.f.core.done
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.closed = true
+		close(p.progress)
+	}()
+	return p
+}
+
+// Notify reports progress. It replaces a report that the reader has not
+// received yet. Once the Promise has settled, Notify does nothing.
+func (p *PromiseWithProgress[T, P]) Notify(v P) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	select {
+	case <-p.progress:
+	default:
+	}
+	p.progress <- v
+}
+
+// Progress returns the channel of progress reports. It is closed once the
+// Promise has settled.
+func (p *PromiseWithProgress[T, P]) Progress() <-chan P {
+	return p.progress
+}
+
+// Progress is a simple progress report for producers that do not need a
+// type of their own.
+type Progress struct {
+	// Fraction is the share of the work that is done, from 0 to 1.
+	Fraction float64
+	// Message describes the current state.
+	Message string
+}
+
+// NewProgressPromise returns a PromiseWithProgress that reports Progress.
+func NewProgressPromise[T any](opts ...Option) *PromiseWithProgress[T, Progress] {
+	return NewPromiseWithProgress[T, Progress](opts...)
+}
+
+// AggregateProgress combines several progress channels into one channel of
+// the mean fraction done, using fraction to tell how far each report is.
+// Fractions are clamped to the range from 0 to 1; a channel that has not
+// reported yet counts as 0, a closed one as 1.
+//
+// The returned channel coalesces like Progress and is closed when all
+// inputs are closed.
+func AggregateProgress[P any](fraction func(P) float64, chans ...<-chan P) <-chan float64 {
+	out := make(chan float64, 1)
+	type report struct {
+		i int
+		f float64
+	}
+	reports := make(chan report)
+	var wg sync.WaitGroup
+	for i, ch := range chans {
+		wg.Add(1)
+		go func(i int, ch <-chan P) {
+			defer wg.Done()
+			for v := range ch {
+				reports <- report{i, math.Max(0, math.Min(1, fraction(v)))}
+			}
+			reports <- report{i, 1}
+		}(i, ch)
+	}
+	go func() {
+		wg.Wait()
+		close(reports)
+	}()
+	go func() {
+		defer close(out)
+		fractions := make([]float64, len(chans))
+		for r := range reports {
+			fractions[r.i] = r.f
+			sum := 0.0
+			for _, f := range fractions {
+				sum += f
+			}
+			select {
+			case <-out:
+			default:
+			}
+			out <- sum / float64(len(fractions))
+		}
+	}()
+	return out
+}
+
+// AggregateFractions is AggregateProgress for channels of Progress.
+func AggregateFractions(chans ...<-chan Progress) <-chan float64 {
+	return AggregateProgress(func(p Progress) float64 { return p.Fraction }, chans...)
+}
@@ -0,0 +1,93 @@
+package futures_test
+
+import (
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+type phase int
+
+const (
+	downloading phase = iota
+	indexing
+)
+
+type importProgress struct {
+	Phase phase
+	Rows  int
+	Total int
+}
+
+func TestPromiseWithProgressTyped(t *testing.T) {
+	p := futures.NewPromiseWithProgress[string, importProgress]()
+	p.Notify(importProgress{Phase: downloading})
+	for rows := 1; rows <= 100; rows++ {
+		p.Notify(importProgress{Phase: indexing, Rows: rows, Total: 100})
+	}
+	// The reader fell behind and gets only the latest report.
+	if got := <-p.Progress(); got.Phase != indexing || got.Rows != 100 {
+		t.Errorf("progress = %+v, want the latest report", got)
+	}
+
+	p.Resolve("imported")
+	if _, ok := <-p.Progress(); ok {
+		t.Error("progress channel still open after the Promise resolved")
+	}
+	p.Notify(importProgress{}) // must not panic
+	if v, err := p.Future().Get(); v != "imported" || err != nil {
+		t.Errorf("Get = %q, %v; want imported, nil", v, err)
+	}
+}
+
+func TestProgressPromise(t *testing.T) {
+	p := futures.NewProgressPromise[int]()
+	p.Notify(futures.Progress{Fraction: 0.5, Message: "halfway"})
+	if got := <-p.Progress(); got.Fraction != 0.5 || got.Message != "halfway" {
+		t.Errorf("progress = %+v", got)
+	}
+	p.Resolve(1)
+}
+
+func TestAggregateProgress(t *testing.T) {
+	a := futures.NewPromiseWithProgress[int, importProgress]()
+	b := futures.NewPromiseWithProgress[int, importProgress]()
+	rows := func(p importProgress) float64 { return float64(p.Rows) / float64(p.Total) }
+	agg := futures.AggregateProgress(rows, a.Progress(), b.Progress())
+
+	a.Notify(importProgress{Rows: 50, Total: 100})
+	eventuallyReceives(t, agg, 0.25)
+	b.Notify(importProgress{Rows: 100, Total: 100})
+	eventuallyReceives(t, agg, 0.75)
+	a.Resolve(1)
+	b.Resolve(2)
+	last := -1.0
+	for f := range agg {
+		last = f
+	}
+	if last != -1 && last != 1 {
+		t.Errorf("final aggregate = %v, want 1", last)
+	}
+}
+
+func TestAggregateFractions(t *testing.T) {
+	a, b := futures.NewProgressPromise[int](), futures.NewProgressPromise[int]()
+	agg := futures.AggregateFractions(a.Progress(), b.Progress())
+	a.Notify(futures.Progress{Fraction: 2}) // clamped to 1
+	eventuallyReceives(t, agg, 0.5)
+	a.Resolve(0)
+	b.Resolve(0)
+	for range agg {
+	}
+}
+
+// eventuallyReceives receives from ch until it gets want.
+func eventuallyReceives(t *testing.T, ch <-chan float64, want float64) {
+	t.Helper()
+	for got := range ch {
+		if got == want {
+			return
+		}
+	}
+	t.Fatalf("channel closed before delivering %v", want)
+}
@@ -0,0 +1,119 @@
+package futures
+
+import "time"
+
+// Promise is the producing side of a Future whose result is not computed
+// by a function but delivered by hand, for example from a callback or an
+// incoming message. Hand out the Future to the readers, and resolve or
+// reject the Promise once the result is known.
+//
+// Only the first settlement has an effect; later attempts to settle the
+// Promise fail with ErrAlreadySettled.
+type Promise[T any] struct {
+	f *Future[T]
+}
+
+// NewPromise returns a pending Promise. Of the options, WithName,
+// WithSettleHook, WithClock, WithArena, and WithRequireNonZero apply.
+func NewPromise[T any](opts ...Option) *Promise[T] {
+	f := configuredFuture[T](newOptions(opts))
+	f.markStarted()
+	return &Promise[T]{f: f}
+}
+
+// Future returns the Future of p.
+func (p *Promise[T]) Future() *Future[T] {
+	return p.f
+}
+
+// Resolve settles p with the value v. If p has settled already, Resolve
+// leaves it unchanged and returns ErrAlreadySettled.
+func (p *Promise[T]) Resolve(v T) error {
+	checkContexts(p.f.name, v)
+	if !p.f.settle(v, nil) {
+		return ErrAlreadySettled
+	}
+	return nil
+}
+
+// Reject settles p with the error err. If p has settled already, Reject
+// leaves it unchanged and returns ErrAlreadySettled.
+func (p *Promise[T]) Reject(err error) error {
+	var zero T
+	if !p.f.settle(zero, err) {
+		return ErrAlreadySettled
+	}
+	return nil
+}
+
+// Cancel settles p with a *CancellationError that carries reason. If p
+// has settled already, Cancel leaves it unchanged and returns
+// ErrAlreadySettled.
+func (p *Promise[T]) Cancel(reason string) error {
+	return p.Reject(&CancellationError{Reason: reason})
+}
+
+// RejectAfter arms a timer that rejects p once d has elapsed, unless p has
+// settled by then. Use it when the result depends on an external event that
+// might never arrive, such as a webhook callback. The error of p then
+// matches both ErrProducerTimeout and err in errors.Is; if err is nil, it
+// is ErrProducerTimeout. This tells it apart from ErrTimeout, which is
+// what readers get when they give up waiting.
+//
+// The timer runs on the clock set by WithClock and is stopped as soon as p
+// settles. If RejectAfter is called more than once, the earliest deadline
+// wins.
+func (p *Promise[T]) RejectAfter(d time.Duration, err error) {
+	if err == nil {
+		err = ErrProducerTimeout
+	} else {
+		err = &producerTimeoutError{err: err}
+	}
+	t := p.f.afterFunc(d, func() { p.Reject(err) })
+	p.f.core.AddCallback(func(T, error) { t.Stop() })
+}
+
+// RejectAt is like RejectAfter but rejects p at the time deadline.
+func (p *Promise[T]) RejectAt(deadline time.Time, err error) {
+	p.RejectAfter(deadline.Sub(p.f.clock.Now()), err)
+}
+
+// Settlement reports which settlement of p won, if any.
+func (p *Promise[T]) Settlement() Settlement {
+	return p.f.State()
+}
+
+// Producer returns a handle for the goroutine that is responsible for
+// settling p. Closing the handle rejects p with ErrBrokenPromise unless p
+// has settled already, so that a producer that forgets to settle p cannot
+// leave the readers waiting forever:
+//
+//	go func() {
+//		prod := p.Producer()
+//		defer prod.Close()
+//		// ... eventually call prod.Resolve or prod.Reject ...
+//	}()
+func (p *Promise[T]) Producer() *Producer[T] {
+	return &Producer[T]{p: p}
+}
+
+// Producer is a handle for settling a Promise. See Promise.Producer.
+type Producer[T any] struct {
+	p *Promise[T]
+}
+
+// Resolve settles the Promise with the value v, like Promise.Resolve.
+func (pr *Producer[T]) Resolve(v T) error {
+	return pr.p.Resolve(v)
+}
+
+// Reject settles the Promise with the error err, like Promise.Reject.
+func (pr *Producer[T]) Reject(err error) error {
+	return pr.p.Reject(err)
+}
+
+// Close rejects the Promise with ErrBrokenPromise if it has not settled
+// yet.
+func (pr *Producer[T]) Close() {
+	pr.p.Reject(ErrBrokenPromise)
+}
@@ -0,0 +1,201 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestPromise(t *testing.T) {
+	p := futures.NewPromise[int]()
+	f := p.Future()
+	if f.Err() != nil {
+		t.Fatal("new Promise is not pending")
+	}
+	if got := p.Settlement(); got != futures.Unsettled {
+		t.Errorf("Settlement() = %v before settling, want unsettled", got)
+	}
+	if err := p.Resolve(1); err != nil {
+		t.Errorf("first Resolve: err = %v", err)
+	}
+	if err := p.Resolve(2); !errors.Is(err, futures.ErrAlreadySettled) {
+		t.Errorf("second Resolve: err = %v, want ErrAlreadySettled", err)
+	}
+	if err := p.Reject(errors.New("too late")); !errors.Is(err, futures.ErrAlreadySettled) {
+		t.Errorf("Reject after Resolve: err = %v, want ErrAlreadySettled", err)
+	}
+	if v, err := f.Get(); v != 1 || err != nil {
+		t.Errorf("Get = %d, %v; want 1, nil", v, err)
+	}
+	if got := p.Settlement(); got != futures.Resolved {
+		t.Errorf("Settlement() = %v, want resolved", got)
+	}
+
+	errBoom := errors.New("boom")
+	p = futures.NewPromise[int]()
+	p.Reject(errBoom)
+	if _, err := p.Future().Get(); err != errBoom {
+		t.Errorf("Get after Reject: err = %v, want %v", err, errBoom)
+	}
+	if err := p.Resolve(3); !errors.Is(err, futures.ErrAlreadySettled) {
+		t.Errorf("Resolve after Reject: err = %v, want ErrAlreadySettled", err)
+	}
+	if got := p.Settlement(); got != futures.Rejected {
+		t.Errorf("Settlement() = %v, want rejected", got)
+	}
+}
+
+func TestPromiseConcurrentResolve(t *testing.T) {
+	for round := 0; round < 100; round++ {
+		p := futures.NewPromise[int]()
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		var winners []int
+		for i := 0; i < 8; i++ {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if p.Resolve(i) == nil {
+					mu.Lock()
+					winners = append(winners, i)
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		if len(winners) != 1 {
+			t.Fatalf("round %d: %d calls to Resolve won, want exactly one", round, len(winners))
+		}
+		if v, _ := p.Future().Get(); v != winners[0] {
+			t.Fatalf("round %d: Get = %d, want the winner's value %d", round, v, winners[0])
+		}
+	}
+}
+
+func TestProducerForgetsToResolve(t *testing.T) {
+	p := futures.NewPromise[string]()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := p.Future().Get(); !errors.Is(err, futures.ErrBrokenPromise) {
+				t.Errorf("Get: err = %v, want ErrBrokenPromise", err)
+			}
+		}()
+	}
+	go func() {
+		prod := p.Producer()
+		defer prod.Close()
+		// Forgets to call prod.Resolve.
+	}()
+	wg.Wait()
+}
+
+func TestProducerResolves(t *testing.T) {
+	p := futures.NewPromise[string]()
+	go func() {
+		prod := p.Producer()
+		defer prod.Close()
+		prod.Resolve("done")
+	}()
+	if v, err := p.Future().Get(); v != "done" || err != nil {
+		t.Errorf("Get = %q, %v; want done, nil", v, err)
+	}
+}
+
+func TestNewBrokenPromise(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) {
+		runtime.Goexit()
+		return 1, nil
+	})
+	if _, err := f.Get(); !errors.Is(err, futures.ErrBrokenPromise) {
+		t.Errorf("Get: err = %v, want ErrBrokenPromise", err)
+	}
+}
+
+func TestPromiseRejectAfter(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	p := futures.NewPromise[int](futures.WithClock(clock))
+	errNoCallback := errors.New("webhook never called")
+	p.RejectAfter(time.Minute, errNoCallback)
+
+	clock.Advance(59 * time.Second)
+	if p.Settlement() != futures.Unsettled {
+		t.Fatal("Promise settled before the deadline")
+	}
+	clock.Advance(time.Second)
+	_, err := p.Future().Get()
+	if !errors.Is(err, futures.ErrProducerTimeout) || !errors.Is(err, errNoCallback) {
+		t.Errorf("err = %v, want ErrProducerTimeout wrapping %v", err, errNoCallback)
+	}
+	if errors.Is(err, futures.ErrTimeout) {
+		t.Error("a producer timeout matches ErrTimeout")
+	}
+}
+
+func TestPromiseRejectAtDefaultError(t *testing.T) {
+	start := time.Now()
+	clock := futuretest.NewFakeClock(start)
+	p := futures.NewPromise[int](futures.WithClock(clock))
+	p.RejectAt(start.Add(time.Hour), nil)
+	p.RejectAfter(time.Minute, nil) // the earlier deadline wins
+	clock.Advance(time.Minute)
+	if _, err := p.Future().Get(); err != futures.ErrProducerTimeout {
+		t.Errorf("err = %v, want ErrProducerTimeout", err)
+	}
+}
+
+func TestPromiseRejectAfterDisarmed(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	p := futures.NewPromise[int](futures.WithClock(clock))
+	p.RejectAfter(time.Minute, nil)
+	if n := clock.Pending(); n != 1 {
+		t.Fatalf("%d timers pending, want 1", n)
+	}
+	p.Resolve(1)
+	if n := clock.Pending(); n != 0 {
+		t.Errorf("%d timers pending after Resolve, want 0", n)
+	}
+	clock.Advance(time.Hour)
+	if v, err := p.Future().Get(); v != 1 || err != nil {
+		t.Errorf("Get = %v, %v; want 1, nil", v, err)
+	}
+
+	// Arming a settled Promise does not leave a timer behind.
+	p.RejectAfter(time.Minute, nil)
+	if n := clock.Pending(); n != 0 {
+		t.Errorf("%d timers pending after arming a settled Promise, want 0", n)
+	}
+}
+
+func TestPromiseCancel(t *testing.T) {
+	p := futures.NewPromise[int]()
+	if err := p.Cancel("user left"); err != nil {
+		t.Fatalf("Cancel() = %v, want nil", err)
+	}
+	_, err := p.Future().Get()
+	var ce *futures.CancellationError
+	if !errors.As(err, &ce) || ce.Reason != "user left" {
+		t.Fatalf("err = %v, want a CancellationError with the reason", err)
+	}
+	if !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v does not match ErrCanceled", err)
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v matches context.Canceled", err)
+	}
+	if got, want := err.Error(), "futures: canceled: user left"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if err := p.Cancel("again"); !errors.Is(err, futures.ErrAlreadySettled) {
+		t.Errorf("second Cancel() = %v, want ErrAlreadySettled", err)
+	}
+}
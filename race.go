@@ -0,0 +1,30 @@
+package futures
+
+// Race returns a Future that settles with the outcome of whichever of futs
+// settles first, no matter whether it resolved or failed. Once the race is
+// decided, all other futures are canceled.
+//
+// Called without futures, Race returns a Future that fails with
+// ErrNoFutures.
+func Race[T any](futs ...*Future[T]) *Future[T] {
+	if len(futs) == 0 {
+		return Failed[T](ErrNoFutures)
+	}
+	out := newFuture[T]()
+	for _, f := range futs {
+		go func(f *Future[T]) {
+			select {
+			case <-f.Done():
+				if out.settle(f.core.value, f.core.err) {
+					for _, other := range futs {
+						if other != f {
+							other.Cancel()
+						}
+					}
+				}
+			case <-out.core.done:
+			}
+		}(f)
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestRaceFirstResolvedWins(t *testing.T) {
+	started, loserStopped := make(chan struct{}), make(chan struct{})
+	slow := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		close(loserStopped)
+		return 0, ctx.Err()
+	})
+	<-started
+	fast := futures.Completed(7)
+
+	v, err := futures.Race(slow, fast).Get()
+	if v != 7 || err != nil {
+		t.Fatalf("Race = %v, %v; want 7, nil", v, err)
+	}
+	<-loserStopped
+}
+
+func TestRaceFirstFailureWins(t *testing.T) {
+	errBoom := errors.New("boom")
+	release := make(chan struct{})
+	defer close(release)
+	slow := futures.New(func(ctx context.Context) (int, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return 1, nil
+	})
+
+	_, err := futures.Race(slow, futures.Failed[int](errBoom)).Get()
+	if err != errBoom {
+		t.Fatalf("Race: err = %v, want %v", err, errBoom)
+	}
+}
+
+func TestRaceCancelsLosers(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	loser := futures.New(func(ctx context.Context) (int, error) {
+		<-release // ignores ctx on purpose
+		return 1, nil
+	})
+	if v, _ := futures.Race(loser, futures.Completed(2)).Get(); v != 2 {
+		t.Fatalf("Race = %d, want 2", v)
+	}
+	// The loser settles as canceled even though its computation goes on.
+	if err := loser.Wait(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("loser: err = %v, want context.Canceled", err)
+	}
+}
+
+func TestRaceEmpty(t *testing.T) {
+	if _, err := futures.Race[int]().Get(); !errors.Is(err, futures.ErrNoFutures) {
+		t.Fatalf("Race(): err = %v, want ErrNoFutures", err)
+	}
+}
@@ -0,0 +1,41 @@
+package futures
+
+import "time"
+
+// RateWindow returns a Stream of the throughput of s in values per second.
+// Every time s delivers a value, RateWindow emits the number of values
+// that arrived during the last window, including this one, divided by
+// window. The window slides with every value. The returned Stream ends
+// with the error of s, and closing it closes s. Pass WithStreamClock to
+// replace the clock that timestamps the values.
+func RateWindow[T any](s *Stream[T], window time.Duration, opts ...StreamOption) *Stream[float64] {
+	o := newStreamOptions(0, opts)
+	out := newStream[float64](o)
+	go func() {
+		defer s.Close()
+		var arrivals []time.Time // within the window, oldest first
+		for {
+			select {
+			case _, ok := <-s.C():
+				if !ok {
+					out.close(s.Err())
+					return
+				}
+				now := o.clock.Now()
+				arrivals = append(arrivals, now)
+				i := 0
+				for i < len(arrivals) && now.Sub(arrivals[i]) >= window {
+					i++
+				}
+				arrivals = arrivals[i:]
+				if !out.send(float64(len(arrivals)) / window.Seconds()) {
+					return
+				}
+			case <-out.quit:
+				out.close(nil)
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,62 @@
+package futures_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestRateWindow(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Unix(0, 0))
+	ch := make(chan int)
+	rates := futures.RateWindow(futures.StreamOf(ch), time.Second, futures.WithStreamClock(clock))
+	var got []float64
+	for i := 0; i < 15; i++ {
+		ch <- i
+		got = append(got, <-rates.C())
+		clock.Advance(100 * time.Millisecond)
+	}
+	close(ch)
+	if _, ok := <-rates.C(); ok {
+		t.Error("received a rate after the source ended")
+	}
+	// The window fills up over the first ten values and then slides.
+	for i, r := range got {
+		want := float64(min(i+1, 10))
+		if r != want {
+			t.Errorf("rate %d = %v, want %v", i, r, want)
+		}
+	}
+}
+
+func TestRateWindowSlides(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Unix(0, 0))
+	ch := make(chan int)
+	rates := futures.RateWindow(futures.StreamOf(ch), 200*time.Millisecond, futures.WithStreamClock(clock))
+	defer close(ch)
+	ch <- 1
+	if r := <-rates.C(); r != 5 {
+		t.Errorf("first rate = %v, want 5", r)
+	}
+	ch <- 2
+	if r := <-rates.C(); r != 10 {
+		t.Errorf("second rate = %v, want 10", r)
+	}
+	clock.Advance(200*time.Millisecond - time.Nanosecond)
+	ch <- 3
+	if r := <-rates.C(); r != 15 {
+		t.Errorf("rate just inside the window = %v, want 15", r)
+	}
+	clock.Advance(time.Nanosecond)
+	ch <- 4
+	if r := <-rates.C(); r != 10 {
+		t.Errorf("rate once the first values have left the window = %v, want 10", r)
+	}
+	clock.Advance(time.Hour)
+	ch <- 5
+	if r := <-rates.C(); r != 5 {
+		t.Errorf("rate after a pause = %v, want 5", r)
+	}
+}
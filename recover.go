@@ -0,0 +1,16 @@
+package futures
+
+// Recover returns a Future that resolves to the value of f if f resolves,
+// and to fn(err) if f fails with err. Use it to turn a failure into a
+// default value inline.
+//
+// The returned Future always resolves, unless fn panics, in which case it
+// fails with a *PanicError.
+func (f *Future[T]) Recover(fn func(error) T) *Future[T] {
+	return chain(f, func(v T, err error) (T, error) {
+		if err == nil {
+			return v, nil
+		}
+		return catchPanic(func() (T, error) { return fn(err), nil })
+	})
+}
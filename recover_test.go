@@ -0,0 +1,45 @@
+package futures_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestRecover(t *testing.T) {
+	called := false
+	toZero := func(err error) int {
+		called = true
+		return -1
+	}
+	if v, err := futures.Completed(1).Recover(toZero).Get(); v != 1 || err != nil || called {
+		t.Errorf("resolved: Get = %d, %v (fn called: %v); want 1, nil without calling fn", v, err, called)
+	}
+
+	errBoom := errors.New("boom")
+	var got error
+	v, err := futures.Failed[int](errBoom).Recover(func(err error) int {
+		got = err
+		return -1
+	}).Get()
+	if v != -1 || err != nil {
+		t.Errorf("failed: Get = %d, %v; want -1, nil", v, err)
+	}
+	if got != errBoom {
+		t.Errorf("fn received %v, want %v", got, errBoom)
+	}
+}
+
+func TestRecoverPanic(t *testing.T) {
+	_, err := futures.Failed[int](errors.New("boom")).Recover(func(error) int {
+		panic("recovery failed")
+	}).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) || pe.Value() != "recovery failed" {
+		t.Fatalf("err = %v, want a PanicError with the panic value", err)
+	}
+	if len(pe.Stack()) == 0 {
+		t.Error("PanicError has no stack")
+	}
+}
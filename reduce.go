@@ -0,0 +1,47 @@
+package futures
+
+// Reduce folds the values of futs into an accumulator, starting with
+// initial, and returns a Future for the final accumulator.
+//
+// fn is applied in the order in which the futures settle, not in the order
+// of futs. Completion order is not deterministic, so unless fn is
+// commutative, the result may vary between runs. fn is never called
+// concurrently.
+//
+// If any future fails, the returned Future fails right away with that
+// error. Without futures, the returned Future resolves to initial.
+func Reduce[T, U any](futs []*Future[T], initial U, fn func(U, T) U) *Future[U] {
+	if len(futs) == 0 {
+		return Completed(initial)
+	}
+	out := newFuture[U]()
+	go func() {
+		acc := initial
+		ch := completionOrder(futs)
+		for range futs {
+			f := <-ch
+			if f.core.err != nil {
+				var zero U
+				out.settle(zero, f.core.err)
+				return
+			}
+			acc = fn(acc, f.core.value)
+		}
+		out.settle(acc, nil)
+	}()
+	return out
+}
+
+// completionOrder returns a channel that delivers each of futs once it has
+// settled. The channel is buffered, so that no goroutine is left blocked
+// if the receiver stops reading early.
+func completionOrder[T any](futs []*Future[T]) <-chan *Future[T] {
+	ch := make(chan *Future[T], len(futs))
+	for _, f := range futs {
+		go func(f *Future[T]) {
+			<-f.Done()
+			ch <- f
+		}(f)
+	}
+	return ch
+}
@@ -0,0 +1,72 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestReduceSum(t *testing.T) {
+	var futs []*futures.Future[int]
+	for i := 1; i <= 10; i++ {
+		i := i
+		futs = append(futs, futures.New(func(ctx context.Context) (int, error) { return i, nil }))
+	}
+	sum, err := futures.Reduce(futs, 0, func(acc, v int) int { return acc + v }).Get()
+	if sum != 55 || err != nil {
+		t.Fatalf("Reduce = %v, %v; want 55, nil", sum, err)
+	}
+}
+
+func TestReduceCompletionOrder(t *testing.T) {
+	gates := []chan struct{}{make(chan struct{}), make(chan struct{}), make(chan struct{})}
+	var futs []*futures.Future[int]
+	for i, gate := range gates {
+		i, gate := i, gate
+		futs = append(futs, futures.New(func(ctx context.Context) (int, error) {
+			<-gate
+			return i, nil
+		}))
+	}
+	applied := make(chan struct{})
+	out := futures.Reduce(futs, []int(nil), func(acc []int, v int) []int {
+		applied <- struct{}{}
+		return append(acc, v)
+	})
+	order := []int{2, 0, 1}
+	for _, i := range order {
+		close(gates[i])
+		<-applied
+	}
+	got, err := out.Get()
+	if err != nil {
+		t.Fatalf("Reduce: %v", err)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(order) {
+		t.Errorf("Reduce applied values in order %v, want completion order %v", got, order)
+	}
+}
+
+func TestReduceFailsFast(t *testing.T) {
+	errBoom := errors.New("boom")
+	release := make(chan struct{})
+	defer close(release)
+	never := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	_, err := futures.Reduce([]*futures.Future[int]{never, futures.Failed[int](errBoom)}, 0, func(acc, v int) int { return acc + v }).Get()
+	if err != errBoom {
+		t.Fatalf("Reduce: err = %v, want %v", err, errBoom)
+	}
+}
+
+func TestReduceEmpty(t *testing.T) {
+	got, err := futures.Reduce(nil, 5, func(acc, v int) int { return acc + v }).Get()
+	if got != 5 || err != nil {
+		t.Fatalf("Reduce(nil) = %v, %v; want 5, nil", got, err)
+	}
+}
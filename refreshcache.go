@@ -0,0 +1,182 @@
+package futures
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RefreshCache is a cache whose entries are loaded by a function and
+// refreshed when they get too old. Readers choose how old a value may be,
+// and whether they would rather get a stale value than wait for a slow
+// refresh; see GetFresh.
+type RefreshCache[K comparable, V any] struct {
+	load  func(ctx context.Context, k K) (V, error)
+	opts  []Option
+	clock Clock
+
+	mu      sync.Mutex
+	entries map[K]*refreshEntry[V]
+}
+
+type refreshEntry[V any] struct {
+	value  V
+	loaded time.Time
+	ok     bool // value has been loaded
+	rev    uint64
+	// changed is closed and cleared when rev grows. It is created by the
+	// first reader that waits for it.
+	changed chan struct{}
+	// refresh is the pending refresh, if any.
+	refresh *Future[Revision[V]]
+}
+
+// FreshResult is the outcome of RefreshCache.GetFresh that delivered a
+// value.
+type FreshResult[V any] struct {
+	Value V
+	// Rev is the revision of Value.
+	Rev uint64
+	// Stale is nil if Value is fresh enough for the reader. Otherwise, the
+	// refresh did not finish in time, and Value is the older value that
+	// was served instead.
+	Stale *StaleInfo
+}
+
+// StaleInfo describes a stale value that was served instead of waiting
+// for a refresh.
+type StaleInfo struct {
+	// Age is the age of the value.
+	Age time.Duration
+	// Err is why the reader stopped waiting for the refresh: ErrTimeout or
+	// ErrCanceled.
+	Err error
+}
+
+// NewRefreshCache returns an empty RefreshCache that loads entries with
+// load. The options apply to the futures that run load; WithClock also
+// sets the clock that measures the age of entries.
+func NewRefreshCache[K comparable, V any](load func(ctx context.Context, k K) (V, error), opts ...Option) *RefreshCache[K, V] {
+	return &RefreshCache[K, V]{
+		load:    load,
+		opts:    opts,
+		clock:   newOptions(opts).clock,
+		entries: map[K]*refreshEntry[V]{},
+	}
+}
+
+// GetFresh returns the value for k if it is at most maxStale old.
+// Otherwise, it starts a refresh, unless one is in flight already, and
+// waits for it until ctx is done. There are three outcomes:
+//
+//   - Fresh: the cached value was recent enough, or the refresh finished
+//     in time. The result has no StaleInfo.
+//   - Stale: ctx was done before the refresh finished, and an older value
+//     exists. The result carries the older value and a StaleInfo, and the
+//     error is nil. The refresh goes on and updates the cache.
+//   - Error: the refresh failed, or ctx was done and there is no older
+//     value. GetFresh returns the error of the refresh, or ErrTimeout or
+//     ErrCanceled.
+func (c *RefreshCache[K, V]) GetFresh(ctx context.Context, k K, maxStale time.Duration) (FreshResult[V], error) {
+	c.mu.Lock()
+	e := c.entry(k)
+	if e.ok && c.clock.Now().Sub(e.loaded) <= maxStale {
+		v, rev := e.value, e.rev
+		c.mu.Unlock()
+		return FreshResult[V]{Value: v, Rev: rev}, nil
+	}
+	stale, rev, loaded, hasStale := e.value, e.rev, e.loaded, e.ok
+	f := e.refresh
+	start := f == nil
+	if start {
+		// The refresh starts only after c.mu is released, because an
+		// executor may run load right away on this goroutine.
+		f = Lazy(func(ctx context.Context) (Revision[V], error) {
+			v, err := c.load(ctx, k)
+			if err != nil {
+				return Revision[V]{}, err
+			}
+			// Store the value before the Future settles, so that
+			// readers woken by it find the cache up to date.
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			e.value, e.loaded, e.ok = v, c.clock.Now(), true
+			e.rev++
+			if e.changed != nil {
+				close(e.changed)
+				e.changed = nil
+			}
+			return Revision[V]{Value: v, Rev: e.rev}, nil
+		}, c.opts...)
+		e.refresh = f
+	}
+	c.mu.Unlock()
+	if start {
+		f.core.AddCallback(func(Revision[V], error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			e.refresh = nil
+		})
+		f.ensureStarted()
+	}
+
+	r, err := f.GetWithContext(ctx)
+	switch {
+	case err == nil:
+		return FreshResult[V]{Value: r.Value, Rev: r.Rev}, nil
+	case hasStale && !isSettled(f):
+		return FreshResult[V]{
+			Value: stale,
+			Rev:   rev,
+			Stale: &StaleInfo{Age: c.clock.Now().Sub(loaded), Err: err},
+		}, nil
+	}
+	return FreshResult[V]{}, err
+}
+
+// TryGetIfNewer returns the cached value for k and its revision if the
+// revision is newer than lastRev. Otherwise, it returns right away with
+// notModified set. It never loads a value.
+func (c *RefreshCache[K, V]) TryGetIfNewer(k K, lastRev uint64) (v V, rev uint64, notModified bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.entry(k)
+	return e.value, e.rev, e.rev <= lastRev
+}
+
+// GetIfNewer is like TryGetIfNewer but blocks until a value newer than
+// lastRev has been loaded for k. It does not start a load itself; values
+// arrive through GetFresh. If ctx is done first, GetIfNewer returns the
+// cached value with notModified set and ErrCanceled or ErrTimeout,
+// depending on ctx.Err().
+func (c *RefreshCache[K, V]) GetIfNewer(ctx context.Context, k K, lastRev uint64) (v V, rev uint64, notModified bool, err error) {
+	for {
+		c.mu.Lock()
+		e := c.entry(k)
+		v, rev = e.value, e.rev
+		if rev > lastRev {
+			c.mu.Unlock()
+			return v, rev, false, nil
+		}
+		if e.changed == nil {
+			e.changed = make(chan struct{})
+		}
+		changed := e.changed
+		c.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return v, rev, true, contextError(ctx)
+		}
+	}
+}
+
+// entry returns the entry for k, creating it if needed. c.mu must be held.
+func (c *RefreshCache[K, V]) entry(k K) *refreshEntry[V] {
+	e := c.entries[k]
+	if e == nil {
+		e = &refreshEntry[V]{}
+		c.entries[k] = e
+	}
+	return e
+}
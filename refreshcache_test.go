@@ -0,0 +1,216 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+// loader is a load function for a RefreshCache whose calls return the
+// results sent to it.
+type loader struct {
+	calls   atomic.Int32
+	results chan loadResult
+}
+
+type loadResult struct {
+	v   int
+	err error
+}
+
+func newLoader() *loader {
+	return &loader{results: make(chan loadResult, 1)}
+}
+
+func (l *loader) load(ctx context.Context, k string) (int, error) {
+	l.calls.Add(1)
+	r := <-l.results
+	return r.v, r.err
+}
+
+func TestRefreshCacheGetFresh(t *testing.T) {
+	errLoad := errors.New("load failed")
+	const maxStale = time.Minute
+	for _, c := range []struct {
+		name     string
+		cached   bool          // an older value exists
+		age      time.Duration // age of the older value
+		inTime   bool          // the refresh finishes before the deadline
+		refresh  loadResult
+		want     int
+		wantErr  error
+		stale    bool
+		wantLoad bool
+	}{
+		{name: "FreshCached", cached: true, age: maxStale, want: 1},
+		{name: "StaleRefreshInTime", cached: true, age: 2 * maxStale, inTime: true, refresh: loadResult{v: 2}, want: 2, wantLoad: true},
+		{name: "StaleRefreshLate", cached: true, age: 2 * maxStale, refresh: loadResult{v: 2}, want: 1, stale: true, wantLoad: true},
+		{name: "StaleRefreshFails", cached: true, age: 2 * maxStale, inTime: true, refresh: loadResult{err: errLoad}, wantErr: errLoad, wantLoad: true},
+		{name: "AbsentRefreshInTime", inTime: true, refresh: loadResult{v: 2}, want: 2, wantLoad: true},
+		{name: "AbsentRefreshLate", refresh: loadResult{v: 2}, wantErr: futures.ErrTimeout, wantLoad: true},
+		{name: "AbsentRefreshFails", inTime: true, refresh: loadResult{err: errLoad}, wantErr: errLoad, wantLoad: true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			start := time.Now()
+			clock := futuretest.NewFakeClock(start)
+			l := newLoader()
+			cache := futures.NewRefreshCache(l.load, futures.WithClock(clock))
+			if c.cached {
+				l.results <- loadResult{v: 1}
+				if _, err := cache.GetFresh(context.Background(), "k", maxStale); err != nil {
+					t.Fatalf("priming the cache: %v", err)
+				}
+				clock.Advance(c.age)
+			}
+			calls := l.calls.Load()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			if c.inTime {
+				l.results <- c.refresh
+			}
+			r, err := cache.GetFresh(ctx, "k", maxStale)
+			if !c.inTime {
+				l.results <- c.refresh
+			}
+
+			if loaded := l.calls.Load() > calls; loaded != c.wantLoad {
+				t.Errorf("refresh started = %v, want %v", loaded, c.wantLoad)
+			}
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) {
+					t.Errorf("err = %v, want %v", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil || r.Value != c.want {
+				t.Fatalf("GetFresh = %v, %v; want %d, nil", r.Value, err, c.want)
+			}
+			if !c.stale {
+				if r.Stale != nil {
+					t.Errorf("Stale = %+v for a fresh value", *r.Stale)
+				}
+				return
+			}
+			if r.Stale == nil || r.Stale.Age != c.age || !errors.Is(r.Stale.Err, futures.ErrTimeout) {
+				t.Fatalf("Stale = %+v, want age %v and ErrTimeout", r.Stale, c.age)
+			}
+			// The late refresh still updates the cache.
+			eventually(t, func() bool {
+				r, err := cache.GetFresh(context.Background(), "k", maxStale)
+				return err == nil && r.Value == c.refresh.v && r.Stale == nil
+			}, "the late refresh did not update the cache")
+		})
+	}
+}
+
+func TestRefreshCacheSharesRefresh(t *testing.T) {
+	l := newLoader()
+	cache := futures.NewRefreshCache(l.load)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if r, err := cache.GetFresh(context.Background(), "k", time.Minute); err != nil || r.Value != 7 {
+				t.Errorf("GetFresh = %v, %v; want 7, nil", r.Value, err)
+			}
+		}()
+	}
+	eventually(t, func() bool { return l.calls.Load() == 1 }, "no refresh started")
+	l.results <- loadResult{v: 7}
+	wg.Wait()
+	if n := l.calls.Load(); n != 1 {
+		t.Errorf("load called %d times, want 1", n)
+	}
+}
+
+func TestRefreshCacheRevisions(t *testing.T) {
+	l := newLoader()
+	cache := futures.NewRefreshCache(l.load)
+	if _, rev, notModified := cache.TryGetIfNewer("k", 0); rev != 0 || !notModified {
+		t.Fatalf("TryGetIfNewer before a load: rev %d, notModified %v; want 0, true", rev, notModified)
+	}
+
+	l.results <- loadResult{v: 1}
+	if r, err := cache.GetFresh(context.Background(), "k", 0); err != nil || r.Value != 1 || r.Rev != 1 {
+		t.Fatalf("GetFresh = %+v, %v; want value 1, rev 1", r, err)
+	}
+	if v, rev, notModified := cache.TryGetIfNewer("k", 0); v != 1 || rev != 1 || notModified {
+		t.Errorf("TryGetIfNewer(0) = %d, %d, %v; want 1, 1, false", v, rev, notModified)
+	}
+	if _, _, notModified := cache.TryGetIfNewer("k", 1); !notModified {
+		t.Error("TryGetIfNewer(1) is modified, want not modified")
+	}
+
+	// A failed load keeps the revision.
+	l.results <- loadResult{err: errors.New("load failed")}
+	cache.GetFresh(context.Background(), "k", 0)
+	if _, rev, _ := cache.TryGetIfNewer("k", 0); rev != 1 {
+		t.Errorf("rev = %d after a failed load, want 1", rev)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if v, rev, notModified, err := cache.GetIfNewer(ctx, "k", 1); v != 1 || rev != 1 || !notModified || !errors.Is(err, futures.ErrTimeout) {
+		t.Errorf("GetIfNewer(1) = %d, %d, %v, %v; want 1, 1, true, ErrTimeout", v, rev, notModified, err)
+	}
+}
+
+func TestRefreshCacheSyncExecutor(t *testing.T) {
+	l := newLoader()
+	cache := futures.NewRefreshCache(l.load, futures.WithExecutor(futures.SyncExecutor))
+	for want := 1; want <= 2; want++ {
+		l.results <- loadResult{v: want}
+		r, err := cache.GetFresh(context.Background(), "k", 0)
+		if err != nil || r.Value != want || r.Rev != uint64(want) {
+			t.Fatalf("GetFresh = %+v, %v; want value %d, rev %d", r, err, want, want)
+		}
+	}
+}
+
+// TestRefreshCacheGetIfNewerRace races loads of one key against many
+// readers that long-poll it. Every reader must see revisions in
+// increasing order, values that match them, and finally the last one.
+func TestRefreshCacheGetIfNewerRace(t *testing.T) {
+	const loads, readers = 100, 20
+	var n atomic.Int32
+	cache := futures.NewRefreshCache(func(ctx context.Context, k string) (int, error) {
+		return int(n.Add(1)) * 10, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var last uint64
+			for last < loads {
+				v, rev, notModified, err := cache.GetIfNewer(context.Background(), "k", last)
+				if err != nil || notModified || rev <= last {
+					t.Errorf("GetIfNewer(%d) = rev %d, %v, %v", last, rev, notModified, err)
+					return
+				}
+				if v != int(rev)*10 {
+					t.Errorf("rev %d has value %d, want %d", rev, v, rev*10)
+				}
+				last = rev
+			}
+		}()
+	}
+	for i := 0; i < loads; i++ {
+		if _, err := cache.GetFresh(context.Background(), "k", 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+	if _, rev, _ := cache.TryGetIfNewer("k", 0); rev != loads {
+		t.Errorf("final rev = %d, want %d", rev, loads)
+	}
+}
@@ -0,0 +1,55 @@
+package futures
+
+import (
+	"context"
+	"sync"
+)
+
+// Replayable computes a value once and replays it to any number of
+// subscribers, including those that subscribe long after the value is
+// known.
+type Replayable[T any] struct {
+	fn   func() T
+	opts []Option
+	once sync.Once
+	fut  *Future[T]
+}
+
+// NewReplayable returns a Replayable for the result of fn. fn runs on the
+// first call to Subscribe, not before.
+func NewReplayable[T any](fn func() T, opts ...Option) *Replayable[T] {
+	return &Replayable[T]{fn: fn, opts: opts}
+}
+
+// Subscribe returns a Future for the value of r. The first call starts the
+// computation. Once the value is known, Subscribe returns a Future that has
+// already resolved.
+//
+// Each subscriber gets a Future of its own, so canceling it does not affect
+// the computation or other subscribers.
+func (r *Replayable[T]) Subscribe() *Future[T] {
+	r.once.Do(func() {
+		r.fut = New(func(ctx context.Context) (T, error) {
+			return r.fn(), nil
+		}, r.opts...)
+	})
+	return follow(r.fut)
+}
+
+// follow returns a new Future that settles like src.
+func follow[T any](src *Future[T]) *Future[T] {
+	select {
+	case <-src.Done():
+		return settled(src.core.value, src.core.err)
+	default:
+	}
+	out := newFuture[T]()
+	go func() {
+		select {
+		case <-src.core.done:
+			out.settle(src.core.value, src.core.err)
+		case <-out.core.done:
+		}
+	}()
+	return out
+}
@@ -0,0 +1,63 @@
+package futures_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestReplayable(t *testing.T) {
+	var calls atomic.Int32
+	r := futures.NewReplayable(func() int {
+		calls.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		return 42
+	})
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("fn ran %d times before the first Subscribe", n)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := r.Subscribe().Get(); v != 42 || err != nil {
+				t.Errorf("Get = %d, %v; want 42, nil", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	time.Sleep(200 * time.Millisecond)
+	late := r.Subscribe()
+	select {
+	case <-late.Done():
+	default:
+		t.Fatal("late subscriber had to wait")
+	}
+	if v, _ := late.Get(); v != 42 {
+		t.Errorf("late Get = %d, want 42", v)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fn ran %d times, want 1", n)
+	}
+}
+
+func TestReplayableCancelSubscriber(t *testing.T) {
+	release := make(chan struct{})
+	r := futures.NewReplayable(func() int {
+		<-release
+		return 1
+	})
+	first := r.Subscribe()
+	second := r.Subscribe()
+	first.Cancel()
+	close(release)
+	if v, err := second.Get(); v != 1 || err != nil {
+		t.Errorf("second subscriber: Get = %d, %v; want 1, nil", v, err)
+	}
+}
@@ -0,0 +1,83 @@
+package futures
+
+import (
+	"context"
+	"errors"
+)
+
+var errNilFuture = errors.New("futures: function returned a nil Future")
+
+// Retry calls fn and awaits the Future it returns. If that Future fails,
+// Retry calls fn again, up to maxAttempts times in total, without delay.
+// The returned Future resolves to the first successful value, or fails
+// with the error of the last attempt.
+//
+// A maxAttempts below 1 counts as 1, which means no retry. A panic in fn,
+// or fn returning a nil Future, counts as a failed attempt; a panic fails
+// the attempt with a *PanicError.
+func Retry[T any](fn func() *Future[T], maxAttempts int) *Future[T] {
+	policy := ConstantBackoff(0).WithMaxAttempts(max(maxAttempts, 1))
+	return retry(context.Background(), fn, policy, SystemClock)
+}
+
+// RetryWithBackoff is like Retry but waits between attempts as long as
+// policy says, and stops once policy returns a negative delay. Use
+// Backoff.WithMaxAttempts to limit the number of attempts; without a
+// limit, RetryWithBackoff retries until an attempt succeeds. Pass
+// WithClock to replace the clock used for waiting.
+func RetryWithBackoff[T any](fn func() *Future[T], policy BackoffPolicy, opts ...Option) *Future[T] {
+	return RetryWithBackoffContext(context.Background(), fn, policy, opts...)
+}
+
+// RetryWithBackoffContext is like RetryWithBackoff but stops retrying when
+// ctx is done. The attempt in flight is then canceled, and the returned
+// Future fails with ErrCanceled or ErrTimeout. Canceling the returned
+// Future has the same effect.
+func RetryWithBackoffContext[T any](ctx context.Context, fn func() *Future[T], policy BackoffPolicy, opts ...Option) *Future[T] {
+	return retry(ctx, fn, policy, newOptions(opts).clock)
+}
+
+func retry[T any](ctx context.Context, fn func() *Future[T], policy BackoffPolicy, clock Clock) *Future[T] {
+	out := newFuture[T]()
+	ctx, stop := context.WithCancelCause(ctx)
+	out.cancel = stop
+	go func() {
+		defer stop(nil)
+		var v T
+		var err error
+		for attempt := 1; ; attempt++ {
+			f := attemptFuture(fn)
+			v, err = f.GetWithContext(ctx)
+			if err == nil {
+				break
+			}
+			if ctx.Err() != nil {
+				f.Cancel()
+				err = contextError(ctx)
+				break
+			}
+			d := policy.Delay(attempt)
+			if d < 0 {
+				break
+			}
+			if err = sleep(ctx, clock, d); err != nil {
+				break
+			}
+		}
+		out.settle(v, err)
+	}()
+	return out
+}
+
+// attemptFuture calls fn and turns a panic or a nil Future into a failed
+// Future.
+func attemptFuture[T any](fn func() *Future[T]) *Future[T] {
+	f, err := catchPanic(func() (*Future[T], error) { return fn(), nil })
+	switch {
+	case err != nil:
+		return Failed[T](err)
+	case f == nil:
+		return Failed[T](errNilFuture)
+	}
+	return f
+}
@@ -0,0 +1,192 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	v, err := futures.Retry(func() *futures.Future[int] {
+		calls++
+		if calls < 3 {
+			return futures.Failed[int](errors.New("transient"))
+		}
+		return futures.Completed(calls)
+	}, 5).Get()
+	if err != nil || v != 3 {
+		t.Fatalf("Retry = %d, %v; want 3, nil", v, err)
+	}
+	if calls != 3 {
+		t.Errorf("fn called %d times, want 3", calls)
+	}
+}
+
+func TestRetryLastError(t *testing.T) {
+	calls := 0
+	_, err := futures.Retry(func() *futures.Future[int] {
+		calls++
+		return futures.Failed[int](fmt.Errorf("attempt %d", calls))
+	}, 4).Get()
+	if err == nil || err.Error() != "attempt 4" {
+		t.Fatalf("Retry: err = %v, want the error of attempt 4", err)
+	}
+	if calls != 4 {
+		t.Errorf("fn called %d times, want 4", calls)
+	}
+}
+
+func TestRetryAtLeastOnce(t *testing.T) {
+	for _, n := range []int{-1, 0, 1} {
+		calls := 0
+		futures.Retry(func() *futures.Future[int] {
+			calls++
+			return futures.Failed[int](errors.New("fail"))
+		}, n).Get()
+		if calls != 1 {
+			t.Errorf("maxAttempts %d: fn called %d times, want 1", n, calls)
+		}
+	}
+}
+
+func TestRetryPanicAndNil(t *testing.T) {
+	calls := 0
+	v, err := futures.Retry(func() *futures.Future[int] {
+		calls++
+		switch calls {
+		case 1:
+			panic("boom")
+		case 2:
+			return nil
+		}
+		return futures.Completed(42)
+	}, 3).Get()
+	if err != nil || v != 42 {
+		t.Fatalf("Retry = %d, %v; want 42, nil", v, err)
+	}
+
+	_, err = futures.Retry(func() *futures.Future[int] { panic("boom") }, 2).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) || pe.Value() != "boom" {
+		t.Errorf("Retry: err = %v, want a PanicError with the panic value", err)
+	}
+	_, err = futures.Retry(func() *futures.Future[int] { return nil }, 2).Get()
+	if err == nil {
+		t.Error("Retry with nil futures succeeded")
+	}
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	var calls atomic.Int32
+	policy := futures.ExponentialBackoff(time.Second, 2, 0).WithMaxAttempts(3)
+	f := futures.RetryWithBackoff(func() *futures.Future[int] {
+		calls.Add(1)
+		return futures.Failed[int](errors.New("unavailable"))
+	}, policy, futures.WithClock(clock))
+
+	clock.BlockUntil(1)
+	clock.Advance(999 * time.Millisecond)
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("%d attempts before the first delay passed, want 1", n)
+	}
+	clock.Advance(time.Millisecond)
+	clock.BlockUntil(1)
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("%d attempts after the first delay, want 2", n)
+	}
+	clock.Advance(2 * time.Second)
+	if _, err := f.Get(); err == nil || err.Error() != "unavailable" {
+		t.Fatalf("RetryWithBackoff: err = %v, want unavailable", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("%d attempts, want 3", n)
+	}
+}
+
+func TestRetryWithBackoffContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attemptStarted := make(chan struct{})
+	attemptCanceled := make(chan struct{})
+	f := futures.RetryWithBackoffContext(ctx, func() *futures.Future[int] {
+		return futures.New(func(ctx context.Context) (int, error) {
+			close(attemptStarted)
+			<-ctx.Done()
+			close(attemptCanceled)
+			return 0, ctx.Err()
+		})
+	}, futures.ConstantBackoff(time.Hour))
+
+	<-attemptStarted
+	cancel()
+	if _, err := f.Get(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("RetryWithBackoffContext: err = %v, want context.Canceled", err)
+	}
+	select {
+	case <-attemptCanceled:
+	case <-time.After(time.Second):
+		t.Fatal("the attempt in flight was not canceled")
+	}
+}
+
+func TestRetryWithBackoffContextDuringDelay(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	ctx, cancel := context.WithCancel(context.Background())
+	f := futures.RetryWithBackoffContext(ctx, func() *futures.Future[int] {
+		return futures.Failed[int](errors.New("unavailable"))
+	}, futures.ConstantBackoff(time.Hour), futures.WithClock(clock))
+
+	clock.BlockUntil(1)
+	cancel()
+	if _, err := f.Get(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("RetryWithBackoffContext: err = %v, want context.Canceled", err)
+	}
+}
+
+func TestRetryWithBackoffCancel(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	var calls atomic.Int32
+	f := futures.RetryWithBackoff(func() *futures.Future[int] {
+		calls.Add(1)
+		return futures.Failed[int](errors.New("unavailable"))
+	}, futures.ConstantBackoff(time.Millisecond), futures.WithClock(clock))
+
+	clock.BlockUntil(1)
+	f.Cancel()
+	eventually(t, func() bool { return clock.Pending() == 0 }, "the delay was not stopped")
+	clock.Advance(time.Hour)
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fn called %d times after Cancel, want 1", n)
+	}
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want ErrCanceled", err)
+	}
+}
+
+func TestRetryCancelAttemptInFlight(t *testing.T) {
+	attemptStarted := make(chan struct{})
+	attemptCanceled := make(chan struct{})
+	f := futures.Retry(func() *futures.Future[int] {
+		return futures.New(func(ctx context.Context) (int, error) {
+			close(attemptStarted)
+			<-ctx.Done()
+			close(attemptCanceled)
+			return 0, ctx.Err()
+		})
+	}, 3)
+
+	<-attemptStarted
+	f.Cancel()
+	select {
+	case <-attemptCanceled:
+	case <-time.After(time.Second):
+		t.Fatal("the attempt in flight was not canceled")
+	}
+}
@@ -0,0 +1,118 @@
+package futures
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Semaphore limits how many computations run at the same time. It can be
+// shared between futures of different types, and between the parts of a
+// program that talk to the same downstream system.
+//
+// Acquire and Release use it directly. LimitedNew, or WithExecutor with
+// the Semaphore as the Executor, limit the computations of futures; their
+// goroutines are only started once a permit is free, so that waiting
+// computations cost no goroutine. Permits are granted in the order they
+// were asked for.
+type Semaphore struct {
+	mu      sync.Mutex
+	size    int
+	free    int
+	waiters []*semWaiter
+}
+
+// semWaiter is a request for a permit. grant is called once the permit
+// has been handed over.
+type semWaiter struct {
+	grant func()
+}
+
+// NewSemaphore returns a Semaphore with n permits. An n below 1 counts
+// as 1.
+func NewSemaphore(n int) *Semaphore {
+	n = max(n, 1)
+	return &Semaphore{size: n, free: n}
+}
+
+// Acquire blocks until a permit is free and takes it. If ctx is done
+// first, Acquire takes no permit and returns ErrCanceled or ErrTimeout,
+// depending on ctx.Err().
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	granted := make(chan struct{})
+	w := &semWaiter{grant: func() { close(granted) }}
+	if s.take(w) {
+		return nil
+	}
+	select {
+	case <-granted:
+		return nil
+	case <-ctx.Done():
+	}
+	s.mu.Lock()
+	i := slices.Index(s.waiters, w)
+	if i >= 0 {
+		s.waiters = slices.Delete(s.waiters, i, i+1)
+	}
+	s.mu.Unlock()
+	if i < 0 {
+		// The permit was granted while ctx was done; pass it on.
+		s.Release()
+	}
+	return contextError(ctx)
+}
+
+// Release returns a permit taken by Acquire. Release panics if no permit
+// is taken.
+func (s *Semaphore) Release() {
+	s.mu.Lock()
+	if len(s.waiters) > 0 {
+		w := s.waiters[0]
+		s.waiters[0] = nil
+		s.waiters = s.waiters[1:]
+		s.mu.Unlock()
+		w.grant()
+		return
+	}
+	if s.free == s.size {
+		s.mu.Unlock()
+		panic("futures: Semaphore.Release without Acquire")
+	}
+	s.free++
+	s.mu.Unlock()
+}
+
+// Go implements Executor. It runs fn in a new goroutine as soon as a
+// permit is free, and releases the permit when fn returns. Go never
+// blocks.
+func (s *Semaphore) Go(fn func()) {
+	w := &semWaiter{grant: func() {
+		go func() {
+			defer s.Release()
+			fn()
+		}()
+	}}
+	if s.take(w) {
+		w.grant()
+	}
+}
+
+// take takes a permit if one is free and nobody is waiting, and reports
+// whether it did. Otherwise it queues w.
+func (s *Semaphore) take(w *semWaiter) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.free > 0 && len(s.waiters) == 0 {
+		s.free--
+		return true
+	}
+	s.waiters = append(s.waiters, w)
+	return false
+}
+
+// LimitedNew is like New but starts fn only once sem has a free permit,
+// and returns the permit when fn returns. The options apply as in New,
+// except that WithExecutor is overridden.
+func LimitedNew[T any](sem *Semaphore, fn func(ctx context.Context) (T, error), opts ...Option) *Future[T] {
+	return New(fn, append(opts[:len(opts):len(opts)], WithExecutor(sem))...)
+}
@@ -0,0 +1,117 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestSemaphoreAcquireRelease(t *testing.T) {
+	sem := futures.NewSemaphore(1)
+	if err := sem.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	acquired := make(chan struct{})
+	go func() {
+		sem.Acquire(context.Background())
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("a second Acquire succeeded with one permit")
+	case <-time.After(10 * time.Millisecond):
+	}
+	sem.Release()
+	<-acquired
+	sem.Release()
+}
+
+func TestSemaphoreAcquireContext(t *testing.T) {
+	sem := futures.NewSemaphore(1)
+	sem.Acquire(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if err := sem.Acquire(ctx); !errors.Is(err, futures.ErrTimeout) {
+		t.Errorf("err = %v, want ErrTimeout", err)
+	}
+	// The abandoned request must not hold on to a permit.
+	sem.Release()
+	if err := sem.Acquire(context.Background()); err != nil {
+		t.Errorf("Acquire after an abandoned request: %v", err)
+	}
+}
+
+func TestSemaphoreReleaseWithoutAcquire(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Release without Acquire did not panic")
+		}
+	}()
+	futures.NewSemaphore(1).Release()
+}
+
+func TestLimitedNew(t *testing.T) {
+	const limit = 2
+	sem := futures.NewSemaphore(limit)
+	var running, peak atomic.Int32
+	track := func() {
+		n := running.Add(1)
+		for {
+			m := peak.Load()
+			if n <= m || peak.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+	}
+	// Futures of different types share the limit.
+	var ints []*futures.Future[int]
+	var strs []*futures.Future[string]
+	for i := 0; i < 10; i++ {
+		i := i
+		ints = append(ints, futures.LimitedNew(sem, func(context.Context) (int, error) {
+			track()
+			return i, nil
+		}))
+		strs = append(strs, futures.LimitedNew(sem, func(context.Context) (string, error) {
+			track()
+			return "ok", nil
+		}))
+	}
+	for i, f := range ints {
+		if v, err := f.Get(); v != i || err != nil {
+			t.Errorf("ints[%d].Get = %d, %v", i, v, err)
+		}
+	}
+	for i, f := range strs {
+		if v, err := f.Get(); v != "ok" || err != nil {
+			t.Errorf("strs[%d].Get = %q, %v", i, v, err)
+		}
+	}
+	if n := peak.Load(); n > limit {
+		t.Errorf("%d computations ran at once, limit is %d", n, limit)
+	}
+}
+
+func TestLimitedNewStartsNoGoroutineWhileWaiting(t *testing.T) {
+	sem := futures.NewSemaphore(1)
+	sem.Acquire(context.Background())
+	var started atomic.Bool
+	f := futures.LimitedNew(sem, func(context.Context) (int, error) {
+		started.Store(true)
+		return 1, nil
+	})
+	time.Sleep(10 * time.Millisecond)
+	if started.Load() {
+		t.Fatal("the computation started without a permit")
+	}
+	sem.Release()
+	if v, err := f.Get(); v != 1 || err != nil {
+		t.Errorf("Get = %d, %v; want 1, nil", v, err)
+	}
+}
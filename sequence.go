@@ -0,0 +1,42 @@
+package futures
+
+import "context"
+
+// Sequence calls the functions in fns one after the other, each after the
+// Future returned by the previous one has resolved, and returns a Future
+// for their values in the order of fns. Use it for steps that must not
+// overlap, such as database migrations; to run futures concurrently, start
+// them all and await them.
+//
+// If a step fails, the returned Future fails with its error, and the
+// remaining functions are not called. A panic in a function, or a
+// function returning a nil Future, fails the step; a panic fails it with
+// a *PanicError.
+//
+// A single goroutine drives the whole sequence. Canceling the returned
+// Future cancels the step in flight and calls no further functions.
+func Sequence[T any](fns []func() *Future[T], opts ...Option) *Future[[]T] {
+	return New(func(ctx context.Context) ([]T, error) {
+		vs := make([]T, 0, len(fns))
+		for _, fn := range fns {
+			v, err := awaitStep(ctx, attemptFuture(fn))
+			if err != nil {
+				return nil, err
+			}
+			vs = append(vs, v)
+		}
+		return vs, nil
+	}, opts...)
+}
+
+// awaitStep awaits f on behalf of a computation with the context ctx. If
+// ctx is done first, awaitStep cancels f and returns the error of ctx.
+func awaitStep[T any](ctx context.Context, f *Future[T]) (T, error) {
+	v, err := f.GetWithContext(ctx)
+	if ctx.Err() != nil {
+		f.Cancel()
+		var zero T
+		return zero, contextError(ctx)
+	}
+	return v, err
+}
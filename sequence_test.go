@@ -0,0 +1,103 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestSequenceRunsStepsInOrder(t *testing.T) {
+	var running, overlaps atomic.Int32
+	var order []int
+	step := func(i int) func() *futures.Future[int] {
+		return func() *futures.Future[int] {
+			order = append(order, i)
+			return futures.New(func(ctx context.Context) (int, error) {
+				if running.Add(1) > 1 {
+					overlaps.Add(1)
+				}
+				defer running.Add(-1)
+				return i * 10, nil
+			})
+		}
+	}
+	v, err := futures.Sequence([]func() *futures.Future[int]{step(1), step(2), step(3)}).Get()
+	if err != nil || !reflect.DeepEqual(v, []int{10, 20, 30}) {
+		t.Errorf("Get = %v, %v; want [10 20 30], nil", v, err)
+	}
+	if !reflect.DeepEqual(order, []int{1, 2, 3}) {
+		t.Errorf("steps were called in order %v", order)
+	}
+	if n := overlaps.Load(); n != 0 {
+		t.Errorf("%d steps overlapped", n)
+	}
+}
+
+func TestSequenceStopsAtFailure(t *testing.T) {
+	errBoom := errors.New("boom")
+	called := false
+	_, err := futures.Sequence([]func() *futures.Future[int]{
+		func() *futures.Future[int] { return futures.Completed(1) },
+		func() *futures.Future[int] { return futures.Failed[int](errBoom) },
+		func() *futures.Future[int] {
+			called = true
+			return futures.Completed(3)
+		},
+	}).Get()
+	if err != errBoom {
+		t.Errorf("err = %v, want %v", err, errBoom)
+	}
+	if called {
+		t.Error("the step after the failure was called")
+	}
+}
+
+func TestSequenceStepPanics(t *testing.T) {
+	_, err := futures.Sequence([]func() *futures.Future[int]{
+		func() *futures.Future[int] { panic("bad step") },
+	}).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) || pe.Value() != "bad step" {
+		t.Errorf("err = %v, want a PanicError", err)
+	}
+}
+
+func TestSequenceEmpty(t *testing.T) {
+	v, err := futures.Sequence[int](nil).Get()
+	if err != nil || v == nil || len(v) != 0 {
+		t.Errorf("Get = %#v, %v; want an empty slice, nil", v, err)
+	}
+}
+
+func TestSequenceCancel(t *testing.T) {
+	started := make(chan struct{})
+	stepCanceled := make(chan struct{})
+	called := false
+	seq := futures.Sequence([]func() *futures.Future[int]{
+		func() *futures.Future[int] {
+			return futures.New(func(ctx context.Context) (int, error) {
+				close(started)
+				<-ctx.Done()
+				close(stepCanceled)
+				return 0, ctx.Err()
+			})
+		},
+		func() *futures.Future[int] {
+			called = true
+			return futures.Completed(2)
+		},
+	})
+	<-started
+	seq.Cancel()
+	if _, err := seq.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want ErrCanceled", err)
+	}
+	<-stepCanceled
+	if called {
+		t.Error("a step was called after cancellation")
+	}
+}
@@ -0,0 +1,48 @@
+package futures
+
+import "sync"
+
+// SharedFuture is a value that is computed once, on first demand, and then
+// handed to any number of readers, like shared_future in C++. Unlike
+// Broadcast, it needs no consumer count up front.
+type SharedFuture[T any] struct {
+	fn   func() T
+	once sync.Once
+	core Core[T]
+}
+
+// NewShared returns a SharedFuture for the result of fn. fn runs in a new
+// goroutine when Get or Done is called for the first time.
+func NewShared[T any](fn func() T) *SharedFuture[T] {
+	s := &SharedFuture[T]{fn: fn}
+	s.core.done = make(chan struct{})
+	return s
+}
+
+func (s *SharedFuture[T]) start() {
+	s.once.Do(func() {
+		go func() {
+			v, err := catchPanic(func() (T, error) { return s.fn(), nil })
+			s.core.Settle(v, err)
+		}()
+	})
+}
+
+// Get starts the computation if needed, blocks until it has finished, and
+// returns its result. All readers receive the same value. If fn panicked,
+// Get panics in every reader with a *PanicError.
+func (s *SharedFuture[T]) Get() T {
+	s.start()
+	v, err := s.core.Get()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Done starts the computation if needed and returns a channel that is
+// closed once the result is available.
+func (s *SharedFuture[T]) Done() <-chan struct{} {
+	s.start()
+	return s.core.done
+}
@@ -0,0 +1,66 @@
+package futures_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestSharedFuture(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	s := futures.NewShared(func() string {
+		calls.Add(1)
+		<-release
+		return "shared"
+	})
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("fn ran %d times before the first Get", n)
+	}
+
+	const readers = 100
+	var wg sync.WaitGroup
+	results := make(chan string, readers)
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- s.Get()
+		}()
+	}
+	eventually(t, func() bool { return calls.Load() == 1 }, "fn did not start")
+	close(release)
+	wg.Wait()
+	close(results)
+	for v := range results {
+		if v != "shared" {
+			t.Errorf("Get = %q, want shared", v)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("fn ran %d times, want 1", n)
+	}
+	select {
+	case <-s.Done():
+	default:
+		t.Error("Done is not closed after the computation")
+	}
+}
+
+func TestSharedFuturePanic(t *testing.T) {
+	s := futures.NewShared(func() int { panic("no value") })
+	for i := 0; i < 2; i++ {
+		func() {
+			defer func() {
+				var pe *futures.PanicError
+				if err, _ := recover().(error); !errors.As(err, &pe) || pe.Value() != "no value" {
+					t.Errorf("reader %d recovered %v, want a PanicError", i, err)
+				}
+			}()
+			s.Get()
+		}()
+	}
+}
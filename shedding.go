@@ -0,0 +1,70 @@
+package futures
+
+import (
+	"errors"
+	"path"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrShedding is the error of a Future that was not started because its
+// name or goroutine label matched a selector passed to SetShedding.
+var ErrShedding = errors.New("futures: shedding load")
+
+var (
+	// shedSelectors holds the active selectors. It is nil while nothing is
+	// shed, so that New only pays for an atomic load.
+	shedSelectors atomic.Pointer[[]string]
+	shedMu        sync.Mutex // serializes SetShedding
+)
+
+// SetShedding turns load shedding on or off for the futures that match
+// selector. While it is on, New, Lazy, and the other functions that start
+// a computation return a Future that has failed with ErrShedding, and the
+// computation never runs. Futures that are running already are not
+// affected.
+//
+// The selector is a pattern in the syntax of path.Match that is matched
+// against the name set by WithName and the label set by
+// WithGoroutineLabel. "*" matches every Future, including those without
+// a name. A malformed pattern matches nothing.
+func SetShedding(selector string, on bool) {
+	shedMu.Lock()
+	defer shedMu.Unlock()
+	var sels []string
+	if p := shedSelectors.Load(); p != nil {
+		sels = slices.Clone(*p)
+	}
+	i := slices.Index(sels, selector)
+	switch {
+	case on && i < 0:
+		sels = append(sels, selector)
+	case !on && i >= 0:
+		sels = slices.Delete(sels, i, i+1)
+	default:
+		return
+	}
+	if len(sels) == 0 {
+		shedSelectors.Store(nil)
+		return
+	}
+	shedSelectors.Store(&sels)
+}
+
+// shedding reports whether a Future with the given name and label is to
+// be shed.
+func shedding(name, label string) bool {
+	p := shedSelectors.Load()
+	if p == nil {
+		return false
+	}
+	for _, sel := range *p {
+		for _, s := range [...]string{name, label} {
+			if ok, _ := path.Match(sel, s); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
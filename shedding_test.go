@@ -0,0 +1,83 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestShedding(t *testing.T) {
+	var ran atomic.Int32
+	fn := func(ctx context.Context) (int, error) {
+		ran.Add(1)
+		return 1, nil
+	}
+	release := make(chan struct{})
+	running := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 2, nil
+	}, futures.WithName("batch-running"))
+
+	futures.SetShedding("batch-*", true)
+	defer futures.SetShedding("batch-*", false)
+
+	if _, err := futures.New(fn, futures.WithName("batch-import")).Get(); !errors.Is(err, futures.ErrShedding) {
+		t.Errorf("named future: err = %v, want ErrShedding", err)
+	}
+	if _, err := futures.Lazy(fn, futures.WithGoroutineLabel("batch-export")).Get(); !errors.Is(err, futures.ErrShedding) {
+		t.Errorf("labeled future: err = %v, want ErrShedding", err)
+	}
+	if n := ran.Load(); n != 0 {
+		t.Errorf("shed computations ran %d times", n)
+	}
+	if v, err := futures.New(fn, futures.WithName("checkout")).Get(); v != 1 || err != nil {
+		t.Errorf("unmatched future: Get = %v, %v; want 1, nil", v, err)
+	}
+	close(release)
+	if v, err := running.Get(); v != 2 || err != nil {
+		t.Errorf("running future: Get = %v, %v; want 2, nil", v, err)
+	}
+
+	futures.SetShedding("batch-*", false)
+	if v, err := futures.New(fn, futures.WithName("batch-import")).Get(); v != 1 || err != nil {
+		t.Errorf("after turning shedding off: Get = %v, %v; want 1, nil", v, err)
+	}
+}
+
+func TestSheddingToggledConcurrently(t *testing.T) {
+	defer futures.SetShedding("*", false)
+	stop := make(chan struct{})
+	toggled := make(chan struct{})
+	go func() {
+		defer close(toggled)
+		for on := true; ; on = !on {
+			select {
+			case <-stop:
+				return
+			default:
+				futures.SetShedding("*", on)
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				v, err := futures.New(func(ctx context.Context) (int, error) { return 1, nil }).Get()
+				if err == nil && v != 1 || err != nil && !errors.Is(err, futures.ErrShedding) {
+					t.Errorf("Get = %v, %v; want 1, nil or ErrShedding", v, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	<-toggled
+}
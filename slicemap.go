@@ -0,0 +1,40 @@
+package futures
+
+import "context"
+
+// SliceMap returns a Future for the slice that results from applying fn to
+// each element of the slice f resolves to, in order. fn is called for one
+// element after the other, on a goroutine of its own as with Then. The
+// first error returned by fn fails the returned Future, and fn is not
+// called for the remaining elements. If f fails, fn is not called at all.
+// If fn panics, the returned Future fails with a *PanicError.
+func SliceMap[T, R any](f *Future[[]T], fn func(T) (R, error)) *Future[[]R] {
+	return Then(f, func(xs []T) ([]R, error) {
+		rs := make([]R, len(xs))
+		for i, x := range xs {
+			r, err := fn(x)
+			if err != nil {
+				return nil, err
+			}
+			rs[i] = r
+		}
+		return rs, nil
+	})
+}
+
+// SliceMapConcurrent is like SliceMap but calls fn for all elements at
+// the same time, each on a goroutine of its own, as FanOut does. The
+// returned Future fails as soon as one call fails, without waiting for
+// the others; the calls in progress run to completion, but their results
+// are discarded. The order of the results is that of the elements.
+//
+// Canceling the returned Future cancels f if it is still pending, and the
+// FanOut otherwise. fn takes no context, so calls that are running go on
+// until they return.
+func SliceMapConcurrent[T, R any](f *Future[[]T], fn func(T) (R, error)) *Future[[]R] {
+	return FlatMap(f, func(xs []T) *Future[[]R] {
+		return FanOut(xs, func(x T) *Future[R] {
+			return New(func(context.Context) (R, error) { return fn(x) })
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package futures_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestSliceMapParsesStrings(t *testing.T) {
+	for name, sliceMap := range map[string]func(*futures.Future[[]string], func(string) (int, error)) *futures.Future[[]int]{
+		"SliceMap":           futures.SliceMap[string, int],
+		"SliceMapConcurrent": futures.SliceMapConcurrent[string, int],
+	} {
+		t.Run(name, func(t *testing.T) {
+			v, err := sliceMap(futures.Completed([]string{"3", "1", "2"}), strconv.Atoi).Get()
+			if err != nil || !reflect.DeepEqual(v, []int{3, 1, 2}) {
+				t.Errorf("Get = %v, %v; want [3 1 2], nil", v, err)
+			}
+
+			_, err = sliceMap(futures.Completed([]string{"1", "x", "3"}), strconv.Atoi).Get()
+			var numErr *strconv.NumError
+			if !errors.As(err, &numErr) || numErr.Num != "x" {
+				t.Errorf("err = %v, want the parse error for x", err)
+			}
+
+			errBoom := errors.New("boom")
+			if _, err := sliceMap(futures.Failed[[]string](errBoom), strconv.Atoi).Get(); err != errBoom {
+				t.Errorf("upstream failure: err = %v, want %v", err, errBoom)
+			}
+		})
+	}
+}
+
+func TestSliceMapShortCircuits(t *testing.T) {
+	var calls atomic.Int32
+	_, err := futures.SliceMap(futures.Completed([]string{"1", "x", "3", "4"}), func(s string) (int, error) {
+		calls.Add(1)
+		return strconv.Atoi(s)
+	}).Get()
+	if err == nil {
+		t.Fatal("no error")
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("fn was called %d times, want 2", n)
+	}
+}
+
+func TestSliceMapConcurrentRunsInParallel(t *testing.T) {
+	const n = 4
+	var running atomic.Int32
+	all := make(chan struct{})
+	f := futures.SliceMapConcurrent(futures.Completed(make([]int, n)), func(int) (int, error) {
+		if running.Add(1) == n {
+			close(all)
+		}
+		<-all
+		return 1, nil
+	})
+	select {
+	case <-f.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the calls did not run at the same time")
+	}
+}
+
+func TestSliceMapPanic(t *testing.T) {
+	_, err := futures.SliceMap(futures.Completed([]int{1}), func(int) (int, error) { panic("bad") }).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) {
+		t.Errorf("err = %v, want a PanicError", err)
+	}
+}
+
+// cancelCounter counts the futures that are canceled while it is
+// registered.
+type cancelCounter struct{ n atomic.Int32 }
+
+func (c *cancelCounter) OnCreate(string, string)         {}
+func (c *cancelCounter) OnResolve(string, time.Duration) {}
+func (c *cancelCounter) OnError(_ string, err error, _ time.Duration) {
+	if errors.Is(err, futures.ErrCanceled) {
+		c.n.Add(1)
+	}
+}
+
+func TestSliceMapConcurrentCancel(t *testing.T) {
+	const n = 3
+	counter := &cancelCounter{}
+	defer futures.AddInterceptor(counter)()
+	var running atomic.Int32
+	release := make(chan struct{})
+	defer close(release)
+	f := futures.SliceMapConcurrent(futures.Completed(make([]int, n)), func(int) (int, error) {
+		running.Add(1)
+		<-release
+		return 1, nil
+	})
+	eventually(t, func() bool { return running.Load() == n }, "the calls did not start")
+	f.Cancel()
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want ErrCanceled", err)
+	}
+	// The FanOut and the futures of the n calls are canceled while the
+	// calls are still blocked.
+	eventually(t, func() bool { return counter.n.Load() >= n+2 }, "canceling did not reach the FanOut")
+}
@@ -0,0 +1,125 @@
+package futures
+
+import (
+	"slices"
+	"time"
+)
+
+// SortedStream returns a Stream of the values of s, reordered according to
+// less, for sources whose values arrive slightly out of order.
+//
+// Every value is held back for up to bufferWindow after its arrival. When
+// the window of the oldest held value has passed, that value becomes the
+// watermark: it is emitted together with all held values that do not sort
+// after it, in sorted order. A value that arrives after a greater value
+// has been emitted is still emitted, only out of order.
+//
+// When s ends, the held values are emitted in sorted order, and the
+// returned Stream ends with the error of s. Closing the returned Stream
+// closes s. Pass WithStreamClock to replace the clock that measures the
+// window.
+func SortedStream[T any](s *Stream[T], less func(a, b T) bool, bufferWindow time.Duration, opts ...StreamOption) *Stream[T] {
+	o := newStreamOptions(0, opts)
+	out := newStream[T](o)
+	go sortStream(out, s, less, bufferWindow, o.clock)
+	return out
+}
+
+// sortStream runs the Stream returned by SortedStream.
+func sortStream[T any](out, s *Stream[T], less func(a, b T) bool, window time.Duration, clock Clock) {
+	type held struct {
+		v   T
+		due time.Time
+	}
+	compare := func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	}
+	// emit sends vs in sorted order.
+	emit := func(vs []T) bool {
+		slices.SortStableFunc(vs, compare)
+		for _, v := range vs {
+			if !out.send(v) {
+				return false
+			}
+		}
+		return true
+	}
+
+	var pending []held // in arrival order
+	// The timer signals expired once the window of pending[0] may have
+	// passed. A signal from a timer that was replaced is told apart by
+	// checking the due time.
+	var timer Timer
+	expired := make(chan struct{}, 1)
+	arm := func() {
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = clock.AfterFunc(pending[0].due.Sub(clock.Now()), func() {
+			select {
+			case expired <- struct{}{}:
+			default:
+			}
+		})
+	}
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	in := s.C()
+	for {
+		select {
+		case v, ok := <-in:
+			if !ok {
+				vs := make([]T, len(pending))
+				for i, h := range pending {
+					vs[i] = h.v
+				}
+				if emit(vs) {
+					out.close(s.Err())
+				}
+				return
+			}
+			pending = append(pending, held{v: v, due: clock.Now().Add(window)})
+			if len(pending) == 1 {
+				arm()
+			}
+		case <-expired:
+			if len(pending) == 0 || clock.Now().Before(pending[0].due) {
+				continue
+			}
+			for len(pending) > 0 && !clock.Now().Before(pending[0].due) {
+				mark := pending[0].v
+				var vs []T
+				rest := pending[:0]
+				for _, h := range pending {
+					if less(mark, h.v) {
+						rest = append(rest, h)
+					} else {
+						vs = append(vs, h.v)
+					}
+				}
+				clear(pending[len(rest):])
+				pending = rest
+				if !emit(vs) {
+					s.Close()
+					return
+				}
+			}
+			if len(pending) > 0 {
+				arm()
+			}
+		case <-out.quit:
+			s.Close()
+			out.close(nil)
+			return
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package futures_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+func receiveN(t *testing.T, s *futures.Stream[int], n int) []int {
+	t.Helper()
+	var got []int
+	for len(got) < n {
+		select {
+		case v, ok := <-s.C():
+			if !ok {
+				t.Fatalf("stream ended after %v, want %d values", got, n)
+			}
+			got = append(got, v)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %v, want %d values", got, n)
+		}
+	}
+	return got
+}
+
+func TestSortedStream(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Unix(0, 0))
+	ch := make(chan int)
+	defer close(ch)
+	sorted := futures.SortedStream(futures.StreamOf(ch), intLess, 20*time.Millisecond,
+		futures.WithStreamClock(clock))
+	ch <- 3
+	clock.BlockUntil(1)
+	ch <- 1
+	ch <- 2
+	ch <- 10 // makes sure that 2 has been received
+	clock.Advance(20*time.Millisecond - time.Nanosecond)
+	clock.Advance(time.Nanosecond)
+	if got := receiveN(t, sorted, 3); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("got %v, want [1 2 3]", got)
+	}
+}
+
+func TestSortedStreamHoldsUntilWindow(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Unix(0, 0))
+	ch := make(chan int)
+	sorted := futures.SortedStream(futures.StreamOf(ch), intLess, 10*time.Millisecond,
+		futures.WithStreamClock(clock))
+	ch <- 5
+	clock.BlockUntil(1)
+	clock.Advance(5 * time.Millisecond)
+	ch <- 9
+	ch <- 100 // makes sure that 9 has been received
+	clock.Advance(5 * time.Millisecond)
+	if got := receiveN(t, sorted, 1); got[0] != 5 {
+		t.Fatalf("got %v, want [5]", got)
+	}
+	// 9 is held until its own window has passed, so 7 sorts before it.
+	ch <- 7
+	ch <- 200
+	clock.Advance(time.Minute)
+	close(ch)
+	var got []int
+	for v := range sorted.C() {
+		got = append(got, v)
+	}
+	if want := []int{7, 9, 100, 200}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSortedStreamLateValue(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Unix(0, 0))
+	ch := make(chan int)
+	defer close(ch)
+	sorted := futures.SortedStream(futures.StreamOf(ch), intLess, 10*time.Millisecond,
+		futures.WithStreamClock(clock))
+	ch <- 5
+	clock.BlockUntil(1)
+	clock.Advance(10 * time.Millisecond)
+	if got := receiveN(t, sorted, 1); got[0] != 5 {
+		t.Fatalf("got %v, want [5]", got)
+	}
+	ch <- 9
+	clock.BlockUntil(1)
+	ch <- 4 // older than the watermark
+	ch <- 6
+	ch <- 100 // makes sure that 6 has been received
+	clock.Advance(10 * time.Millisecond)
+	if got, want := receiveN(t, sorted, 3), []int{4, 6, 9}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSortedStreamFlushesOnEnd(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 2
+	ch <- 3
+	ch <- 1
+	close(ch)
+	sorted := futures.SortedStream(futures.StreamOf(ch), intLess, time.Hour)
+	var got []int
+	for v := range sorted.C() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("got %v, want [1 2 3]", got)
+	}
+	if err := sorted.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestSortedStreamClose(t *testing.T) {
+	ch := make(chan int)
+	defer close(ch)
+	sorted := futures.SortedStream(futures.StreamOf(ch), intLess, time.Hour)
+	sorted.Close()
+	select {
+	case _, ok := <-sorted.C():
+		if ok {
+			t.Error("received a value after Close")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream did not end after Close")
+	}
+}
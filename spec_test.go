@@ -0,0 +1,463 @@
+package futures_test
+
+// The tests in this file specify the semantics of the package. Each test
+// checks one guarantee through the public API only, so the suite stays
+// valid across internal redesigns. SPEC.md is generated from the names and
+// doc comments of the tests; run go generate after changing them.
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+var errSpec = errors.New("spec failure")
+
+// pendingFuture returns a Future whose computation waits until its context
+// is done.
+func pendingFuture() *futures.Future[int] {
+	return futures.New(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+}
+
+// A Future settles at most once. Only the first settlement takes effect;
+// later attempts neither change the value nor the error.
+func TestSpec_SettlesOnce(t *testing.T) {
+	p := futures.NewPromise[int]()
+	p.Resolve(1)
+	p.Reject(errSpec)
+	p.Resolve(2)
+	if v, err := p.Future().Get(); v != 1 || err != nil {
+		t.Errorf("Get = %v, %v; want 1, nil", v, err)
+	}
+}
+
+// A failure is sticky: every read of a failed Future returns the same
+// error.
+func TestSpec_StickyFailure(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) { return 0, errSpec })
+	for i := 0; i < 3; i++ {
+		if _, err := f.Get(); err != errSpec {
+			t.Fatalf("Get #%d: err = %v, want %v", i, err, errSpec)
+		}
+	}
+	if err := f.Err(); err != errSpec {
+		t.Errorf("Err() = %v, want %v", err, errSpec)
+	}
+}
+
+// A value is sticky: every read of a resolved Future returns the same
+// value, without running the computation again.
+func TestSpec_StickyValue(t *testing.T) {
+	var runs atomic.Int32
+	f := futures.New(func(ctx context.Context) (int, error) {
+		return int(runs.Add(1)), nil
+	})
+	for i := 0; i < 3; i++ {
+		if v, err := f.Get(); v != 1 || err != nil {
+			t.Fatalf("Get #%d = %v, %v; want 1, nil", i, v, err)
+		}
+	}
+}
+
+// Once Done is closed, TryGet reports the final result, equal to what Get
+// returns.
+func TestSpec_DoneImpliesTryGetFinal(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) { return 5, nil })
+	<-f.Done()
+	v, err, ok := f.TryGet()
+	if !ok {
+		t.Fatal("TryGet reports a pending Future after Done was closed")
+	}
+	if gv, gerr := f.Get(); gv != v || gerr != err {
+		t.Errorf("TryGet = %v, %v; Get = %v, %v", v, err, gv, gerr)
+	}
+}
+
+// Once Done is closed, State reports Resolved or Rejected, never
+// Unsettled.
+func TestSpec_DoneImpliesSettledState(t *testing.T) {
+	ok := futures.New(func(ctx context.Context) (int, error) { return 1, nil })
+	<-ok.Done()
+	if s := ok.State(); s != futures.Resolved {
+		t.Errorf("State() = %v after success, want resolved", s)
+	}
+	failed := futures.New(func(ctx context.Context) (int, error) { return 0, errSpec })
+	<-failed.Done()
+	if s := failed.State(); s != futures.Rejected {
+		t.Errorf("State() = %v after failure, want rejected", s)
+	}
+}
+
+// While a Future is pending, Err returns nil, TryGet reports false, and
+// State reports Unsettled.
+func TestSpec_PendingReadsDoNotBlock(t *testing.T) {
+	f := pendingFuture()
+	defer f.Cancel()
+	if err := f.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+	if _, _, ok := f.TryGet(); ok {
+		t.Error("TryGet reports a settled Future")
+	}
+	if s := f.State(); s != futures.Unsettled {
+		t.Errorf("State() = %v, want unsettled", s)
+	}
+}
+
+// Err returns the same error as Get once the Future has settled.
+func TestSpec_ErrMatchesGet(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) { return 0, errSpec })
+	_, err := f.Get()
+	if f.Err() != err {
+		t.Errorf("Err() = %v, Get returned %v", f.Err(), err)
+	}
+}
+
+// Canceling a pending Future makes it fail with ErrCanceled, which
+// matches context.Canceled.
+func TestSpec_CancelPending(t *testing.T) {
+	f := pendingFuture()
+	f.Cancel()
+	_, err := f.Get()
+	if !errors.Is(err, futures.ErrCanceled) || !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want ErrCanceled matching context.Canceled", err)
+	}
+}
+
+// Canceling a settled Future does not change its result.
+func TestSpec_CancelAfterSettleHasNoEffect(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) { return 1, nil })
+	f.Get()
+	f.Cancel()
+	if v, err := f.Get(); v != 1 || err != nil {
+		t.Errorf("Get = %v, %v after Cancel; want 1, nil", v, err)
+	}
+}
+
+// Canceling a Future cancels the context of its running computation. A
+// computation that has not started yet never runs.
+func TestSpec_CancelPropagatesToComputation(t *testing.T) {
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+		return 0, ctx.Err()
+	})
+	<-started
+	f.Cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the computation's context was not canceled")
+	}
+}
+
+// The cause passed to CancelWithCause is the unwrapped error of the
+// Future, which still matches ErrCanceled.
+func TestSpec_CancelWithCauseUnwraps(t *testing.T) {
+	f := pendingFuture()
+	f.CancelWithCause(errSpec)
+	_, err := f.Get()
+	if !errors.Is(err, futures.ErrCanceled) || !errors.Is(err, errSpec) {
+		t.Errorf("err = %v, want ErrCanceled wrapping %v", err, errSpec)
+	}
+}
+
+// Canceling the context passed to NewWithContext cancels the Future.
+func TestSpec_ParentCancelPropagates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := futures.NewWithContext(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	cancel()
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want ErrCanceled", err)
+	}
+}
+
+// A Future whose context passes its deadline fails with ErrTimeout, which
+// matches context.DeadlineExceeded.
+func TestSpec_DeadlineIsTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	f := futures.NewWithContext(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	_, err := f.Get()
+	if !errors.Is(err, futures.ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want ErrTimeout matching context.DeadlineExceeded", err)
+	}
+}
+
+// A read that gives up because its own context is done does not affect
+// the Future; it can still be read and settle normally.
+func TestSpec_GivingUpLeavesFutureIntact(t *testing.T) {
+	release := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := f.GetWithContext(ctx); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("GetWithContext: err = %v, want ErrCanceled", err)
+	}
+	close(release)
+	if v, err := f.Get(); v != 1 || err != nil {
+		t.Errorf("Get = %v, %v; want 1, nil", v, err)
+	}
+}
+
+// A read that gives up because its context passed its deadline returns
+// ErrTimeout.
+func TestSpec_ReadDeadlineIsTimeout(t *testing.T) {
+	f := pendingFuture()
+	defer f.Cancel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := f.GetWithContext(ctx); !errors.Is(err, futures.ErrTimeout) {
+		t.Errorf("GetWithContext: err = %v, want ErrTimeout", err)
+	}
+}
+
+// Done returns the same channel on every call, before and after
+// settlement.
+func TestSpec_DoneIsStable(t *testing.T) {
+	p := futures.NewPromise[int]()
+	f := p.Future()
+	before := f.Done()
+	p.Resolve(1)
+	if f.Done() != before {
+		t.Error("Done returned a different channel after settlement")
+	}
+}
+
+// GetWithContext returns the result of a settled Future even if its
+// context is done already.
+func TestSpec_SettledWinsOverDoneContext(t *testing.T) {
+	f := futures.Completed(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if v, err := f.GetWithContext(ctx); v != 1 || err != nil {
+		t.Errorf("GetWithContext = %v, %v; want 1, nil", v, err)
+	}
+}
+
+// A result that the computation delivers after the Future was canceled is
+// discarded.
+func TestSpec_LateResultDiscarded(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	returned := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		defer close(returned)
+		close(started)
+		<-release
+		return 1, nil
+	})
+	<-started
+	f.Cancel()
+	close(release)
+	<-returned
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want ErrCanceled", err)
+	}
+}
+
+// The context of a computation is canceled once its Future has settled,
+// so work it started in the background stops.
+func TestSpec_ComputationContextEndsWithFuture(t *testing.T) {
+	ctxs := make(chan context.Context, 1)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		ctxs <- ctx
+		return 1, nil
+	})
+	f.Get()
+	ctx := <-ctxs
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the computation's context is still live")
+	}
+}
+
+// A panic in a computation makes the Future fail with a *PanicError
+// instead of crashing the program.
+func TestSpec_PanicBecomesPanicError(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) { panic("spec") })
+	var pe *futures.PanicError
+	if _, err := f.Get(); !errors.As(err, &pe) || pe.Value() != "spec" {
+		t.Errorf("err = %v, want a *PanicError", err)
+	}
+}
+
+// A computation that exits its goroutine without returning makes the
+// Future fail with ErrBrokenPromise.
+func TestSpec_GoexitBreaksPromise(t *testing.T) {
+	f := futures.New(func(ctx context.Context) (int, error) {
+		runtime.Goexit()
+		return 0, nil
+	})
+	if _, err := f.Get(); !errors.Is(err, futures.ErrBrokenPromise) {
+		t.Errorf("err = %v, want ErrBrokenPromise", err)
+	}
+}
+
+// A lazy Future does not run its computation until it is awaited.
+func TestSpec_LazyWaitsForReader(t *testing.T) {
+	var runs atomic.Int32
+	f := futures.Lazy(func(ctx context.Context) (int, error) {
+		runs.Add(1)
+		return 1, nil
+	})
+	time.Sleep(10 * time.Millisecond)
+	if n := runs.Load(); n != 0 {
+		t.Fatalf("computation ran %d times before anyone awaited it", n)
+	}
+	f.Get()
+	if n := runs.Load(); n != 1 {
+		t.Errorf("computation ran %d times, want 1", n)
+	}
+}
+
+// A lazy Future runs its computation once, no matter how many readers race
+// to be first.
+func TestSpec_LazyRunsOnce(t *testing.T) {
+	var runs atomic.Int32
+	f := futures.Lazy(func(ctx context.Context) (int, error) {
+		runs.Add(1)
+		return 1, nil
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.Get()
+		}()
+	}
+	wg.Wait()
+	if n := runs.Load(); n != 1 {
+		t.Errorf("computation ran %d times, want 1", n)
+	}
+}
+
+// A lazy Future that is canceled before anyone awaits it never runs its
+// computation.
+func TestSpec_CanceledLazyNeverRuns(t *testing.T) {
+	var runs atomic.Int32
+	f := futures.Lazy(func(ctx context.Context) (int, error) {
+		runs.Add(1)
+		return 1, nil
+	})
+	f.Cancel()
+	f.Get()
+	if n := runs.Load(); n != 0 {
+		t.Errorf("computation ran %d times, want 0", n)
+	}
+}
+
+// TryGet and State do not start a lazy Future.
+func TestSpec_PeekingDoesNotStartLazy(t *testing.T) {
+	var runs atomic.Int32
+	f := futures.Lazy(func(ctx context.Context) (int, error) {
+		runs.Add(1)
+		return 1, nil
+	})
+	defer f.Cancel()
+	f.TryGet()
+	f.State()
+	time.Sleep(10 * time.Millisecond)
+	if n := runs.Load(); n != 0 {
+		t.Errorf("computation ran %d times, want 0", n)
+	}
+}
+
+// Futures created by Completed and Failed are settled from the start.
+func TestSpec_CompletedAndFailedAreSettled(t *testing.T) {
+	if _, _, ok := futures.Completed(1).TryGet(); !ok {
+		t.Error("Completed returned a pending Future")
+	}
+	if _, err, ok := futures.Failed[int](errSpec).TryGet(); !ok || err != errSpec {
+		t.Error("Failed returned a pending Future or lost the error")
+	}
+}
+
+// Settling a Promise that has settled already fails with
+// ErrAlreadySettled.
+func TestSpec_PromiseRejectsSecondSettlement(t *testing.T) {
+	p := futures.NewPromise[int]()
+	if err := p.Resolve(1); err != nil {
+		t.Fatalf("first Resolve: %v", err)
+	}
+	if err := p.Reject(errSpec); !errors.Is(err, futures.ErrAlreadySettled) {
+		t.Errorf("Reject: err = %v, want ErrAlreadySettled", err)
+	}
+}
+
+// Closing the Producer of an unsettled Promise breaks the Promise.
+func TestSpec_ClosedProducerBreaksPromise(t *testing.T) {
+	p := futures.NewPromise[int]()
+	p.Producer().Close()
+	if _, err := p.Future().Get(); !errors.Is(err, futures.ErrBrokenPromise) {
+		t.Errorf("err = %v, want ErrBrokenPromise", err)
+	}
+}
+
+// Wait returns the error of the Future.
+func TestSpec_WaitReturnsError(t *testing.T) {
+	f := futures.Failed[int](errSpec)
+	if err := f.Wait(context.Background()); err != errSpec {
+		t.Errorf("Wait = %v, want %v", err, errSpec)
+	}
+}
+
+// All concurrent readers of a Future observe the same result.
+func TestSpec_ConcurrentReadersAgree(t *testing.T) {
+	release := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 9, nil
+	})
+	var wg sync.WaitGroup
+	var wrong atomic.Int32
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := f.Get(); v != 9 || err != nil {
+				wrong.Add(1)
+			}
+		}()
+	}
+	close(release)
+	wg.Wait()
+	if n := wrong.Load(); n > 0 {
+		t.Errorf("%d readers observed a different result", n)
+	}
+}
+
+// Each settle hook runs exactly once per Future, after settlement.
+func TestSpec_SettleHookRunsOnce(t *testing.T) {
+	var calls atomic.Int32
+	p := futures.NewPromise[int](futures.WithSettleHook(func(futures.SettleInfo) { calls.Add(1) }))
+	p.Resolve(1)
+	p.Reject(errSpec)
+	p.Future().Cancel()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("hook ran %d times, want 1", n)
+	}
+}
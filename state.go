@@ -0,0 +1,104 @@
+package futures
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Settlement describes whether and how a Future or a Promise has settled.
+type Settlement int
+
+const (
+	// Unsettled means that the Future is still pending.
+	Unsettled Settlement = iota
+	// Resolved means that the Future settled with a value. For a Promise,
+	// it means that Resolve won.
+	Resolved
+	// Rejected means that the Future settled with an error, including
+	// ErrCanceled and ErrTimeout. For a Promise, it means that Reject won,
+	// or that the Producer was closed first.
+	Rejected
+)
+
+func (s Settlement) String() string {
+	switch s {
+	case Unsettled:
+		return "unsettled"
+	case Resolved:
+		return "resolved"
+	case Rejected:
+		return "rejected"
+	}
+	return fmt.Sprintf("Settlement(%d)", int(s))
+}
+
+// State reports whether and how f has settled, without blocking. It does
+// not start a lazy Future. Once State has returned Resolved or Rejected,
+// Get returns the settled result without blocking.
+//
+// State is safe for concurrent use, like all methods of Future.
+func (f *Future[T]) State() Settlement {
+	if f.core.Err() != nil {
+		return Rejected
+	}
+	select {
+	case <-f.core.done:
+		return Resolved
+	default:
+		return Unsettled
+	}
+}
+
+// TryGet returns the result of f if f has settled, and ok reports whether
+// it has. TryGet never blocks and does not start a lazy Future. A call
+// that returns the result counts as reading f, for WasAwaited and
+// EnsureNoDrop.
+//
+// TryGet is safe for concurrent use, like all methods of Future.
+func (f *Future[T]) TryGet() (v T, err error, ok bool) {
+	select {
+	case <-f.core.done:
+		f.markAwaited()
+		return f.core.value, f.core.err, true
+	default:
+		var zero T
+		return zero, nil, false
+	}
+}
+
+// Duration returns the time from the start of the computation of f to its
+// settlement, as measured by the clock set by WithClock. The computation
+// of a lazy Future starts when it is first awaited; for a Promise, the
+// time starts with NewPromise. ok is false while f is pending. A Future
+// that settled before its computation started, or that was created
+// settled, reports a duration of 0.
+func (f *Future[T]) Duration() (d time.Duration, ok bool) {
+	f.core.mu.Lock()
+	defer f.core.mu.Unlock()
+	if !f.core.settled {
+		return 0, false
+	}
+	if f.started.IsZero() {
+		return 0, true
+	}
+	return f.ended.Sub(f.started), true
+}
+
+// String describes f for debugging: its name, its state, how long it took
+// to settle, and its error. It does not include the value.
+func (f *Future[T]) String() string {
+	var b strings.Builder
+	b.WriteString("future")
+	if f.name != "" {
+		fmt.Fprintf(&b, " %q", f.name)
+	}
+	b.WriteString(" " + f.State().String())
+	if d, ok := f.Duration(); ok {
+		fmt.Fprintf(&b, " in %v", d)
+	}
+	if err := f.Err(); err != nil {
+		b.WriteString(": " + err.Error())
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestDuration(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	p := futures.NewPromise[int](futures.WithName("fetch"), futures.WithClock(clock))
+	f := p.Future()
+	if d, ok := f.Duration(); ok || d != 0 {
+		t.Errorf("Duration() = %v, %v while pending; want 0, false", d, ok)
+	}
+	if got, want := f.String(), `future "fetch" unsettled`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	clock.Advance(3 * time.Second)
+	p.Reject(errors.New("boom"))
+	clock.Advance(time.Second)
+	if d, ok := f.Duration(); !ok || d != 3*time.Second {
+		t.Errorf("Duration() = %v, %v; want 3s, true", d, ok)
+	}
+	if got, want := f.String(), `future "fetch" rejected in 3s: boom`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestDurationLazy(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	release := make(chan struct{})
+	f := futures.Lazy(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	}, futures.WithClock(clock))
+	clock.Advance(5 * time.Second) // not counted: the computation has not started
+	done := f.Done()
+	clock.Advance(2 * time.Second)
+	close(release)
+	<-done
+	if d, ok := f.Duration(); !ok || d != 2*time.Second {
+		t.Errorf("Duration() = %v, %v; want 2s, true", d, ok)
+	}
+	if got, want := f.String(), "future resolved in 2s"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestDurationCompleted(t *testing.T) {
+	if d, ok := futures.Completed(1).Duration(); !ok || d != 0 {
+		t.Errorf("Duration() = %v, %v; want 0, true", d, ok)
+	}
+}
+
+func TestTryGetMarksAwaited(t *testing.T) {
+	p := futures.NewPromise[int]()
+	f := p.Future()
+	if _, _, ok := f.TryGet(); ok || f.WasAwaited() {
+		t.Fatalf("TryGet on a pending Future: ok = %v, WasAwaited = %v; want false, false", ok, f.WasAwaited())
+	}
+	p.Resolve(1)
+	if v, _, ok := f.TryGet(); !ok || v != 1 {
+		t.Fatalf("TryGet = %d, %v; want 1, true", v, ok)
+	}
+	if !f.WasAwaited() {
+		t.Error("WasAwaited = false after TryGet returned the result")
+	}
+}
@@ -0,0 +1,192 @@
+package futures
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrOverflow terminates a stream with the OverflowFail policy whose
+// buffer is full.
+var ErrOverflow = errors.New("futures: stream buffer overflow")
+
+// Stream is a sequence of values that arrive over time.
+//
+// Receive the values from the channel returned by C. When the channel is
+// closed, Err reports why the stream ended.
+type Stream[T any] struct {
+	ch     chan T
+	quit   chan struct{}
+	policy OverflowPolicy
+
+	mu       sync.Mutex
+	closed   bool
+	err      error
+	quitOnce sync.Once
+}
+
+// OverflowPolicy decides what happens when a stream's buffer is full and
+// the producer has another value.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes the producer wait until the consumer has made
+	// room. This is the default.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest buffered value to make room.
+	OverflowDropOldest
+	// OverflowFail ends the stream with ErrOverflow.
+	OverflowFail
+)
+
+// StreamOption configures how a stream buffers values for a slow consumer.
+type StreamOption func(*streamOptions)
+
+type streamOptions struct {
+	buffer int
+	policy OverflowPolicy
+	clock  Clock
+}
+
+func newStreamOptions(defaultBuffer int, opts []StreamOption) streamOptions {
+	o := streamOptions{buffer: defaultBuffer, clock: SystemClock}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.policy != OverflowBlock && o.buffer < 1 {
+		// Without a buffer, every value that the consumer is not
+		// receiving at that very moment would overflow.
+		o.buffer = 1
+	}
+	return o
+}
+
+// WithResultBuffer bounds the number of values a stream buffers for its
+// consumer to n.
+func WithResultBuffer(n int) StreamOption {
+	return func(o *streamOptions) {
+		o.buffer = max(n, 0)
+	}
+}
+
+// WithOverflow sets the policy that applies once the buffer is full.
+// OverflowDropOldest and OverflowFail need a buffer; with them, a buffer
+// size of 0 counts as 1.
+func WithOverflow(p OverflowPolicy) StreamOption {
+	return func(o *streamOptions) {
+		o.policy = p
+	}
+}
+
+// WithStreamClock sets the Clock for streams that measure time, such as
+// the window of SortedStream and RateWindow. The default is SystemClock.
+func WithStreamClock(c Clock) StreamOption {
+	return func(o *streamOptions) {
+		o.clock = c
+	}
+}
+
+func newStream[T any](o streamOptions) *Stream[T] {
+	return &Stream[T]{
+		ch:     make(chan T, o.buffer),
+		quit:   make(chan struct{}),
+		policy: o.policy,
+	}
+}
+
+// StreamOf returns a Stream of the values received from ch. The stream
+// ends when ch is closed.
+func StreamOf[T any](ch <-chan T, opts ...StreamOption) *Stream[T] {
+	s := newStream[T](newStreamOptions(0, opts))
+	go func() {
+		defer s.close(nil)
+		for v := range ch {
+			if !s.send(v) {
+				return
+			}
+		}
+	}()
+	return s
+}
+
+// C returns the channel that delivers the values of the stream. It is
+// closed when the stream ends.
+func (s *Stream[T]) C() <-chan T {
+	return s.ch
+}
+
+// Err returns the error that ended the stream, or nil if the stream ended
+// normally or has not ended yet.
+func (s *Stream[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close tells the producer that the consumer is not interested in more
+// values. The producer stops at the next value, and C is closed.
+func (s *Stream[T]) Close() {
+	s.quitOnce.Do(func() { close(s.quit) })
+}
+
+// send delivers v according to the overflow policy. It returns false if
+// the stream has ended or the consumer has closed it. Only one goroutine
+// may call send and close.
+func (s *Stream[T]) send(v T) bool {
+	select {
+	case <-s.quit:
+		s.close(nil)
+		return false
+	default:
+	}
+	if s.isClosed() {
+		return false
+	}
+	switch s.policy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case s.ch <- v:
+				return true
+			default:
+			}
+			select {
+			case <-s.ch:
+			default:
+			}
+		}
+	case OverflowFail:
+		select {
+		case s.ch <- v:
+			return true
+		default:
+			s.close(ErrOverflow)
+			return false
+		}
+	default:
+		select {
+		case s.ch <- v:
+			return true
+		case <-s.quit:
+			s.close(nil)
+			return false
+		}
+	}
+}
+
+func (s *Stream[T]) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// close ends the stream with err.
+func (s *Stream[T]) close(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.err = err
+	close(s.ch)
+}
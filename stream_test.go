@@ -0,0 +1,119 @@
+package futures_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+// fill feeds 1..n into a stream whose consumer does not receive anything
+// until the producer is done or blocked.
+func fill(n int, opts ...futures.StreamOption) (*futures.Stream[int], <-chan struct{}) {
+	in := make(chan int)
+	s := futures.StreamOf(in, opts...)
+	fed := make(chan struct{})
+	go func() {
+		defer close(fed)
+		defer close(in)
+		for i := 1; i <= n; i++ {
+			in <- i
+		}
+	}()
+	return s, fed
+}
+
+func drain[T any](s *futures.Stream[T]) []T {
+	var got []T
+	for v := range s.C() {
+		got = append(got, v)
+	}
+	return got
+}
+
+func TestStreamOverflowBlock(t *testing.T) {
+	s, fed := fill(5, futures.WithResultBuffer(2), futures.WithOverflow(futures.OverflowBlock))
+	// The producer can hand over two values to the buffer, one more to the
+	// stream goroutine, and then has to wait for the stalled consumer.
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case <-fed:
+		t.Fatal("producer did not block on a stalled consumer")
+	default:
+	}
+	got := drain(s)
+	<-fed
+	if len(got) != 5 || s.Err() != nil {
+		t.Errorf("got %v, %v; want all five values and no error", got, s.Err())
+	}
+}
+
+func TestStreamOverflowDropOldest(t *testing.T) {
+	s, fed := fill(5, futures.WithResultBuffer(2), futures.WithOverflow(futures.OverflowDropOldest))
+	<-fed
+	// The consumer may receive while the last value is being handed
+	// over, but it must end up with the newest value and miss some of
+	// the older ones.
+	got := drain(s)
+	if len(got) == 0 || len(got) > 3 || got[len(got)-1] != 5 || !slices.IsSorted(got) {
+		t.Errorf("got %v, want a few increasing values ending in 5", got)
+	}
+	if s.Err() != nil {
+		t.Errorf("Err = %v, want nil", s.Err())
+	}
+}
+
+func TestStreamOverflowFail(t *testing.T) {
+	s, _ := fill(5, futures.WithResultBuffer(2), futures.WithOverflow(futures.OverflowFail))
+	eventually(t, func() bool { return errors.Is(s.Err(), futures.ErrOverflow) }, "stream did not fail with ErrOverflow")
+	got := drain(s)
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("got %v, want the buffered values [1 2]", got)
+	}
+}
+
+func TestStreamOverflowZeroBuffer(t *testing.T) {
+	s, fed := fill(3, futures.WithResultBuffer(0), futures.WithOverflow(futures.OverflowDropOldest))
+	<-fed
+	if got := drain(s); len(got) == 0 || len(got) > 2 || got[len(got)-1] != 3 {
+		t.Errorf("got %v, want at most two values ending in 3", got)
+	}
+}
+
+func TestAsCompletedStalledConsumer(t *testing.T) {
+	futs := []*futures.Future[int]{futures.Completed(1), futures.Completed(2), futures.Completed(3)}
+	s := futures.AsCompleted(futs, futures.WithResultBuffer(1), futures.WithOverflow(futures.OverflowFail))
+	eventually(t, func() bool { return errors.Is(s.Err(), futures.ErrOverflow) }, "stream did not fail with ErrOverflow")
+	if got := drain(s); len(got) != 1 {
+		t.Errorf("got %d futures, want 1", len(got))
+	}
+
+	s = futures.AsCompleted(futs)
+	sum := 0
+	for f := range s.C() {
+		v, _ := f.Get()
+		sum += v
+	}
+	if sum != 6 || s.Err() != nil {
+		t.Errorf("default AsCompleted: sum %d, err %v; want 6, nil", sum, s.Err())
+	}
+}
+
+func TestStreamClose(t *testing.T) {
+	in := make(chan int, 100)
+	for i := 0; i < 100; i++ {
+		in <- i
+	}
+	close(in)
+	s := futures.StreamOf(in)
+	<-s.C()
+	s.Close()
+	if got := drain(s); len(got) > 1 {
+		t.Errorf("received %d values after Close, want at most 1", len(got))
+	}
+	if s.Err() != nil {
+		t.Errorf("Err after Close = %v, want nil", s.Err())
+	}
+}
@@ -0,0 +1,89 @@
+package futures
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// StrictMode selects the goroutines of the package on which a blocking
+// read of a pending Future panics. See SetStrict.
+type StrictMode uint32
+
+const (
+	// StrictCallbacks covers continuations passed to ThenInline and Map,
+	// which run on the goroutine that settles a Future. It also covers
+	// callbacks registered with OnSuccess, OnFailure, OnComplete, and
+	// Finally. These run on a goroutine of their own, so blocking there
+	// cannot deadlock the settling goroutine, but it delays everything
+	// that waits for the callback, such as the Future of Finally.
+	StrictCallbacks StrictMode = 1 << iota
+	// StrictWorkers covers the workers of pools created by NewPool and
+	// NewPoolWithMetrics.
+	StrictWorkers
+)
+
+var (
+	strictMode atomic.Uint32
+	// strictOwners maps the ID of each goroutine that runs code covered
+	// by strictMode to a description of the Future it runs code for.
+	strictOwners sync.Map
+)
+
+// SetStrict turns on strict mode for the goroutines selected by mode, or
+// turns it off if mode is zero, which is the default.
+//
+// In strict mode, Get, GetWithContext, and Wait panic if they are called
+// on a pending Future from one of the selected goroutines. Blocking there
+// is a common cause of deadlocks: a ThenInline or Map continuation that
+// waits for a Future the same goroutine would settle next never returns,
+// and pool workers that wait for tasks queued behind them starve the
+// pool. The panic message names both futures involved.
+//
+// Strict mode identifies goroutines by their ID, which costs a stack
+// trace per callback. Use it in tests and during debugging, not in
+// production.
+func SetStrict(mode StrictMode) {
+	strictMode.Store(uint32(mode))
+}
+
+// runStrict calls fn. If strict mode covers kind, blocking reads of
+// pending futures panic during fn, naming owner as the reason.
+func runStrict(kind StrictMode, owner string, fn func()) {
+	if StrictMode(strictMode.Load())&kind == 0 {
+		fn()
+		return
+	}
+	id := goroutineID()
+	prev, nested := strictOwners.Swap(id, owner)
+	defer func() {
+		if nested {
+			strictOwners.Store(id, prev)
+			return
+		}
+		strictOwners.Delete(id)
+	}()
+	fn()
+}
+
+// checkStrict panics if f is pending and the calling goroutine runs code
+// covered by strict mode.
+func (f *Future[T]) checkStrict() {
+	if strictMode.Load() == 0 || isSettled(f) {
+		return
+	}
+	owner, ok := strictOwners.Load(goroutineID())
+	if !ok {
+		return
+	}
+	panic(fmt.Sprintf("futures: strict mode: blocking read of pending %s from %s", describeFuture(f.name), owner))
+}
+
+// describeFuture returns a description of the Future with the given name
+// for use in messages.
+func describeFuture(name string) string {
+	if name == "" {
+		return "unnamed future"
+	}
+	return fmt.Sprintf("future %q", name)
+}
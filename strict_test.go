@@ -0,0 +1,117 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestStrictInlineContinuation(t *testing.T) {
+	futures.SetStrict(futures.StrictCallbacks)
+	defer futures.SetStrict(0)
+
+	// a's continuation waits for b, but b is resolved by the goroutine
+	// that resolves a, after a's continuations have returned. Without
+	// strict mode, this deadlocks.
+	a := futures.NewPromise[int](futures.WithName("a"))
+	b := futures.NewPromise[int](futures.WithName("b"))
+	sum := futures.ThenInline(a.Future(), func(v int) (int, error) {
+		w, err := b.Future().Get()
+		return v + w, err
+	})
+	go func() {
+		a.Resolve(1)
+		b.Resolve(2)
+	}()
+
+	_, err := sum.Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("err = %v, want a PanicError", err)
+	}
+	msg := fmt.Sprint(pe.Value())
+	for _, want := range []string{`future "b"`, `inline continuation of future "a"`} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("panic message %q does not mention %s", msg, want)
+		}
+	}
+}
+
+func TestStrictCallback(t *testing.T) {
+	futures.SetStrict(futures.StrictCallbacks)
+	defer futures.SetStrict(0)
+
+	panics := make(chan *futures.PanicError, 1)
+	a := futures.NewPromise[int](futures.WithName("a"), futures.WithPanicHandler(func(pe *futures.PanicError) {
+		panics <- pe
+	}))
+	b := futures.NewPromise[int]()
+	a.Future().OnComplete(func(int, error) {
+		b.Future().Wait(context.Background())
+	})
+	a.Resolve(1)
+	select {
+	case pe := <-panics:
+		msg := fmt.Sprint(pe.Value())
+		if !strings.Contains(msg, "unnamed future") || !strings.Contains(msg, `callback of future "a"`) {
+			t.Errorf("panic message %q does not name both futures", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("blocking Wait in a callback did not panic")
+	}
+}
+
+func TestStrictAllowsSettledFutures(t *testing.T) {
+	futures.SetStrict(futures.StrictCallbacks)
+	defer futures.SetStrict(0)
+
+	b := futures.Completed(2)
+	v, err := futures.ThenInline(futures.Completed(1), func(v int) (int, error) {
+		w, err := b.Get()
+		return v + w, err
+	}).Get()
+	if v != 3 || err != nil {
+		t.Errorf("Get = %d, %v; want 3, nil", v, err)
+	}
+}
+
+// TestStrictOff checks that blocking reads in continuations are allowed
+// by default.
+func TestStrictOff(t *testing.T) {
+	a := futures.NewPromise[int]()
+	b := futures.NewPromise[int]()
+	sum := futures.ThenInline(a.Future(), func(v int) (int, error) {
+		w, err := b.Future().Get()
+		return v + w, err
+	})
+	go a.Resolve(1)
+	time.Sleep(10 * time.Millisecond)
+	b.Resolve(2)
+	if v, err := sum.Get(); v != 3 || err != nil {
+		t.Errorf("Get = %d, %v; want 3, nil", v, err)
+	}
+}
+
+func TestStrictWorkers(t *testing.T) {
+	futures.SetStrict(futures.StrictWorkers)
+	defer futures.SetStrict(0)
+
+	p := futures.NewPool[int]()
+	defer p.Close()
+	inner := futures.NewPromise[int](futures.WithName("inner"))
+	_, err := p.Submit(func(ctx context.Context) (int, error) {
+		return inner.Future().Get()
+	}).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("err = %v, want a PanicError", err)
+	}
+	if msg := fmt.Sprint(pe.Value()); !strings.Contains(msg, `future "inner"`) || !strings.Contains(msg, "pool worker") {
+		t.Errorf("panic message %q does not name the future and the worker", msg)
+	}
+}
@@ -0,0 +1,42 @@
+package futures
+
+// Then returns a Future for the result of fn, which is called with the
+// value of f once f has resolved. If f fails, fn is not called, and the
+// returned Future fails with the error of f. If fn panics, the returned
+// Future fails with a *PanicError.
+//
+// fn runs on a goroutine of its own, so it may block or take its time
+// without holding up the producer of f. Use ThenInline for cheap
+// continuations.
+//
+// Canceling the returned Future cancels f as well, because no one is
+// waiting for f through it anymore. Do not call Then on a Future that has
+// other readers if they must not be affected by the cancellation.
+//
+// The returned Future is a stage of the Chain of f. Then starts no
+// goroutine until f has settled, so chains of any length are cheap.
+func Then[A, B any](f *Future[A], fn func(A) (B, error)) *Future[B] {
+	return then(f, false, fn)
+}
+
+// ThenInline is like Then but calls fn on the goroutine that settles f,
+// before that goroutine goes on, or on the calling goroutine if f has
+// settled already. This saves starting a goroutine, but the producer of f
+// waits for fn, so fn must be quick and must not block. A panic in fn
+// does not reach the producer; it fails the returned Future with a
+// *PanicError, as with Then.
+func ThenInline[A, B any](f *Future[A], fn func(A) (B, error)) *Future[B] {
+	return then(f, true, fn)
+}
+
+func then[A, B any](f *Future[A], inline bool, fn func(A) (B, error)) *Future[B] {
+	out := newFuture[B]()
+	out.cancel = f.CancelWithCause
+	return chainTo(f, out, inline, func(v A, err error) (B, error) {
+		if err != nil {
+			var zero B
+			return zero, err
+		}
+		return catchPanic(func() (B, error) { return fn(v) })
+	})
+}
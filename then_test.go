@@ -0,0 +1,146 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestThen(t *testing.T) {
+	f := futures.Then(futures.Completed(21), func(v int) (string, error) {
+		return strconv.Itoa(2 * v), nil
+	})
+	if v, err := f.Get(); v != "42" || err != nil {
+		t.Errorf("Get = %q, %v; want 42, nil", v, err)
+	}
+}
+
+func TestThenSkipsOnError(t *testing.T) {
+	errBoom := errors.New("boom")
+	var ran atomic.Bool
+	f := futures.Then(futures.Failed[int](errBoom), func(v int) (int, error) {
+		ran.Store(true)
+		return v, nil
+	})
+	if _, err := f.Get(); err != errBoom {
+		t.Errorf("err = %v, want %v", err, errBoom)
+	}
+	if ran.Load() {
+		t.Error("fn ran although the upstream future failed")
+	}
+}
+
+func TestThenError(t *testing.T) {
+	errBoom := errors.New("boom")
+	f := futures.Then(futures.Completed(1), func(int) (int, error) { return 0, errBoom })
+	if _, err := f.Get(); err != errBoom {
+		t.Errorf("err = %v, want %v", err, errBoom)
+	}
+	g := futures.Then(futures.Completed(1), func(int) (int, error) { panic("then failed") })
+	var pe *futures.PanicError
+	if _, err := g.Get(); !errors.As(err, &pe) {
+		t.Errorf("err = %v, want a *PanicError", err)
+	}
+}
+
+func TestThenCancelTail(t *testing.T) {
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	head := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+		return 0, ctx.Err()
+	})
+	mid := futures.Then(head, func(v int) (int, error) { return v + 1, nil })
+	tail := futures.Then(mid, func(v int) (int, error) { return v + 1, nil })
+	<-started
+	tail.Cancel()
+	<-stopped
+	for name, f := range map[string]*futures.Future[int]{"head": head, "mid": mid, "tail": tail} {
+		if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+			t.Errorf("%s: err = %v, want ErrCanceled", name, err)
+		}
+	}
+}
+
+func TestThenLongChainNoGoroutines(t *testing.T) {
+	p := futures.NewPromise[int]()
+	before := runtime.NumGoroutine()
+	f := p.Future()
+	for i := 0; i < 1000; i++ {
+		f = futures.Then(f, func(v int) (int, error) { return v + 1, nil })
+	}
+	if n := runtime.NumGoroutine() - before; n > 10 {
+		t.Errorf("a chain of 1000 pending stages started %d goroutines", n)
+	}
+	p.Resolve(0)
+	if v, err := f.Get(); v != 1000 || err != nil {
+		t.Errorf("Get = %v, %v; want 1000, nil", v, err)
+	}
+}
+
+func TestThenRunsOnOwnGoroutine(t *testing.T) {
+	p := futures.NewPromise[int]()
+	entered := make(chan struct{})
+	proceed := make(chan struct{})
+	f := futures.Then(p.Future(), func(v int) (int, error) {
+		close(entered)
+		<-proceed
+		return v, nil
+	})
+	// Resolve returns while fn is still blocked.
+	p.Resolve(1)
+	<-entered
+	close(proceed)
+	if v, err := f.Get(); v != 1 || err != nil {
+		t.Errorf("Get = %v, %v; want 1, nil", v, err)
+	}
+}
+
+func TestThenInlineRunsOnProducer(t *testing.T) {
+	p := futures.NewPromise[int]()
+	var inProducer atomic.Bool
+	f := futures.ThenInline(p.Future(), func(v int) (int, error) {
+		if !inProducer.Load() {
+			t.Error("fn did not run while the producer was in Resolve")
+		}
+		return v + 1, nil
+	})
+	resolved := make(chan struct{})
+	go func() {
+		inProducer.Store(true)
+		p.Resolve(1)
+		inProducer.Store(false)
+		close(resolved)
+	}()
+	<-resolved
+	// fn has run by the time Resolve returned, so f has settled.
+	if v, err, ok := f.TryGet(); !ok || v != 2 || err != nil {
+		t.Errorf("TryGet = %v, %v, %v after Resolve; want 2, nil, true", v, err, ok)
+	}
+}
+
+func TestThenInlineSettled(t *testing.T) {
+	f := futures.ThenInline(futures.Completed(1), func(v int) (int, error) { return v + 1, nil })
+	if v, err, ok := f.TryGet(); !ok || v != 2 || err != nil {
+		t.Errorf("TryGet = %v, %v, %v; want 2, nil, true", v, err, ok)
+	}
+}
+
+func TestThenInlinePanicSparesProducer(t *testing.T) {
+	p := futures.NewPromise[int]()
+	f := futures.ThenInline(p.Future(), func(int) (int, error) { panic("continuation failed") })
+	if err := p.Resolve(1); err != nil {
+		t.Fatalf("Resolve() = %v", err)
+	}
+	var pe *futures.PanicError
+	if _, err := f.Get(); !errors.As(err, &pe) {
+		t.Errorf("err = %v, want a *PanicError", err)
+	}
+}
@@ -0,0 +1,98 @@
+package futures
+
+import (
+	"context"
+	"time"
+)
+
+// TimeoutError is the error of a Future returned by WithTimeout whose
+// source did not settle in time. It matches ErrTimeout and
+// context.DeadlineExceeded in errors.Is, and it has the Timeout and
+// Temporary methods of the net package convention, so code that checks
+// for net.Error timeouts recognizes it as well.
+type TimeoutError struct {
+	deadline time.Time
+	fired    time.Time
+}
+
+func (e *TimeoutError) Error() string {
+	return "futures: timeout: deadline " + e.deadline.Format(time.RFC3339Nano) + " passed"
+}
+
+// Deadline returns the deadline that was set for the Future.
+func (e *TimeoutError) Deadline() time.Time {
+	return e.deadline
+}
+
+// Fired returns the time at which the timeout fired. Timers fire late
+// rather than early, so Fired is never before Deadline, but it may be
+// somewhat after it.
+func (e *TimeoutError) Fired() time.Time {
+	return e.fired
+}
+
+// Timeout returns true.
+func (e *TimeoutError) Timeout() bool { return true }
+
+// Temporary returns true: a later attempt might succeed in time.
+func (e *TimeoutError) Temporary() bool { return true }
+
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout || target == context.DeadlineExceeded
+}
+
+// WithTimeout returns a Future that settles like f if f settles within d,
+// and fails with a *TimeoutError otherwise. When the timeout fires, f is
+// canceled with the *TimeoutError as the cause, which stops its
+// computation. Canceling the returned Future cancels f, too.
+//
+// Timeouts compose: in f.WithTimeout(a).WithTimeout(b), the earlier of the
+// two deadlines wins. The timer runs on the clock set by WithClock for f
+// and is stopped as soon as either Future settles.
+func (f *Future[T]) WithTimeout(d time.Duration) *Future[T] {
+	clock := f.timerClock()
+	return f.withDeadline(clock, clock.Now().Add(d), d)
+}
+
+// WithDeadline is like WithTimeout but takes an absolute deadline, such as
+// one imposed by an incoming request. The *TimeoutError records deadline
+// as it was given. If deadline has passed already and f is still pending,
+// the returned Future fails right away.
+func (f *Future[T]) WithDeadline(deadline time.Time) *Future[T] {
+	clock := f.timerClock()
+	return f.withDeadline(clock, deadline, deadline.Sub(clock.Now()))
+}
+
+// timerClock returns the clock for the timers of f. Futures that were
+// created settled have no clock.
+func (f *Future[T]) timerClock() Clock {
+	if f.clock == nil {
+		return SystemClock
+	}
+	return f.clock
+}
+
+// withDeadline implements WithTimeout and WithDeadline. d is the time
+// left until deadline.
+func (f *Future[T]) withDeadline(clock Clock, deadline time.Time, d time.Duration) *Future[T] {
+	out := newFuture[T]()
+	out.clock = clock
+	out.cancel = f.CancelWithCause
+	ChainOf(f).add(out)
+	expire := func() {
+		terr := &TimeoutError{deadline: deadline, fired: clock.Now()}
+		var zero T
+		if out.settle(zero, terr) {
+			f.CancelWithCause(terr)
+		}
+	}
+	f.ensureStarted()
+	f.core.AddCallback(func(v T, err error) { out.settle(v, err) })
+	if d <= 0 {
+		expire()
+		return out
+	}
+	t := f.afterFunc(d, expire)
+	out.core.AddCallback(func(T, error) { t.Stop() })
+	return out
+}
@@ -0,0 +1,149 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestWithTimeoutFires(t *testing.T) {
+	start := time.Now()
+	clock := futuretest.NewFakeClock(start)
+	started := make(chan struct{})
+	stopped := make(chan error, 1)
+	f := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		stopped <- context.Cause(ctx)
+		return 0, ctx.Err()
+	}, futures.WithClock(clock))
+	g := f.WithTimeout(time.Second)
+	<-started
+
+	clock.Advance(time.Second)
+	_, err := g.Get()
+	var te *futures.TimeoutError
+	if !errors.As(err, &te) || !te.Deadline().Equal(start.Add(time.Second)) {
+		t.Fatalf("err = %v, want a TimeoutError with the deadline", err)
+	}
+	if !te.Fired().Equal(start.Add(time.Second)) {
+		t.Errorf("Fired() = %v, want %v", te.Fired(), start.Add(time.Second))
+	}
+	var ne net.Error
+	if !errors.As(err, &ne) || !ne.Timeout() {
+		t.Errorf("err = %v is not a net.Error timeout", err)
+	}
+	if !errors.Is(err, futures.ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v does not match ErrTimeout and context.DeadlineExceeded", err)
+	}
+	if cause := <-stopped; !errors.As(cause, &te) {
+		t.Errorf("the computation was canceled with cause %v, want the TimeoutError", cause)
+	}
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("source: err = %v, want ErrCanceled", err)
+	}
+}
+
+func TestWithTimeoutInTime(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	p := futures.NewPromise[int](futures.WithClock(clock))
+	g := p.Future().WithTimeout(time.Second)
+	p.Resolve(5)
+	if v, err := g.Get(); v != 5 || err != nil {
+		t.Errorf("Get = %v, %v; want 5, nil", v, err)
+	}
+	if n := clock.Pending(); n != 0 {
+		t.Errorf("%d timers pending after settlement, want 0", n)
+	}
+}
+
+func TestWithTimeoutComposes(t *testing.T) {
+	start := time.Now()
+	for name, chain := range map[string]func(f *futures.Future[int]) *futures.Future[int]{
+		"earlier first": func(f *futures.Future[int]) *futures.Future[int] {
+			return f.WithTimeout(time.Second).WithTimeout(time.Minute)
+		},
+		"earlier last": func(f *futures.Future[int]) *futures.Future[int] {
+			return f.WithTimeout(time.Minute).WithTimeout(time.Second)
+		},
+	} {
+		clock := futuretest.NewFakeClock(start)
+		p := futures.NewPromise[int](futures.WithClock(clock))
+		g := chain(p.Future())
+		clock.Advance(time.Second)
+		_, err := g.Get()
+		var te *futures.TimeoutError
+		if !errors.As(err, &te) || !te.Deadline().Equal(start.Add(time.Second)) {
+			t.Errorf("%s: err = %v, want a timeout at the earlier deadline", name, err)
+		}
+		clock.Advance(time.Hour)
+	}
+}
+
+func TestWithTimeoutSettled(t *testing.T) {
+	if v, err := futures.Completed(1).WithTimeout(0).Get(); v != 1 || err != nil {
+		t.Errorf("Get = %v, %v; want 1, nil", v, err)
+	}
+}
+
+func TestWithDeadline(t *testing.T) {
+	start := time.Now()
+	clock := futuretest.NewFakeClock(start)
+	deadline := start.Add(1500 * time.Microsecond)
+	p := futures.NewPromise[int](futures.WithClock(clock))
+	g := p.Future().WithDeadline(deadline)
+	clock.Advance(time.Millisecond)
+	if g.State() != futures.Unsettled {
+		t.Fatal("the Future timed out before its deadline")
+	}
+	clock.Advance(time.Millisecond)
+	_, err := g.Get()
+	var te *futures.TimeoutError
+	if !errors.As(err, &te) || !te.Deadline().Equal(deadline) {
+		t.Fatalf("err = %v, want a TimeoutError with deadline %v", err, deadline)
+	}
+	if te.Fired().Before(deadline) {
+		t.Errorf("Fired() = %v is before the deadline", te.Fired())
+	}
+	if _, err := p.Future().Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("source: err = %v, want ErrCanceled", err)
+	}
+}
+
+func TestWithDeadlinePassed(t *testing.T) {
+	p := futures.NewPromise[int]()
+	g := p.Future().WithDeadline(time.Now().Add(-time.Second))
+	if g.State() != futures.Rejected || !errors.Is(g.Err(), futures.ErrTimeout) {
+		t.Errorf("State = %v, Err = %v; want an immediate timeout", g.State(), g.Err())
+	}
+	if v, err := futures.Completed(1).WithDeadline(time.Now().Add(-time.Second)).Get(); v != 1 || err != nil {
+		t.Errorf("settled source: Get = %v, %v; want 1, nil", v, err)
+	}
+}
+
+func TestWithTimeoutCancelPropagates(t *testing.T) {
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	f := futures.New(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+		return 0, ctx.Err()
+	})
+	g := f.WithTimeout(time.Hour)
+	<-started
+	g.Cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("canceling the WithTimeout Future did not stop the computation")
+	}
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("source: err = %v, want ErrCanceled", err)
+	}
+}
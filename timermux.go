@@ -0,0 +1,174 @@
+package futures
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// timerMux serves all deadlines of one Future, such as those of
+// WithTimeout, RejectAfter, and latency overrides, with a single timer of
+// the Future's clock. The deadlines are kept in order; the timer is armed
+// for the earliest one and re-armed whenever the earliest one changes.
+//
+// Timers of the SystemClock are re-armed with Reset, so that a Future
+// allocates at most one runtime timer. Timers of other clocks cannot be
+// reset; they are stopped and replaced, and at most one of them is
+// pending at a time.
+type timerMux struct {
+	mu      sync.Mutex
+	clock   Clock
+	entries []*muxTimer // ordered by when, then seq
+	seq     uint64
+	// active reports whether t is armed for armed. t is nil while a timer
+	// of a clock other than SystemClock is being created; tok identifies
+	// the latest such creation.
+	active bool
+	armed  time.Time
+	t      Timer
+	tok    *muxArm
+}
+
+// muxArm identifies an attempt to create a timer.
+type muxArm struct{ _ byte }
+
+// muxTimer is a deadline served by a timerMux. It implements Timer.
+type muxTimer struct {
+	m    *timerMux
+	when time.Time
+	seq  uint64
+	fn   func()
+}
+
+// afterFunc is like f.timerClock().AfterFunc, but all timers of f share
+// one timer of the clock.
+func (f *Future[T]) afterFunc(d time.Duration, fn func()) Timer {
+	m := f.timers.Load()
+	if m == nil {
+		// Room for a few deadlines, the common case, saves regrowing.
+		m = &timerMux{clock: f.timerClock(), entries: make([]*muxTimer, 0, 4)}
+		if !f.timers.CompareAndSwap(nil, m) {
+			m = f.timers.Load()
+		}
+	}
+	return m.add(d, fn)
+}
+
+// add schedules fn to run once d has elapsed.
+func (m *timerMux) add(d time.Duration, fn func()) *muxTimer {
+	m.mu.Lock()
+	now := m.clock.Now()
+	e := &muxTimer{m: m, when: now.Add(d), seq: m.seq, fn: fn}
+	m.seq++
+	i, _ := slices.BinarySearchFunc(m.entries, e, func(a, b *muxTimer) int {
+		if a.when.After(b.when) {
+			return 1
+		}
+		return -1
+	})
+	m.entries = slices.Insert(m.entries, i, e)
+	arm := m.rearm(false, now)
+	m.mu.Unlock()
+	arm()
+	return e
+}
+
+// Stop removes t from its timerMux. It returns false if t has fired or
+// has been stopped already.
+func (t *muxTimer) Stop() bool {
+	m := t.m
+	m.mu.Lock()
+	i := slices.Index(m.entries, t)
+	if i < 0 {
+		m.mu.Unlock()
+		return false
+	}
+	m.entries = slices.Delete(m.entries, i, i+1)
+	// Removing a later deadline leaves the timer as it is, so the clock
+	// needs to be read only if the earliest one was removed.
+	var now time.Time
+	if i == 0 {
+		now = m.clock.Now()
+	}
+	arm := m.rearm(false, now)
+	m.mu.Unlock()
+	arm()
+	return true
+}
+
+// fire runs the functions whose deadlines have passed, in order, and
+// re-arms the timer for the rest.
+func (m *timerMux) fire() {
+	m.mu.Lock()
+	now := m.clock.Now()
+	n := 0
+	for n < len(m.entries) && !m.entries[n].when.After(now) {
+		n++
+	}
+	due := slices.Clone(m.entries[:n])
+	m.entries = slices.Delete(m.entries, 0, n)
+	// The timer that fired is spent, or it is a stale one; either way,
+	// the earliest remaining deadline needs a timer.
+	arm := m.rearm(true, now)
+	m.mu.Unlock()
+	arm()
+	for _, e := range due {
+		e.fn()
+	}
+}
+
+// rearm arms the timer for the earliest deadline, unless it is armed for
+// it already and force is false. It returns the part of the work that
+// must run without holding m.mu, because the clock may call fire right
+// away. now is the current time of m.clock. m.mu must be held.
+func (m *timerMux) rearm(force bool, now time.Time) func() {
+	if len(m.entries) == 0 {
+		m.active = false
+		return m.release()
+	}
+	when := m.entries[0].when
+	if m.active && !force && m.armed.Equal(when) {
+		return func() {}
+	}
+	m.active, m.armed = true, when
+	d := when.Sub(now)
+	if t, ok := m.t.(*time.Timer); ok {
+		// A runtime timer never calls fire from Reset, so it can be
+		// reset under the lock, which keeps resets in order.
+		t.Reset(d)
+		return func() {}
+	}
+	old := m.release()
+	tok := &muxArm{}
+	m.tok = tok
+	return func() {
+		old()
+		t := m.clock.AfterFunc(d, m.fire)
+		m.mu.Lock()
+		if m.tok != tok {
+			// A later re-arm or release superseded this one.
+			m.mu.Unlock()
+			t.Stop()
+			return
+		}
+		m.t, m.tok = t, nil
+		m.mu.Unlock()
+	}
+}
+
+// release stops the timer. A runtime timer is kept for reuse; other
+// timers are dropped and stopped by the returned function. m.mu must be
+// held.
+func (m *timerMux) release() func() {
+	m.tok = nil
+	switch t := m.t.(type) {
+	case nil:
+		return func() {}
+	case *time.Timer:
+		t.Stop()
+		return func() {}
+	default:
+		m.t = nil
+		return func() { t.Stop() }
+	}
+}
@@ -0,0 +1,206 @@
+package futures
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// manualClock is a Clock whose time moves only with Advance. Package
+// futuretest has a complete one, but it cannot be imported here.
+type manualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+type manualTimer struct {
+	c    *manualClock
+	when time.Time
+	fn   func()
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) AfterFunc(d time.Duration, fn func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &manualTimer{c: c, when: c.now.Add(d), fn: fn}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (t *manualTimer) Stop() bool {
+	c := t.c
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := len(c.timers)
+	c.timers = slices.DeleteFunc(c.timers, func(x *manualTimer) bool { return x == t })
+	return len(c.timers) < n
+}
+
+// Advance moves the time forward by d, firing the timers that come due
+// in the order of their deadlines.
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	c.mu.Unlock()
+	for {
+		c.mu.Lock()
+		var next *manualTimer
+		for _, t := range c.timers {
+			if !t.when.After(end) && (next == nil || t.when.Before(next.when)) {
+				next = t
+			}
+		}
+		if next == nil {
+			c.now = end
+			c.mu.Unlock()
+			return
+		}
+		c.timers = slices.DeleteFunc(c.timers, func(x *manualTimer) bool { return x == next })
+		if next.when.After(c.now) {
+			c.now = next.when
+		}
+		c.mu.Unlock()
+		next.fn()
+	}
+}
+
+func (c *manualClock) pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// permutations returns all orderings of xs.
+func permutations(xs []int) [][]int {
+	if len(xs) <= 1 {
+		return [][]int{append([]int(nil), xs...)}
+	}
+	var out [][]int
+	for i := range xs {
+		rest := append(append([]int(nil), xs[:i]...), xs[i+1:]...)
+		for _, p := range permutations(rest) {
+			out = append(out, append([]int{xs[i]}, p...))
+		}
+	}
+	return out
+}
+
+// TestTimerMuxRearming adds the deadlines 1s to 4s in every order, stops
+// every subset of them in both directions, and checks that the mux never
+// has more than one timer pending and that the remaining deadlines fire
+// on time, in order.
+func TestTimerMuxRearming(t *testing.T) {
+	for _, order := range permutations([]int{1, 2, 3, 4}) {
+		for stopped := 0; stopped < 1<<4; stopped++ {
+			for _, reverse := range []bool{false, true} {
+				name := fmt.Sprintf("add%v/stop%04b/reverse=%v", order, stopped, reverse)
+				t.Run(name, func(t *testing.T) {
+					checkRearming(t, order, stopped, reverse)
+				})
+			}
+		}
+	}
+}
+
+func checkRearming(t *testing.T, order []int, stopped int, reverse bool) {
+	start := time.Unix(0, 0)
+	clock := &manualClock{now: start}
+	m := &timerMux{clock: clock}
+	var fired []int
+	var firedAt []time.Time
+	timers := map[int]Timer{}
+	for _, s := range order {
+		s := s
+		timers[s] = m.add(time.Duration(s)*time.Second, func() {
+			fired = append(fired, s)
+			firedAt = append(firedAt, clock.Now())
+		})
+		if n := clock.pending(); n != 1 {
+			t.Fatalf("after adding %ds: %d timers pending, want 1", s, n)
+		}
+	}
+
+	stop := append([]int(nil), order...)
+	if reverse {
+		slices.Reverse(stop)
+	}
+	var want []int
+	for _, s := range stop {
+		if stopped&(1<<(s-1)) != 0 {
+			if !timers[s].Stop() {
+				t.Errorf("Stop of %ds returned false", s)
+			}
+			if timers[s].Stop() {
+				t.Errorf("second Stop of %ds returned true", s)
+			}
+		} else {
+			want = append(want, s)
+		}
+		if n, wantN := clock.pending(), min(len(m.entries), 1); n != wantN {
+			t.Fatalf("after stopping up to %ds: %d timers pending, want %d", s, n, wantN)
+		}
+	}
+	slices.Sort(want)
+
+	for i := 0; i < 4*4; i++ {
+		clock.Advance(250 * time.Millisecond)
+		if n := clock.pending(); n > 1 {
+			t.Fatalf("%d timers pending at %v", n, clock.Now().Sub(start))
+		}
+	}
+	if !slices.Equal(fired, want) {
+		t.Fatalf("fired %v, want %v", fired, want)
+	}
+	for i, s := range fired {
+		if want := start.Add(time.Duration(s) * time.Second); !firedAt[i].Equal(want) {
+			t.Errorf("the %ds deadline fired at %v, want %v", s, firedAt[i], want)
+		}
+		if timers[s].Stop() {
+			t.Errorf("Stop of the fired %ds deadline returned true", s)
+		}
+	}
+	if n := clock.pending(); n != 0 {
+		t.Errorf("%d timers pending after all deadlines, want 0", n)
+	}
+}
+
+func TestTimerMuxEqualDeadlines(t *testing.T) {
+	clock := &manualClock{now: time.Unix(0, 0)}
+	m := &timerMux{clock: clock}
+	var fired []int
+	for i := 0; i < 3; i++ {
+		i := i
+		m.add(time.Second, func() { fired = append(fired, i) })
+	}
+	clock.Advance(time.Second)
+	if want := []int{0, 1, 2}; !slices.Equal(fired, want) {
+		t.Errorf("fired %v, want %v in the order of adding", fired, want)
+	}
+}
+
+func TestTimerMuxAddFromCallback(t *testing.T) {
+	clock := &manualClock{now: time.Unix(0, 0)}
+	m := &timerMux{clock: clock}
+	var fired []string
+	m.add(time.Second, func() {
+		fired = append(fired, "first")
+		m.add(time.Second, func() { fired = append(fired, "second") })
+	})
+	clock.Advance(time.Second)
+	if n := clock.pending(); n != 1 {
+		t.Fatalf("%d timers pending after adding from a callback, want 1", n)
+	}
+	clock.Advance(time.Second)
+	if want := []string{"first", "second"}; !slices.Equal(fired, want) {
+		t.Errorf("fired %v, want %v", fired, want)
+	}
+}
@@ -0,0 +1,72 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestTimerMuxSystemClock(t *testing.T) {
+	p := futures.NewPromise[int]()
+	defer p.Resolve(0)
+	late := p.Future().WithTimeout(time.Hour)
+	early := p.Future().WithTimeout(10 * time.Millisecond)
+	if err := early.Wait(context.Background()); !errors.Is(err, futures.ErrTimeout) {
+		t.Errorf("early: err = %v, want ErrTimeout", err)
+	}
+	// The early timeout canceled the promise's Future, which settles late.
+	if err := late.Wait(context.Background()); err == nil {
+		t.Error("late: no error")
+	}
+}
+
+func TestTimerMuxRejectAfterAndTimeout(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Unix(0, 0))
+	p := futures.NewPromise[int](futures.WithClock(clock))
+	p.RejectAfter(2*time.Second, nil)
+	out := p.Future().WithTimeout(5 * time.Second)
+	if n := clock.Pending(); n != 1 {
+		t.Fatalf("%d timers pending, want 1", n)
+	}
+	clock.Advance(2 * time.Second)
+	if err := out.Err(); !errors.Is(err, futures.ErrProducerTimeout) {
+		t.Errorf("err = %v, want ErrProducerTimeout", err)
+	}
+	if n := clock.Pending(); n != 0 {
+		t.Errorf("%d timers pending after settlement, want 0", n)
+	}
+}
+
+// countingClock is the system clock, counting the timers it creates.
+type countingClock struct {
+	created atomic.Int64
+}
+
+func (c *countingClock) Now() time.Time { return time.Now() }
+
+func (c *countingClock) AfterFunc(d time.Duration, f func()) futures.Timer {
+	c.created.Add(1)
+	return time.AfterFunc(d, f)
+}
+
+// BenchmarkTimersPer10kFutures reports how many runtime timers 10,000
+// futures allocate that each have a producer deadline and two timeouts.
+func BenchmarkTimersPer10kFutures(b *testing.B) {
+	clock := &countingClock{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 10000; j++ {
+			p := futures.NewPromise[int](futures.WithClock(clock))
+			p.RejectAfter(time.Hour, nil)
+			p.Future().WithTimeout(30 * time.Minute)
+			p.Future().WithTimeout(10 * time.Minute)
+			p.Resolve(j)
+		}
+	}
+	b.ReportMetric(float64(clock.created.Load())/float64(b.N), "timers/10k-futures")
+}
@@ -0,0 +1,16 @@
+package tutorial_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/appliedgo/futures/tutorial"
+)
+
+func ExampleTimeoutFuture() {
+	tutorial.CalculationTime = 10 * time.Millisecond
+	get, stop := tutorial.TimeoutFuture(1)
+	defer stop()
+	fmt.Println(get(time.Second))
+	// Output: 8 false
+}
@@ -0,0 +1,130 @@
+// Package tutorial contains the three futures from the article "Futures
+// in Go, no package required" (futures.go in the repository root) as
+// functions that can be imported, tested, and linked to.
+//
+// Unlike the snippets in the article, each function cleans up after
+// itself: no goroutine outlives the future, and the stop functions end
+// the computing goroutine before they return, whether or not the result
+// was ever read.
+package tutorial
+
+import (
+	"sync"
+	"time"
+)
+
+// CalculationTime is how long the "horribly complex and long-winded
+// calculation" of each future takes. The article uses one second. A change
+// affects the futures created afterwards.
+var CalculationTime = time.Second
+
+// SimpleFuture returns a channel that delivers input*2 once the
+// calculation is done, like the first example of the article.
+//
+// The channel has a buffer of 1, so the computing goroutine ends right
+// after the calculation even if nobody ever receives from the channel.
+// There is nothing to stop.
+func SimpleFuture(input int) <-chan int {
+	c := make(chan int, 1)
+	d := CalculationTime
+	go func() {
+		time.Sleep(d)
+		c <- input * 2
+	}()
+	return c
+}
+
+// MultiReadFuture computes input*4 and returns a function that returns
+// the result as often as it is called, like the second example of the
+// article. read blocks until the result is ready.
+//
+// stop ends the calculation if it is still running and returns once the
+// computing goroutine has ended. A read after stop returns the result if
+// it was ready in time, and 0 otherwise. stop may be called more than
+// once.
+func MultiReadFuture(input int) (read func() int, stop func()) {
+	p := produce(input * 4)
+	read = func() int {
+		select {
+		case <-p.done:
+		case <-p.stopped:
+		}
+		return p.result()
+	}
+	return read, p.stop
+}
+
+// TimeoutFuture computes input*8 and returns a function that waits at
+// most the given time for the result, like the third example of the
+// article. get reports timedOut if the result is not ready in time; it can
+// be called again to wait some more.
+//
+// stop behaves as for MultiReadFuture. After stop, get returns the
+// result if it was ready in time, and reports timedOut right away
+// otherwise.
+func TimeoutFuture(input int) (get func(time.Duration) (result int, timedOut bool), stop func()) {
+	p := produce(input * 8)
+	get = func(d time.Duration) (int, bool) {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-p.done:
+			return p.result(), false
+		case <-p.stopped:
+		case <-timer.C:
+		}
+		select {
+		case <-p.done:
+			return p.result(), false
+		default:
+			return 0, true
+		}
+	}
+	return get, p.stop
+}
+
+// producer computes a value on a goroutine that can be stopped.
+type producer struct {
+	value    int
+	done     chan struct{} // closed when value is set
+	stopped  chan struct{} // closed by stop
+	exited   chan struct{} // closed when the goroutine has ended
+	stopOnce sync.Once
+}
+
+// produce starts a producer that delivers v after CalculationTime.
+func produce(v int) *producer {
+	p := &producer{
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		exited:  make(chan struct{}),
+	}
+	timer := time.NewTimer(CalculationTime)
+	go func() {
+		defer close(p.exited)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			p.value = v
+			close(p.done)
+		case <-p.stopped:
+		}
+	}()
+	return p
+}
+
+// result returns the value if it is ready, and 0 otherwise.
+func (p *producer) result() int {
+	select {
+	case <-p.done:
+		return p.value
+	default:
+		return 0
+	}
+}
+
+// stop ends the goroutine of p and waits until it has ended.
+func (p *producer) stop() {
+	p.stopOnce.Do(func() { close(p.stopped) })
+	<-p.exited
+}
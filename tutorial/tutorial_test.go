@@ -0,0 +1,77 @@
+package tutorial_test
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+
+	"github.com/appliedgo/futures/tutorial"
+)
+
+func setCalculationTime(t *testing.T, d time.Duration) {
+	t.Helper()
+	orig := tutorial.CalculationTime
+	tutorial.CalculationTime = d
+	t.Cleanup(func() { tutorial.CalculationTime = orig })
+}
+
+func TestSimpleFuture(t *testing.T) {
+	setCalculationTime(t, time.Millisecond)
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	if v := <-tutorial.SimpleFuture(1); v != 2 {
+		t.Errorf("SimpleFuture(1) delivered %d, want 2", v)
+	}
+	// A future nobody reads must not leak its goroutine either.
+	tutorial.SimpleFuture(1)
+}
+
+func TestMultiReadFuture(t *testing.T) {
+	setCalculationTime(t, time.Millisecond)
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	read, stop := tutorial.MultiReadFuture(1)
+	defer stop()
+	for i := 0; i < 3; i++ {
+		if v := read(); v != 4 {
+			t.Errorf("read %d returned %d, want 4", i+1, v)
+		}
+	}
+}
+
+func TestMultiReadFutureStop(t *testing.T) {
+	setCalculationTime(t, time.Hour)
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	read, stop := tutorial.MultiReadFuture(1)
+	stop()
+	stop()
+	if v := read(); v != 0 {
+		t.Errorf("read after stop returned %d, want 0", v)
+	}
+}
+
+func TestTimeoutFuture(t *testing.T) {
+	setCalculationTime(t, 50*time.Millisecond)
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	get, stop := tutorial.TimeoutFuture(1)
+	defer stop()
+	if v, timedOut := get(time.Millisecond); !timedOut || v != 0 {
+		t.Errorf("get(1ms) = %d, %v; want a timeout", v, timedOut)
+	}
+	if v, timedOut := get(time.Second); timedOut || v != 8 {
+		t.Errorf("get(1s) = %d, %v; want 8", v, timedOut)
+	}
+}
+
+func TestTimeoutFutureStop(t *testing.T) {
+	setCalculationTime(t, time.Hour)
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	get, stop := tutorial.TimeoutFuture(1)
+	stop()
+	start := time.Now()
+	if _, timedOut := get(time.Minute); !timedOut {
+		t.Error("get after stop did not time out")
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("get after stop waited %v", d)
+	}
+}
@@ -0,0 +1,87 @@
+package futures
+
+import (
+	"context"
+	"sync"
+)
+
+// Revision is a value published by an Updatable, together with its
+// revision number. Revisions start at 1 and grow by one with each Set;
+// revision 0 means that no value has been published.
+type Revision[T any] struct {
+	Value T
+	Rev   uint64
+}
+
+// Updatable holds a value that changes over time and lets readers wait for
+// a newer one, like long polling in process. Each reader remembers the
+// revision it saw last and asks for anything newer:
+//
+//	var rev uint64
+//	for {
+//		v, r, _, err := u.GetIfNewer(ctx, rev)
+//		if err != nil {
+//			return err
+//		}
+//		rev = r
+//		apply(v)
+//	}
+//
+// Readers that fall behind skip intermediate revisions and get the latest
+// one. The zero value is not usable; use NewUpdatable.
+type Updatable[T any] struct {
+	mu  sync.Mutex
+	cur Revision[T]
+	// changed is closed and replaced by Set.
+	changed chan struct{}
+}
+
+// NewUpdatable returns an Updatable without a value.
+func NewUpdatable[T any]() *Updatable[T] {
+	return &Updatable[T]{changed: make(chan struct{})}
+}
+
+// Set publishes v under the next revision, wakes all readers waiting in
+// GetIfNewer, and returns the new revision.
+func (u *Updatable[T]) Set(v T) uint64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.cur = Revision[T]{Value: v, Rev: u.cur.Rev + 1}
+	close(u.changed)
+	u.changed = make(chan struct{})
+	return u.cur.Rev
+}
+
+// Current returns the latest value and its revision.
+func (u *Updatable[T]) Current() Revision[T] {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.cur
+}
+
+// TryGetIfNewer returns the latest value and its revision if it is newer
+// than lastRev. Otherwise, it returns right away with notModified set.
+func (u *Updatable[T]) TryGetIfNewer(lastRev uint64) (v T, rev uint64, notModified bool) {
+	cur := u.Current()
+	return cur.Value, cur.Rev, cur.Rev <= lastRev
+}
+
+// GetIfNewer is like TryGetIfNewer but blocks until a value newer than
+// lastRev has been published. If ctx is done first, GetIfNewer returns
+// the latest value with notModified set and ErrCanceled or ErrTimeout,
+// depending on ctx.Err().
+func (u *Updatable[T]) GetIfNewer(ctx context.Context, lastRev uint64) (v T, rev uint64, notModified bool, err error) {
+	for {
+		u.mu.Lock()
+		cur, changed := u.cur, u.changed
+		u.mu.Unlock()
+		if cur.Rev > lastRev {
+			return cur.Value, cur.Rev, false, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return cur.Value, cur.Rev, true, contextError(ctx)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestUpdatableRevisions(t *testing.T) {
+	u := futures.NewUpdatable[string]()
+	if cur := u.Current(); cur.Rev != 0 || cur.Value != "" {
+		t.Errorf("Current() = %+v before Set, want revision 0", cur)
+	}
+	if _, _, notModified := u.TryGetIfNewer(0); !notModified {
+		t.Error("TryGetIfNewer(0) reported a value before Set")
+	}
+	if rev := u.Set("a"); rev != 1 {
+		t.Errorf("first Set returned revision %d, want 1", rev)
+	}
+	if rev := u.Set("b"); rev != 2 {
+		t.Errorf("second Set returned revision %d, want 2", rev)
+	}
+	if v, rev, notModified := u.TryGetIfNewer(0); v != "b" || rev != 2 || notModified {
+		t.Errorf("TryGetIfNewer(0) = %q, %d, %v; want the latest value", v, rev, notModified)
+	}
+	if v, rev, notModified := u.TryGetIfNewer(2); v != "b" || rev != 2 || !notModified {
+		t.Errorf("TryGetIfNewer(2) = %q, %d, %v; want not modified", v, rev, notModified)
+	}
+}
+
+func TestUpdatableGetIfNewerWaits(t *testing.T) {
+	u := futures.NewUpdatable[int]()
+	u.Set(10)
+	got := make(chan futures.Revision[int])
+	go func() {
+		v, rev, _, _ := u.GetIfNewer(context.Background(), 1)
+		got <- futures.Revision[int]{Value: v, Rev: rev}
+	}()
+	select {
+	case r := <-got:
+		t.Fatalf("GetIfNewer returned %+v without a newer value", r)
+	case <-time.After(10 * time.Millisecond):
+	}
+	u.Set(20)
+	if r := <-got; r.Value != 20 || r.Rev != 2 {
+		t.Errorf("GetIfNewer = %+v, want 20 at revision 2", r)
+	}
+}
+
+func TestUpdatableGetIfNewerContext(t *testing.T) {
+	u := futures.NewUpdatable[int]()
+	u.Set(10)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	v, rev, notModified, err := u.GetIfNewer(ctx, 1)
+	if !errors.Is(err, futures.ErrTimeout) {
+		t.Errorf("err = %v, want ErrTimeout", err)
+	}
+	if v != 10 || rev != 1 || !notModified {
+		t.Errorf("GetIfNewer = %d, %d, %v; want the current value, not modified", v, rev, notModified)
+	}
+}
+
+// TestUpdatableConcurrentSet races several writers against many
+// long-polling readers. Each reader must see strictly increasing
+// revisions, each with the value published under it, and end up at the
+// last revision.
+func TestUpdatableConcurrentSet(t *testing.T) {
+	const writers, sets, readers = 4, 100, 20
+	u := futures.NewUpdatable[int]()
+	var published sync.Map // revision -> value
+
+	seen := make([][]futures.Revision[int], readers)
+	var wg sync.WaitGroup
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			var last uint64
+			for last < writers*sets {
+				v, rev, notModified, err := u.GetIfNewer(context.Background(), last)
+				if err != nil || notModified || rev <= last {
+					t.Errorf("reader %d: GetIfNewer(%d) = %d, %d, %v, %v", r, last, v, rev, notModified, err)
+					return
+				}
+				seen[r] = append(seen[r], futures.Revision[int]{Value: v, Rev: rev})
+				last = rev
+			}
+		}(r)
+	}
+	var writing sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		writing.Add(1)
+		go func(w int) {
+			defer writing.Done()
+			for i := 0; i < sets; i++ {
+				v := w*sets + i
+				published.Store(u.Set(v), v)
+			}
+		}(w)
+	}
+	writing.Wait()
+	wg.Wait()
+	for r, revs := range seen {
+		for _, rv := range revs {
+			if want, _ := published.Load(rv.Rev); want != rv.Value {
+				t.Errorf("reader %d saw %d at revision %d, which published %v", r, rv.Value, rv.Rev, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package futures
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrVersionConflict is returned by VersionedCache.Set if the entry has been
+// updated since the caller read it.
+var ErrVersionConflict = errors.New("futures: version conflict")
+
+// Versioned is a value together with the version it was stored under.
+type Versioned[V any] struct {
+	Value   V
+	Version int64
+}
+
+// VersionedCache is a cache with optimistic concurrency control.
+//
+// Every entry carries a version that is incremented on each update. To
+// update an entry, read it with Get, then pass the version you read to Set.
+// If another writer got there first, Set fails with ErrVersionConflict and
+// the caller can re-read and retry. This way, concurrent updates never get
+// lost silently.
+type VersionedCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]Versioned[V]
+}
+
+// NewVersionedCache returns an empty VersionedCache.
+func NewVersionedCache[K comparable, V any]() *VersionedCache[K, V] {
+	return &VersionedCache[K, V]{entries: map[K]Versioned[V]{}}
+}
+
+// Get returns a Future for the entry stored under k. A key that was never
+// set resolves to the zero value with version 0.
+func (c *VersionedCache[K, V]) Get(k K) *Future[Versioned[V]] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Completed(c.entries[k])
+}
+
+// Set stores v under k if the current version of the entry equals version,
+// and increments the version. Otherwise the returned Future fails with
+// ErrVersionConflict. Use version 0 to create an entry.
+func (c *VersionedCache[K, V]) Set(k K, v V, version int64) *Future[struct{}] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cur := c.entries[k]
+	if cur.Version != version {
+		return Failed[struct{}](fmt.Errorf("%w: current version is %d, not %d", ErrVersionConflict, cur.Version, version))
+	}
+	c.entries[k] = Versioned[V]{Value: v, Version: version + 1}
+	return Completed(struct{}{})
+}
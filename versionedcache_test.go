@@ -0,0 +1,72 @@
+package futures_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestVersionedCacheGetAbsent(t *testing.T) {
+	c := futures.NewVersionedCache[string, int]()
+	got, err := c.Get("missing").Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got != (futures.Versioned[int]{}) {
+		t.Errorf("Get = %+v, want zero value with version 0", got)
+	}
+}
+
+func TestVersionedCacheSet(t *testing.T) {
+	c := futures.NewVersionedCache[string, int]()
+	if _, err := c.Set("k", 1, 0).Get(); err != nil {
+		t.Fatalf("Set with version 0: %v", err)
+	}
+	if _, err := c.Set("k", 2, 0).Get(); !errors.Is(err, futures.ErrVersionConflict) {
+		t.Fatalf("Set with stale version: err = %v, want ErrVersionConflict", err)
+	}
+	if _, err := c.Set("k", 2, 1).Get(); err != nil {
+		t.Fatalf("Set with current version: %v", err)
+	}
+	got, _ := c.Get("k").Get()
+	if got.Value != 2 || got.Version != 2 {
+		t.Errorf("Get = %+v, want {Value:2 Version:2}", got)
+	}
+}
+
+func TestVersionedCacheConcurrentSet(t *testing.T) {
+	c := futures.NewVersionedCache[string, int]()
+	const writers = 50
+
+	var wg sync.WaitGroup
+	wins := make(chan int, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := c.Set("k", i, 0).Get()
+			switch {
+			case err == nil:
+				wins <- i
+			case !errors.Is(err, futures.ErrVersionConflict):
+				t.Errorf("writer %d: unexpected error %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(wins)
+
+	var winners []int
+	for i := range wins {
+		winners = append(winners, i)
+	}
+	if len(winners) != 1 {
+		t.Fatalf("%d writers won, want exactly 1", len(winners))
+	}
+	got, _ := c.Get("k").Get()
+	if got.Value != winners[0] || got.Version != 1 {
+		t.Errorf("Get = %+v, want {Value:%d Version:1}", got, winners[0])
+	}
+}
@@ -0,0 +1,96 @@
+package futures
+
+import "sync"
+
+// Watcher recomputes a Future whenever its input changes, like a computed
+// property in a reactive framework.
+type Watcher[T any] struct {
+	mu      sync.Mutex
+	cur     *Future[T]
+	results *Stream[*Future[T]]
+}
+
+// NewWatcher returns a Watcher that calls fn for each value received from
+// input. When a new value arrives while the previous computation is still
+// pending, the previous Future is canceled.
+//
+// The Watcher stops once input is closed and the last computation has
+// settled. A panic in fn, or fn returning a nil Future, makes the
+// computation fail.
+//
+// The options configure the stream returned by Results. By default, it
+// keeps only the latest result, so a slow consumer never holds up
+// recomputation.
+func NewWatcher[In, T any](input <-chan In, fn func(In) *Future[T], opts ...StreamOption) *Watcher[T] {
+	opts = append([]StreamOption{WithResultBuffer(1), WithOverflow(OverflowDropOldest)}, opts...)
+	w := &Watcher[T]{
+		cur:     newFuture[T](),
+		results: newStream[*Future[T]](newStreamOptions(1, opts)),
+	}
+	go watch(w, input, fn)
+	return w
+}
+
+// Current returns the Future for the latest input. Before the first
+// computation has settled, Current returns a Future that settles with the
+// first result that is not superseded by a newer input. If input is closed
+// without any value, that Future fails with ErrNoFutures.
+func (w *Watcher[T]) Current() *Future[T] {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.cur
+}
+
+// Results returns a stream of the computations that settled without being
+// superseded, in input order. The stream ends when the Watcher stops.
+func (w *Watcher[T]) Results() *Stream[*Future[T]] {
+	return w.results
+}
+
+// watch runs the Watcher w.
+func watch[In, T any](w *Watcher[T], input <-chan In, fn func(In) *Future[T]) {
+	first := w.cur
+	var cur *Future[T]
+	var curDone <-chan struct{}
+	defer w.results.close(nil)
+	for input != nil || curDone != nil {
+		select {
+		case in, ok := <-input:
+			if !ok {
+				input = nil
+				continue
+			}
+			if cur != nil {
+				cur.Cancel()
+			}
+			cur = attemptFuture(func() *Future[T] { return fn(in) })
+			curDone = cur.Done()
+			// Until the first result is known, Current keeps returning
+			// the placeholder.
+			if isSettled(first) {
+				w.mu.Lock()
+				w.cur = cur
+				w.mu.Unlock()
+			}
+		case <-curDone:
+			curDone = nil
+			first.settle(cur.core.value, cur.core.err)
+			w.mu.Lock()
+			w.cur = cur
+			w.mu.Unlock()
+			w.results.send(cur)
+		}
+	}
+	var zero T
+	first.settle(zero, ErrNoFutures)
+}
+
+// isSettled reports whether f has settled, without marking it as awaited.
+func isSettled[T any](f *Future[T]) bool {
+	select {
+	case <-f.core.done:
+		return true
+	default:
+		return false
+	}
+}
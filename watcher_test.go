@@ -0,0 +1,80 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestWatcher(t *testing.T) {
+	input := make(chan int)
+	w := futures.NewWatcher(input, func(in int) *futures.Future[int] {
+		return futures.Completed(in * 10)
+	})
+	for in := 1; in <= 3; in++ {
+		input <- in
+		want := in * 10
+		eventually(t, func() bool {
+			v, err := w.Current().Get()
+			return v == want && err == nil
+		}, "Current does not reflect the latest input")
+	}
+	close(input)
+	for range w.Results().C() {
+	}
+	if v, _ := w.Current().Get(); v != 30 {
+		t.Errorf("Current after stop = %d, want 30", v)
+	}
+}
+
+func TestWatcherCancelsSuperseded(t *testing.T) {
+	input := make(chan int)
+	var started []*futures.Future[int]
+	w := futures.NewWatcher(input, func(in int) *futures.Future[int] {
+		f := futures.New(func(ctx context.Context) (int, error) {
+			if in == 3 {
+				return in, nil
+			}
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+		started = append(started, f)
+		return f
+	}, futures.WithResultBuffer(3), futures.WithOverflow(futures.OverflowBlock))
+
+	// The placeholder settles with the first result that is not
+	// superseded.
+	first := w.Current()
+	input <- 1
+	input <- 2
+	input <- 3
+	close(input)
+
+	if v, err := first.Get(); v != 3 || err != nil {
+		t.Errorf("first Current = %d, %v; want 3, nil", v, err)
+	}
+	var results []int
+	for f := range w.Results().C() {
+		v, _ := f.Get()
+		results = append(results, v)
+	}
+	if len(results) != 1 || results[0] != 3 {
+		t.Errorf("results = %v, want [3]", results)
+	}
+	for i, f := range started[:2] {
+		if err := f.Wait(context.Background()); !errors.Is(err, futures.ErrCanceled) {
+			t.Errorf("computation %d: err = %v, want ErrCanceled", i+1, err)
+		}
+	}
+}
+
+func TestWatcherNoInput(t *testing.T) {
+	input := make(chan int)
+	w := futures.NewWatcher(input, func(in int) *futures.Future[int] { return futures.Completed(in) })
+	close(input)
+	if _, err := w.Current().Get(); !errors.Is(err, futures.ErrNoFutures) {
+		t.Errorf("Current: err = %v, want ErrNoFutures", err)
+	}
+}
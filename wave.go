@@ -0,0 +1,119 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrWaveClosed is the error of futures started on a Wave after Close.
+var ErrWaveClosed = errors.New("futures: wave closed")
+
+// waveGrace bounds how long Wave.Close waits for canceled computations to
+// return.
+const waveGrace = time.Second
+
+// Wave is one round of a polling or reconciliation loop. Start the round's
+// computations with Go, await them, and call Close before the next round
+// starts. Close cancels whatever is still pending, so that stale work
+// never carries over into the next round.
+//
+// The computations of a Wave run with a context that is done at the wave's
+// deadline, so a Future of the Wave fails with ErrTimeout at the latest at
+// the deadline.
+type Wave[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	opts   []Option
+
+	mu      sync.Mutex
+	futs    []*Future[T]
+	closed  bool
+	report  WaveReport
+	running sync.WaitGroup
+	active  atomic.Int64
+}
+
+// WaveReport summarizes a closed Wave.
+type WaveReport struct {
+	// Finished counts the futures that settled by themselves, successfully
+	// or not.
+	Finished int
+	// Canceled counts the futures that were canceled by Close or failed
+	// because the wave's deadline passed.
+	Canceled int
+	// Lingering counts the computations that had not returned when Close
+	// stopped waiting for them.
+	Lingering int
+}
+
+// NewWave starts a Wave whose computations run with a context derived from
+// ctx that is done at deadline. The options apply to all futures of the
+// Wave; the clock set by WithClock also times the wait in Close.
+func NewWave[T any](ctx context.Context, deadline time.Time, opts ...Option) *Wave[T] {
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	return &Wave[T]{ctx: ctx, cancel: cancel, opts: opts}
+}
+
+// Go runs fn as part of w and returns a Future for its result. opts are
+// added to the options of the Wave. After Close, the Future fails with
+// ErrWaveClosed and fn does not run.
+func (w *Wave[T]) Go(fn func(ctx context.Context) (T, error), opts ...Option) *Future[T] {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return Failed[T](ErrWaveClosed)
+	}
+	all := append(w.opts[:len(w.opts):len(w.opts)], opts...)
+	inner := newOptions(all).executor
+	exec := ExecutorFunc(func(run func()) {
+		w.running.Add(1)
+		w.active.Add(1)
+		inner.Go(func() {
+			defer w.running.Done()
+			defer w.active.Add(-1)
+			run()
+		})
+	})
+	f := NewWithContext(w.ctx, fn, append(all, WithExecutor(exec))...)
+	w.futs = append(w.futs, f)
+	return f
+}
+
+// Close ends the Wave. It cancels the futures that are still pending,
+// waits a bounded time for their computations to return, and reports the
+// outcome. Calling Close again returns the same report.
+func (w *Wave[T]) Close() WaveReport {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return w.report
+	}
+	w.closed = true
+	for _, f := range w.futs {
+		if isSettled(f) && !errors.Is(f.core.err, ErrTimeout) {
+			w.report.Finished++
+		} else {
+			w.report.Canceled++
+		}
+	}
+	w.cancel()
+
+	returned := make(chan struct{})
+	go func() {
+		w.running.Wait()
+		close(returned)
+	}()
+	expired := make(chan struct{})
+	t := newOptions(w.opts).clock.AfterFunc(waveGrace, func() { close(expired) })
+	defer t.Stop()
+	select {
+	case <-returned:
+	case <-expired:
+		w.report.Lingering = int(w.active.Load())
+	}
+	w.futs = nil
+	return w.report
+}
@@ -0,0 +1,92 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+
+	"github.com/appliedgo/futures"
+	"github.com/appliedgo/futures/futuretest"
+)
+
+func TestWaveReport(t *testing.T) {
+	w := futures.NewWave[int](context.Background(), time.Now().Add(time.Minute))
+	quick := w.Go(func(ctx context.Context) (int, error) { return 1, nil })
+	failing := w.Go(func(ctx context.Context) (int, error) { return 0, errors.New("boom") })
+	stuck := w.Go(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	quick.Wait(context.Background())
+	failing.Wait(context.Background())
+
+	got := w.Close()
+	want := futures.WaveReport{Finished: 2, Canceled: 1}
+	if got != want {
+		t.Errorf("Close() = %+v, want %+v", got, want)
+	}
+	if _, err := stuck.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("straggler: err = %v, want ErrCanceled", err)
+	}
+	if again := w.Close(); again != want {
+		t.Errorf("second Close() = %+v, want %+v", again, want)
+	}
+	if _, err := w.Go(func(ctx context.Context) (int, error) { return 1, nil }).Get(); !errors.Is(err, futures.ErrWaveClosed) {
+		t.Errorf("Go after Close: err = %v, want ErrWaveClosed", err)
+	}
+}
+
+func TestWaveDeadline(t *testing.T) {
+	w := futures.NewWave[int](context.Background(), time.Now().Add(10*time.Millisecond))
+	f := w.Go(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	if _, err := f.Get(); !errors.Is(err, futures.ErrTimeout) {
+		t.Errorf("err = %v, want ErrTimeout", err)
+	}
+	if got := w.Close(); got.Canceled != 1 || got.Finished != 0 {
+		t.Errorf("Close() = %+v, want one canceled future", got)
+	}
+}
+
+func TestWaveLingering(t *testing.T) {
+	clock := futuretest.NewFakeClock(time.Now())
+	w := futures.NewWave[int](context.Background(), time.Now().Add(time.Minute), futures.WithClock(clock))
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	w.Go(func(ctx context.Context) (int, error) {
+		close(started)
+		<-release // ignores ctx
+		return 0, nil
+	})
+	<-started
+
+	report := make(chan futures.WaveReport)
+	go func() { report <- w.Close() }()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	if got := <-report; got.Lingering != 1 {
+		t.Errorf("Close() = %+v, want one lingering computation", got)
+	}
+}
+
+func TestWaveNoLeaks(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	for i := 0; i < 10000; i++ {
+		w := futures.NewWave[int](context.Background(), time.Now().Add(time.Minute))
+		done := w.Go(func(ctx context.Context) (int, error) { return i, nil })
+		w.Go(func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+		done.Wait(context.Background())
+		if got := w.Close(); got.Lingering != 0 {
+			t.Fatalf("wave %d: Close() = %+v, want no lingering computations", i, got)
+		}
+	}
+}
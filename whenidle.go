@@ -0,0 +1,38 @@
+package futures
+
+import (
+	"context"
+	"runtime"
+	"time"
+)
+
+// idleBackoff is how long WhenIdle waits between looking at the number of
+// goroutines.
+var idleBackoff = ExponentialBackoff(time.Millisecond, 2, 100*time.Millisecond)
+
+// WhenIdle defers calling fn until fewer than maxGoroutines goroutines
+// exist, and returns a Future for the result of the Future that fn
+// returns. Use it to start work that is not urgent without adding to the
+// load of a busy program.
+//
+// WhenIdle looks at runtime.NumGoroutine right away and then at
+// intervals that grow from a millisecond to a tenth of a second. The
+// goroutine that waits counts towards the number. A panic in fn, or fn
+// returning a nil Future, fails the returned Future; a panic fails it
+// with a *PanicError.
+//
+// Canceling the returned Future stops waiting, or cancels the Future
+// returned by fn if fn has been called. Pass WithClock to replace the
+// clock used for waiting.
+func WhenIdle[T any](fn func() *Future[T], maxGoroutines int, opts ...Option) *Future[T] {
+	clock := newOptions(opts).clock
+	return New(func(ctx context.Context) (T, error) {
+		for attempt := 1; runtime.NumGoroutine() >= maxGoroutines; attempt++ {
+			if err := sleep(ctx, clock, idleBackoff.Delay(attempt)); err != nil {
+				var zero T
+				return zero, err
+			}
+		}
+		return awaitStep(ctx, attemptFuture(fn))
+	}, opts...)
+}
@@ -0,0 +1,87 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/appliedgo/futures"
+)
+
+// busy starts n goroutines that block until the returned function is
+// called, and returns once they are all running.
+func busy(n int) (release func()) {
+	stop := make(chan struct{})
+	var started sync.WaitGroup
+	started.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			started.Done()
+			<-stop
+		}()
+	}
+	started.Wait()
+	return func() { close(stop) }
+}
+
+func TestWhenIdleDefersUntilGoroutinesExit(t *testing.T) {
+	limit := runtime.NumGoroutine() + 50
+	release := busy(200)
+	var called atomic.Bool
+	f := futures.WhenIdle(func() *futures.Future[int] {
+		called.Store(true)
+		return futures.Completed(1)
+	}, limit)
+
+	time.Sleep(50 * time.Millisecond)
+	if called.Load() {
+		t.Fatal("fn was called while the program was busy")
+	}
+	release()
+	if v, err := f.Get(); v != 1 || err != nil {
+		t.Errorf("Get = %d, %v; want 1, nil", v, err)
+	}
+}
+
+func TestWhenIdleStartsRightAwayWhenIdle(t *testing.T) {
+	f := futures.WhenIdle(func() *futures.Future[int] {
+		return futures.Completed(1)
+	}, runtime.NumGoroutine()+50)
+	select {
+	case <-f.Done():
+	case <-time.After(50 * time.Millisecond):
+		t.Fatal("WhenIdle waited although the program was idle")
+	}
+}
+
+func TestWhenIdleCancel(t *testing.T) {
+	release := busy(10)
+	defer release()
+	var called atomic.Bool
+	f := futures.WhenIdle(func() *futures.Future[int] {
+		called.Store(true)
+		return futures.Completed(1)
+	}, 1)
+	f.Cancel()
+	if _, err := f.Get(); !errors.Is(err, futures.ErrCanceled) {
+		t.Errorf("err = %v, want ErrCanceled", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if called.Load() {
+		t.Error("fn was called after cancellation")
+	}
+}
+
+func TestWhenIdleFnFails(t *testing.T) {
+	_, err := futures.WhenIdle(func() *futures.Future[int] {
+		return futures.New(func(ctx context.Context) (int, error) { panic("bad") })
+	}, runtime.NumGoroutine()+50).Get()
+	var pe *futures.PanicError
+	if !errors.As(err, &pe) {
+		t.Errorf("err = %v, want a PanicError", err)
+	}
+}
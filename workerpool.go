@@ -0,0 +1,117 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrPoolClosed is the error of tasks submitted to a pool that has been
+// closed.
+var ErrPoolClosed = errors.New("futures: pool closed")
+
+// workerPool runs functions on a number of worker goroutines. Functions
+// beyond the number of workers wait in a queue.
+type workerPool struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  []func()
+	closed bool
+	// target is the number of workers the pool should have, running the
+	// number it has. Surplus workers exit when they are idle.
+	target  int
+	running int
+	workers sync.WaitGroup
+}
+
+func newWorkerPool(workers int) *workerPool {
+	p := &workerPool{}
+	p.cond = sync.NewCond(&p.mu)
+	p.resize(workers)
+	return p
+}
+
+// resize sets the number of workers to n. Workers are started right
+// away; surplus workers finish their current function first.
+func (p *workerPool) resize(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.target = n
+	for ; p.running < n; p.running++ {
+		p.workers.Add(1)
+		go p.work()
+	}
+	p.cond.Broadcast()
+}
+
+// size returns the number of workers the pool is set to have.
+func (p *workerPool) size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.target
+}
+
+// enqueue queues fn. It returns false if the pool is closed.
+func (p *workerPool) enqueue(fn func()) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return false
+	}
+	p.queue = append(p.queue, fn)
+	p.cond.Signal()
+	return true
+}
+
+func (p *workerPool) work() {
+	defer p.workers.Done()
+	for {
+		p.mu.Lock()
+		for len(p.queue) == 0 && !p.closed && p.running <= p.target {
+			p.cond.Wait()
+		}
+		if len(p.queue) == 0 || p.running > p.target {
+			p.running--
+			p.mu.Unlock()
+			return
+		}
+		fn := p.queue[0]
+		p.queue[0] = nil
+		p.queue = p.queue[1:]
+		p.mu.Unlock()
+		runStrict(StrictWorkers, "a pool worker", fn)
+	}
+}
+
+// queued returns the number of functions waiting for a worker.
+func (p *workerPool) queued() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.queue)
+}
+
+// submit runs fn on the pool and returns a Future for its result. If the
+// pool is closed, the Future fails with ErrPoolClosed.
+func submit[T any](p *workerPool, fn func(ctx context.Context) (T, error), opts []Option) *Future[T] {
+	rejected := false
+	exec := ExecutorFunc(func(run func()) {
+		rejected = !p.enqueue(run)
+	})
+	f := New(fn, append(opts[:len(opts):len(opts)], WithExecutor(exec))...)
+	if rejected {
+		var zero T
+		f.settle(zero, ErrPoolClosed)
+		f.cancel(ErrPoolClosed)
+	}
+	return f
+}
+
+// close stops accepting functions and waits until the queued ones have
+// run.
+func (p *workerPool) close() {
+	p.mu.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+	p.workers.Wait()
+}
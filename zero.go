@@ -0,0 +1,27 @@
+package futures
+
+import "reflect"
+
+// IsZeroResolved reports whether f has resolved successfully to the zero
+// value of T, such as a nil pointer or nil interface. It returns false
+// while f is pending and if f has failed. IsZeroResolved does not block.
+func IsZeroResolved[T any](f *Future[T]) bool {
+	v, err, ok := f.TryGet()
+	return ok && err == nil && isZero(v)
+}
+
+// isZero reports whether v is the zero value of T. Comparable types are
+// compared with ==, which cannot panic here because the interface values
+// inside a zero value are all nil; only the other types need reflect.
+func isZero[T any](v T) bool {
+	var zero T
+	z := any(zero)
+	if z == nil {
+		// T is an interface type.
+		return any(v) == nil
+	}
+	if reflect.TypeOf(z).Comparable() {
+		return any(v) == z
+	}
+	return reflect.ValueOf(v).IsZero()
+}
@@ -0,0 +1,68 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+type point struct{ X, Y int }
+
+type tagged struct {
+	Tag any
+	N   int
+}
+
+// zeroCase runs the WithRequireNonZero and IsZeroResolved checks for T.
+func zeroCase[T any](t *testing.T, name string, zero, nonZero T) {
+	t.Run(name, func(t *testing.T) {
+		for _, c := range []struct {
+			v    T
+			zero bool
+		}{{zero, true}, {nonZero, false}} {
+			v := c.v
+			plain := futures.New(func(context.Context) (T, error) { return v, nil })
+			plain.Wait(context.Background())
+			if got := futures.IsZeroResolved(plain); got != c.zero {
+				t.Errorf("IsZeroResolved(%v) = %v, want %v", v, got, c.zero)
+			}
+			strict := futures.New(func(context.Context) (T, error) { return v, nil }, futures.WithRequireNonZero())
+			_, err := strict.Get()
+			if c.zero != errors.Is(err, futures.ErrZeroValue) {
+				t.Errorf("WithRequireNonZero: %v resolved with err = %v", v, err)
+			}
+		}
+	})
+}
+
+func TestRequireNonZero(t *testing.T) {
+	zeroCase(t, "Pointer", nil, &point{})
+	zeroCase[fmt.Stringer](t, "Interface", nil, futures.Resolved)
+	zeroCase[any](t, "InterfaceNonComparable", nil, []int{})
+	zeroCase(t, "Slice", nil, []int{})
+	zeroCase(t, "Struct", point{}, point{X: 1})
+	zeroCase(t, "StructWithInterface", tagged{}, tagged{Tag: []int{1}})
+	zeroCase(t, "StructNonComparable", struct{ S []int }{}, struct{ S []int }{S: []int{}})
+}
+
+func TestRequireNonZeroPromise(t *testing.T) {
+	p := futures.NewPromise[*point](futures.WithRequireNonZero())
+	p.Resolve(nil)
+	if _, err := p.Future().Get(); !errors.Is(err, futures.ErrZeroValue) {
+		t.Errorf("err = %v, want ErrZeroValue", err)
+	}
+}
+
+func TestIsZeroResolvedPendingOrFailed(t *testing.T) {
+	p := futures.NewPromise[*point]()
+	if futures.IsZeroResolved(p.Future()) {
+		t.Error("IsZeroResolved is true for a pending Future")
+	}
+	p.Reject(errors.New("boom"))
+	if futures.IsZeroResolved(p.Future()) {
+		t.Error("IsZeroResolved is true for a failed Future")
+	}
+}
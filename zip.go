@@ -0,0 +1,83 @@
+package futures
+
+import "sync/atomic"
+
+// Pair holds the values of two futures combined by Zip.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Triple holds the values of three futures combined by Zip3.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Quad holds the values of four futures combined by Zip4.
+type Quad[A, B, C, D any] struct {
+	First  A
+	Second B
+	Third  C
+	Fourth D
+}
+
+// Zip returns a Future that resolves to the values of fa and fb once both
+// have resolved. Both futures are awaited concurrently. If either fails,
+// the returned Future fails with the first error that occurs, without
+// waiting for the other one.
+func Zip[A, B any](fa *Future[A], fb *Future[B]) *Future[Pair[A, B]] {
+	out := newFuture[Pair[A, B]]()
+	whenAll(out, []waitable{fa, fb}, func() Pair[A, B] {
+		return Pair[A, B]{fa.core.value, fb.core.value}
+	})
+	return out
+}
+
+// Zip3 is like Zip for three futures.
+func Zip3[A, B, C any](fa *Future[A], fb *Future[B], fc *Future[C]) *Future[Triple[A, B, C]] {
+	out := newFuture[Triple[A, B, C]]()
+	whenAll(out, []waitable{fa, fb, fc}, func() Triple[A, B, C] {
+		return Triple[A, B, C]{fa.core.value, fb.core.value, fc.core.value}
+	})
+	return out
+}
+
+// Zip4 is like Zip for four futures.
+func Zip4[A, B, C, D any](fa *Future[A], fb *Future[B], fc *Future[C], fd *Future[D]) *Future[Quad[A, B, C, D]] {
+	out := newFuture[Quad[A, B, C, D]]()
+	whenAll(out, []waitable{fa, fb, fc, fd}, func() Quad[A, B, C, D] {
+		return Quad[A, B, C, D]{fa.core.value, fb.core.value, fc.core.value, fd.core.value}
+	})
+	return out
+}
+
+// waitable is implemented by a Future of any type.
+type waitable interface {
+	Done() <-chan struct{}
+	Err() error
+}
+
+// whenAll settles out with the result of combine once all of futs have
+// resolved, or with the first error of any of them.
+func whenAll[R any](out *Future[R], futs []waitable, combine func() R) {
+	var remaining atomic.Int32
+	remaining.Store(int32(len(futs)))
+	for _, f := range futs {
+		go func(f waitable) {
+			select {
+			case <-f.Done():
+				if err := f.Err(); err != nil {
+					var zero R
+					out.settle(zero, err)
+					return
+				}
+				if remaining.Add(-1) == 0 {
+					out.settle(combine(), nil)
+				}
+			case <-out.core.done:
+			}
+		}(f)
+	}
+}
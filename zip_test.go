@@ -0,0 +1,43 @@
+package futures_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/appliedgo/futures"
+)
+
+func TestZip(t *testing.T) {
+	fa := futures.New(func(ctx context.Context) (int, error) { return 1, nil })
+	fb := futures.New(func(ctx context.Context) (string, error) { return "b", nil })
+	got, err := futures.Zip(fa, fb).Get()
+	if err != nil || got != (futures.Pair[int, string]{First: 1, Second: "b"}) {
+		t.Fatalf("Zip = %+v, %v", got, err)
+	}
+}
+
+func TestZip3AndZip4(t *testing.T) {
+	got3, err := futures.Zip3(futures.Completed(1), futures.Completed("b"), futures.Completed(true)).Get()
+	if err != nil || got3 != (futures.Triple[int, string, bool]{1, "b", true}) {
+		t.Errorf("Zip3 = %+v, %v", got3, err)
+	}
+	got4, err := futures.Zip4(futures.Completed(1), futures.Completed("b"), futures.Completed(true), futures.Completed(2.5)).Get()
+	if err != nil || got4 != (futures.Quad[int, string, bool, float64]{1, "b", true, 2.5}) {
+		t.Errorf("Zip4 = %+v, %v", got4, err)
+	}
+}
+
+func TestZipFailsFast(t *testing.T) {
+	errBoom := errors.New("boom")
+	release := make(chan struct{})
+	defer close(release)
+	never := futures.New(func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	_, err := futures.Zip(never, futures.Failed[string](errBoom)).Get()
+	if err != errBoom {
+		t.Fatalf("Zip: err = %v, want %v", err, errBoom)
+	}
+}